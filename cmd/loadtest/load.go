@@ -0,0 +1,150 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/simulator"
+)
+
+// Kinds of requests
+const (
+	kindMessage = "message" // POST /messages
+	kindGet     = "get"     // GET /rockets/{id}
+	kindList    = "list"    // GET /rockets
+)
+
+// request is a request scheduled by the load, sent by a worker
+type request struct {
+	kind   string
+	method string
+	path   string
+	body   []byte
+}
+
+// load schedules the requests at a fixed rate, messages and reads interleaved, whatever the latency of the server
+type load struct {
+	opts     options
+	requests chan request
+	results  *results
+	start    time.Time
+	sent     int     // Requests scheduled so far
+	reads    float64 // Reads owed to the messages sent so far
+	timer    *time.Timer
+}
+
+// run sends the load to the server for the duration of the test, or until ctx is done, and returns its results
+func run(ctx context.Context, client *http.Client, opts options) *results {
+	ctx, cancel := context.WithTimeout(ctx, opts.Duration)
+	defer cancel()
+
+	l := &load{
+		opts:     opts,
+		requests: make(chan request, opts.Concurrency),
+		results:  newResults(),
+		start:    time.Now(),
+		timer:    time.NewTimer(0),
+	}
+	var wg sync.WaitGroup
+	for range opts.Concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for req := range l.requests {
+				l.send(client, req)
+			}
+		}()
+	}
+
+	// The simulated rockets fly one message per step, the load paces the messages
+	sim := simulator.New(l.publish, simulator.Options{Rockets: opts.Channels})
+	for ctx.Err() == nil {
+		sim.Step(ctx, time.Now())
+	}
+
+	close(l.requests)
+	wg.Wait()
+	l.results.elapsed = time.Since(l.start)
+	return l.results
+}
+
+// publish schedules a message of a simulated rocket, then the reads it owes to the mix
+func (l *load) publish(ctx context.Context, msg *models.RocketMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	message := request{kind: kindMessage, method: http.MethodPost, path: "/messages", body: body}
+	if err := l.schedule(ctx, message); err != nil {
+		return err
+	}
+
+	for l.reads += l.opts.Reads; l.reads >= 1; l.reads-- {
+		read := request{kind: kindGet, method: http.MethodGet, path: "/rockets/" + msg.Metadata.Channel}
+		// A rocket is only found once its launch is processed, which its first message may not be yet
+		if rand.Float64() < l.opts.ListShare || msg.Metadata.MessageNumber == 1 {
+			read = request{kind: kindList, method: http.MethodGet, path: "/rockets"}
+		}
+		if err := l.schedule(ctx, read); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schedule waits for the time of the next request and hands it to a worker. A request finding all the workers busy is
+// not sent, and counted, rather than delaying the next ones: the rate stays the one requested
+func (l *load) schedule(ctx context.Context, req request) error {
+	l.sent++
+	at := l.start.Add(time.Duration(float64(l.sent) * float64(time.Second) / (l.opts.Rate * (1 + l.opts.Reads))))
+	if wait := time.Until(at); wait > 0 {
+		l.timer.Reset(wait)
+		select {
+		case <-l.timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case l.requests <- req:
+	default:
+		l.results.unsent(req.kind)
+	}
+	return nil
+}
+
+// send sends a request and records its outcome
+func (l *load) send(client *http.Client, req request) {
+	httpReq, err := http.NewRequest(req.method, strings.TrimSuffix(l.opts.URL, "/")+req.path, bytes.NewReader(req.body))
+	if err != nil {
+		l.results.record(req.kind, 0, 0, err)
+		return
+	}
+	if req.body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if l.opts.APIKey != "" {
+		httpReq.Header.Set(middleware.APIKeyHeader, l.opts.APIKey)
+	}
+
+	start := time.Now()
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		l.results.record(req.kind, 0, time.Since(start), err)
+		return
+	}
+	// Reading the whole body lets the connection be reused, and accounts for the time to transfer it
+	_, err = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	l.results.record(req.kind, resp.StatusCode, time.Since(start), err)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunReportsDrops(t *testing.T) {
+	var messages atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		// Every tenth message finds the queue full
+		if messages.Add(1)%10 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	results := run(context.Background(), server.Client(), options{URL: server.URL, Rate: 200, Channels: 5,
+		Duration: 500 * time.Millisecond, Concurrency: 4, Reads: 0.5, ListShare: 0.5, Timeout: time.Second})
+
+	sent := results.kinds[kindMessage]
+	require.NotNil(t, sent)
+	assert.InDelta(t, 100, messages.Load(), 20, "messages are sent at the rate requested")
+	assert.Equal(t, int(messages.Load()/10), sent.statuses[http.StatusServiceUnavailable])
+	assert.Equal(t, int(messages.Load()-messages.Load()/10), sent.statuses[http.StatusAccepted])
+	reads := results.kinds[kindGet].statuses[http.StatusOK] + results.kinds[kindList].statuses[http.StatusOK]
+	assert.InDelta(t, messages.Load()/2, reads, 2, "a read is sent every other message")
+
+	var out bytes.Buffer
+	results.print(&out)
+	assert.Contains(t, out.String(), "DROPPED")
+	assert.NotContains(t, out.String(), "Failures")
+}
+
+func TestPercentile(t *testing.T) {
+	var latencies []time.Duration
+	for i := 1; i <= 100; i++ {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 0.5))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 0.99))
+	assert.Equal(t, 100*time.Millisecond, percentile(latencies, 1))
+	assert.Zero(t, percentile(nil, 0.5))
+}
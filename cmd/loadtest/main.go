@@ -0,0 +1,166 @@
+// Command loadtest drives a running server with the telemetry of simulated rockets at a given rate, mixed with reads
+// of the fleet, and reports the throughput, the latencies and the messages dropped. It validates the size of the
+// processing queue, PUBSUB_BUFFER_SIZE, against the load expected in production:
+//
+//	go run ./cmd/loadtest --rate 2000 --channels 200 --duration 1m --reads 0.2
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/middleware"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/pflag"
+)
+
+// options configures a load test
+type options struct {
+	URL         string        // Base URL of the server
+	APIKey      string        // Sent in the X-API-Key header, when the server requires one
+	Rate        float64       // Messages sent per second
+	Channels    int           // Rockets flying at once, each on its channel
+	Duration    time.Duration // Time messages are sent for
+	Concurrency int           // Requests in flight at most
+	Reads       float64       // Reads of the fleet per message sent
+	ListShare   float64       // Share of the reads listing the fleet, the others get a single rocket
+	Timeout     time.Duration // Time a request is waited for
+}
+
+func main() {
+	var opts options
+	flags := pflag.NewFlagSet("loadtest", pflag.ExitOnError)
+	flags.StringVar(&opts.URL, "url", "http://localhost:8088", "base URL of the server")
+	flags.StringVar(&opts.APIKey, "api-key", os.Getenv("ROCKETS_API_KEY"), "API key of the requests (ROCKETS_API_KEY)")
+	flags.Float64Var(&opts.Rate, "rate", 1000, "messages sent per second")
+	flags.IntVar(&opts.Channels, "channels", 100, "rockets flying at once, each on its channel")
+	flags.DurationVar(&opts.Duration, "duration", 30*time.Second, "time messages are sent for")
+	flags.IntVar(&opts.Concurrency, "concurrency", 64, "requests in flight at most")
+	flags.Float64Var(&opts.Reads, "reads", 0.1, "reads of the fleet per message sent")
+	flags.Float64Var(&opts.ListShare, "list-share", 0.2,
+		"share of the reads listing the fleet rather than getting a rocket")
+	flags.DurationVar(&opts.Timeout, "timeout", 5*time.Second, "time a request is waited for")
+	_ = flags.Parse(os.Args[1:])
+
+	if err := validate(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "loadtest:", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	client := &http.Client{
+		Timeout:   opts.Timeout,
+		Transport: &http.Transport{MaxIdleConnsPerHost: opts.Concurrency},
+	}
+	if err := checkHealth(ctx, client, opts); err != nil {
+		fmt.Fprintln(os.Stderr, "loadtest:", err)
+		os.Exit(1)
+	}
+	before, scrapeErr := scrapeDropped(ctx, client, opts)
+
+	results := run(ctx, client, opts)
+
+	fmt.Printf("Sent %.0f messages/s on %d channels with %.2f reads per message, to %s for %s\n\n",
+		opts.Rate, opts.Channels, opts.Reads, opts.URL, results.elapsed.Round(time.Millisecond))
+	results.print(os.Stdout)
+
+	// The server counts the messages it received but did not apply, the queue overflowing among other reasons
+	fmt.Println()
+	after, err := scrapeDropped(context.Background(), client, opts)
+	if scrapeErr != nil {
+		err = scrapeErr
+	}
+	if err != nil {
+		fmt.Printf("Messages dropped by the server: unknown, %v\n", err)
+		return
+	}
+	printDropped(os.Stdout, before, after)
+}
+
+// validate rejects the options that cannot drive a load
+func validate(opts options) error {
+	switch {
+	case opts.Rate <= 0:
+		return fmt.Errorf("--rate must be positive, got %g", opts.Rate)
+	case opts.Channels <= 0:
+		return fmt.Errorf("--channels must be positive, got %d", opts.Channels)
+	case opts.Duration <= 0:
+		return fmt.Errorf("--duration must be positive, got %s", opts.Duration)
+	case opts.Concurrency <= 0:
+		return fmt.Errorf("--concurrency must be positive, got %d", opts.Concurrency)
+	case opts.Reads < 0:
+		return fmt.Errorf("--reads must not be negative, got %g", opts.Reads)
+	case opts.ListShare < 0 || opts.ListShare > 1:
+		return fmt.Errorf("--list-share must be between 0 and 1, got %g", opts.ListShare)
+	}
+	return nil
+}
+
+// checkHealth fails unless the server is up, rather than reporting a test of which every request failed
+func checkHealth(ctx context.Context, client *http.Client, opts options) error {
+	resp, err := get(ctx, client, opts, "/health")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("server unhealthy: %s", resp.Status)
+	}
+	return nil
+}
+
+// scrapeDropped returns the messages dropped by the server so far by reason, from its Prometheus metrics
+func scrapeDropped(ctx context.Context, client *http.Client, opts options) (map[string]float64, error) {
+	resp, err := get(ctx, client, opts, "/metrics")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metrics unavailable: %s", resp.Status)
+	}
+
+	var parser expfmt.TextParser
+	families, err := parser.TextToMetricFamilies(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading metrics: %w", err)
+	}
+	dropped := map[string]float64{}
+	if family, ok := families["rockets_messages_dropped_total"]; ok {
+		for _, metric := range family.GetMetric() {
+			dropped[label(metric, "reason")] += metric.GetCounter().GetValue()
+		}
+	}
+	return dropped, nil
+}
+
+func label(metric *dto.Metric, name string) string {
+	for _, pair := range metric.GetLabel() {
+		if pair.GetName() == name {
+			return pair.GetValue()
+		}
+	}
+	return ""
+}
+
+// get sends a GET request to path on the server
+func get(ctx context.Context, client *http.Client, opts options, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(opts.URL, "/")+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if opts.APIKey != "" {
+		req.Header.Set(middleware.APIKeyHeader, opts.APIKey)
+	}
+	return client.Do(req)
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// stats are the outcomes of the requests of a kind
+type stats struct {
+	unsent    int             // Not sent, all the workers being busy
+	statuses  map[int]int     // Responses by status code
+	errors    int             // Requests that got no response
+	lastError error           // Latest of the errors, reported as an example
+	latencies []time.Duration // Of the responses
+}
+
+// results collects the outcomes of the requests sent by the workers
+type results struct {
+	mu      sync.Mutex
+	kinds   map[string]*stats
+	elapsed time.Duration
+}
+
+func newResults() *results {
+	return &results{kinds: map[string]*stats{}}
+}
+
+func (r *results) stats(kind string) *stats {
+	s, ok := r.kinds[kind]
+	if !ok {
+		s = &stats{statuses: map[int]int{}}
+		r.kinds[kind] = s
+	}
+	return s
+}
+
+// unsent counts a request not sent for all the workers being busy
+func (r *results) unsent(kind string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stats(kind).unsent++
+}
+
+// record records the response to a request, or err when it got none
+func (r *results) record(kind string, status int, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.stats(kind)
+	if status == 0 {
+		s.errors++
+		s.lastError = err
+		return
+	}
+	s.statuses[status]++
+	s.latencies = append(s.latencies, latency)
+}
+
+// print writes a line of throughput and latencies per kind of request, then the failures. Messages turned away by a
+// full queue, 503 Service Unavailable, are reported as dropped rather than failed
+func (r *results) print(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "KIND\tSENT\tPER SECOND\tOK\tDROPPED\tFAILED\tNOT SENT\tP50\tP90\tP99\tMAX")
+	var failures []string
+	for _, kind := range []string{kindMessage, kindGet, kindList} {
+		s, ok := r.kinds[kind]
+		if !ok {
+			continue
+		}
+		var sent, succeeded, dropped, failed int
+		for status, count := range s.statuses {
+			sent += count
+			switch {
+			case status < 300:
+				succeeded += count
+			case status == http.StatusServiceUnavailable:
+				dropped += count
+			default:
+				failed += count
+			}
+		}
+		sent += s.errors
+		failed += s.errors
+
+		slices.Sort(s.latencies)
+		fmt.Fprintf(table, "%s\t%d\t%.1f\t%d\t%d\t%d\t%d\t%s\t%s\t%s\t%s\n", kind, sent,
+			float64(sent)/r.elapsed.Seconds(), succeeded, dropped, failed, s.unsent, percentile(s.latencies, 0.5),
+			percentile(s.latencies, 0.9), percentile(s.latencies, 0.99), percentile(s.latencies, 1))
+		failures = append(failures, s.failures(kind)...)
+	}
+	table.Flush()
+
+	if len(failures) > 0 {
+		fmt.Fprintf(w, "\nFailures: %s\n", strings.Join(failures, ", "))
+	}
+}
+
+// failures describes the responses of a kind that were neither successful nor dropped
+func (s *stats) failures(kind string) []string {
+	var failures []string
+	for _, status := range slices.Sorted(maps.Keys(s.statuses)) {
+		if status >= 300 && status != http.StatusServiceUnavailable {
+			failures = append(failures, fmt.Sprintf("%s %d %s × %d", kind, status, http.StatusText(status), s.statuses[status]))
+		}
+	}
+	if s.errors > 0 {
+		failures = append(failures, fmt.Sprintf("%s without response × %d (%v)", kind, s.errors, s.lastError))
+	}
+	return failures
+}
+
+// percentile returns the latency under which a share p of the sorted latencies fall
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p*float64(len(sorted)))) - 1
+	return sorted[max(i, 0)].Round(10 * time.Microsecond)
+}
+
+// printDropped writes the messages dropped by the server during the test, by reason
+func printDropped(w io.Writer, before, after map[string]float64) {
+	var reasons []string
+	for _, reason := range slices.Sorted(maps.Keys(after)) {
+		if dropped := after[reason] - before[reason]; dropped > 0 {
+			reasons = append(reasons, fmt.Sprintf("%s %.0f", reason, dropped))
+		}
+	}
+	if len(reasons) == 0 {
+		fmt.Fprintln(w, "Messages dropped by the server: none")
+		return
+	}
+	fmt.Fprintf(w, "Messages dropped by the server: %s\n", strings.Join(reasons, ", "))
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// component is a part of the service started and stopped with it
+type component struct {
+	name string
+	// start returns once the component runs. Failures while running are reported to fail, stopping the service
+	start func(fail func(error)) error
+	// stop returns once the component stopped or ctx is done, giving up on the work left
+	stop    func(ctx context.Context) error
+	timeout time.Duration // Time given to stop
+}
+
+// lifecycle starts components in the order they were added, each depending on the ones before it, and stops them in
+// reverse order
+type lifecycle struct {
+	components []component
+	started    int
+	failed     chan error
+}
+
+func newLifecycle() *lifecycle {
+	return &lifecycle{failed: make(chan error, 1)}
+}
+
+// Add appends a component, started after every component added before
+func (l *lifecycle) Add(c component) {
+	l.components = append(l.components, c)
+}
+
+// Start starts the components in order. When one fails to start, those already started are stopped
+func (l *lifecycle) Start() error {
+	for _, c := range l.components {
+		if c.start != nil {
+			if err := c.start(l.fail); err != nil {
+				l.Stop()
+				return fmt.Errorf("starting %s: %w", c.name, err)
+			}
+		}
+		l.started++
+	}
+	return nil
+}
+
+func (l *lifecycle) fail(err error) {
+	select {
+	case l.failed <- err:
+	default: // The service is already stopping
+	}
+}
+
+// Failed receives the first failure of a running component
+func (l *lifecycle) Failed() <-chan error {
+	return l.failed
+}
+
+// Stop stops the started components in reverse order, each within its timeout, and reports those that did not stop
+// cleanly
+func (l *lifecycle) Stop() error {
+	var errs []error
+	for ; l.started > 0; l.started-- {
+		c := l.components[l.started-1]
+		if c.stop == nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+		err := c.stop(ctx)
+		cancel()
+		if err != nil {
+			slog.Warn("Component did not stop cleanly", "component", c.name, "error", err)
+			errs = append(errs, fmt.Errorf("stopping %s: %w", c.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
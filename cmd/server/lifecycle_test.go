@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLifecycle(t *testing.T) {
+	var calls []string
+	add := func(l *lifecycle, name string, startErr error) {
+		l.Add(component{
+			name: name,
+			start: func(func(error)) error {
+				calls = append(calls, "start "+name)
+				return startErr
+			},
+			stop: func(context.Context) error {
+				calls = append(calls, "stop "+name)
+				return nil
+			},
+		})
+	}
+
+	t.Run("stops in reverse order", func(t *testing.T) {
+		calls = nil
+		l := newLifecycle()
+		add(l, "processor", nil)
+		add(l, "http", nil)
+
+		require.NoError(t, l.Start())
+		require.NoError(t, l.Stop())
+		assert.Equal(t, []string{"start processor", "start http", "stop http", "stop processor"}, calls)
+	})
+
+	t.Run("stops started components when one fails to start", func(t *testing.T) {
+		calls = nil
+		l := newLifecycle()
+		add(l, "processor", nil)
+		add(l, "http", errors.New("address already in use"))
+		add(l, "readiness", nil)
+
+		assert.ErrorContains(t, l.Start(), "starting http")
+		assert.Equal(t, []string{"start processor", "start http", "stop processor"}, calls)
+	})
+}
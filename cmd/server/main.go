@@ -1,57 +1,613 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/ahernandez9/rockets/internal/api"
-	"github.com/ahernandez9/rockets/internal/pubsub/channel"
-	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+	"github.com/ahernandez9/rockets/internal/app"
+	"github.com/ahernandez9/rockets/internal/audit"
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/cluster"
+	"github.com/ahernandez9/rockets/internal/config"
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/grpcapi"
+	"github.com/ahernandez9/rockets/internal/health"
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/leader"
+	"github.com/ahernandez9/rockets/internal/lock"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/notify"
+	"github.com/ahernandez9/rockets/internal/pubsub/nats"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
+	"github.com/ahernandez9/rockets/internal/retention"
+	"github.com/ahernandez9/rockets/internal/secrets"
+	"github.com/ahernandez9/rockets/internal/server"
 	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/ahernandez9/rockets/internal/simulator"
+	"github.com/ahernandez9/rockets/internal/telemetry"
+	"github.com/ahernandez9/rockets/internal/validation"
+	"github.com/ahernandez9/rockets/internal/webhook"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // @title Rockets API
 // @version 1.0
 // @description REST API for rocket system with message processing
 
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Admin token or API key with the admin scope, sent as "Bearer <token>"
+
+// @securityDefinitions.apikey APIKey
+// @in header
+// @name X-API-Key
+// @description API key with the ingest scope, required to submit messages once ingest keys are configured
+
 func main() {
-	port := os.Getenv("PORT") // We could use a more advanced approach to load env vars, ex: viper
-	if port == "" {
-		port = "8088"
+	cfg, err := config.Load(os.Args[1:])
+	if errors.Is(err, config.ErrHelp) {
+		return
+	}
+	if err != nil {
+		fatal("Invalid configuration", err)
 	}
 
 	// initialize observability here (logging, tracing, metrics)
+	var logOutput io.Writer = os.Stderr
+	if cfg.LogOutput.File.Path != "" {
+		file, err := logFile(cfg.LogOutput.File)
+		if err != nil {
+			fatal("Failed to open the log file", err)
+		}
+		defer file.Close()
+		logOutput = io.MultiWriter(os.Stderr, file)
+	}
+	if err := logging.Setup(logOutput, cfg.LogOutput.Format, cfg.Log.Level); err != nil {
+		fatal("Invalid logging configuration", err)
+	}
 
-	// Dependencies
-	repo := inmemory.NewInMemoryRepository()
-	pubsub := channel.NewPubSub(1000)
+	var recorder metrics.Recorder
+	var prom *metrics.Prometheus // Only set with the Prometheus backend, which serves GET /metrics
+	var gatherer prometheus.Gatherer
+	var metricsHandler http.Handler
+	switch cfg.Metrics.Backend {
+	case config.MetricsPrometheus:
+		prom = metrics.NewPrometheus()
+		recorder, gatherer, metricsHandler = prom, prom.Gatherer(), prom.Handler()
+	case config.MetricsStatsD:
+		statsd, err := metrics.NewStatsD(metrics.StatsDOptions{
+			Addr:   cfg.Metrics.StatsD.Addr,
+			Prefix: cfg.Metrics.StatsD.Prefix,
+			Tags:   cfg.Metrics.StatsD.DogStatsD,
+		})
+		if err != nil {
+			fatal("Failed to set up StatsD metrics", err)
+		}
+		defer statsd.Close()
+		recorder = statsd
+	}
 
-	// Services
-	rocketService := service.NewRocketService(repo)
-	messageService := service.NewMessageService(pubsub, repo)
+	// Credentials are read from the secrets provider, the environment by default
+	provider, err := secretsProvider(cfg.Secrets)
+	if err != nil {
+		fatal("Invalid secrets configuration", err)
+	}
+	secret := func(name string) string {
+		value, _, err := provider.Lookup(context.Background(), name)
+		if err != nil {
+			fatal("Failed to load secret "+name, err)
+		}
+		return value
+	}
 
-	router := api.SetupRouter(messageService, rocketService)
+	// Failures are reported to Sentry when a DSN is configured
+	var reporter errreport.Reporter = errreport.Nop{}
+	if dsn := secret("SENTRY_DSN"); dsn != "" {
+		sentry, err := errreport.NewSentry(errreport.SentryOptions{
+			DSN:         dsn,
+			Environment: cfg.Sentry.Environment,
+			Release:     cfg.Sentry.Release,
+		})
+		if err != nil {
+			fatal("Failed to set up error reporting", err)
+		}
+		defer sentry.Flush(5 * time.Second)
+		reporter = sentry
+	}
+
+	telemetryConfig, err := telemetry.ConfigFromEnv()
+	if err != nil {
+		fatal("Invalid telemetry configuration", err)
+	}
+	shutdownTelemetry, err := telemetry.Setup(context.Background(), telemetryConfig, gatherer)
+	if err != nil {
+		fatal("Failed to set up telemetry", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTelemetry(ctx); err != nil {
+			slog.Error("Failed to flush telemetry", "error", err)
+		}
+	}()
+
+	// Launches of unknown rocket types are rejected when an allowlist is configured
+	validation.SetAllowedRocketTypes(cfg.Ingest.AllowedRocketTypes)
+
+	// Repositories, message queue and services, assembled by the injectors of package app
+	detector := service.AnomalyDetector{MaxSpeedDelta: cfg.Anomaly.MaxSpeedDelta}
+	locker, err := lockerOf(cfg.Lock, secret)
+	if err != nil {
+		fatal("Invalid lock configuration", err)
+	}
+	// Developers run the broker code paths against a NATS server embedded in the process
+	servicesCfg := cfg
+	if cfg.PubSub.Backend == config.PubSubNATS && cfg.PubSub.NATS.Embedded {
+		url, shutdown, err := nats.RunEmbedded(nats.EmbeddedOptions{
+			Addr:     cfg.PubSub.NATS.EmbeddedAddr,
+			StoreDir: cfg.PubSub.NATS.StoreDir,
+		})
+		if err != nil {
+			fatal("Failed to start the embedded NATS server", err)
+		}
+		defer shutdown()
+		slog.Warn("Started embedded NATS server, for development only", "url", url)
+		servicesCfg.PubSub.NATS.URL = url
+	}
+	// Messages are sealed before the broker stores them when an encryption key is set
+	if key := secret("PUBSUB_ENCRYPTION_KEY"); key != "" && cfg.PubSub.Backend == config.PubSubNATS {
+		decoded, err := base64.StdEncoding.DecodeString(key)
+		if err != nil {
+			fatal("Invalid PUBSUB_ENCRYPTION_KEY, expected base64", err)
+		}
+		servicesCfg.PubSub.NATS.EncryptionKey = decoded
+	}
+	services, err := app.NewServices(servicesCfg, detector, recorder, reporter, locker)
+	if err != nil {
+		fatal("Failed to set up the services", err)
+	}
+
+	// Instances of a cluster only apply the messages of the channels they own, and forward requests about the others
+	var ring *cluster.Ring
+	if len(cfg.Cluster.Members) > 0 {
+		members, _ := cluster.ParseMembers(cfg.Cluster.Members) // Validated with the configuration
+		ring, _ = cluster.NewRing(members, cfg.Cluster.Self)
+		services.Messages = cluster.WrapMessageService(services.Messages, ring)
+		slog.Info("Joined cluster", "self", cfg.Cluster.Self, "members", len(members))
+	}
+	messageService, rocketService := services.Messages, services.Rockets
+
+	// Demos, tests and bug reports start from a known fleet
+	if cfg.Seed != "" {
+		file, err := os.Open(cfg.Seed)
+		if err != nil {
+			fatal("Failed to open seed file", err)
+		}
+		stats, err := service.Seed(context.Background(), services.Backup, file)
+		file.Close()
+		if err != nil {
+			fatal("Invalid seed file "+cfg.Seed, err)
+		}
+		slog.Info("Seeded repository", "file", cfg.Seed, "rockets", stats.Rockets, "events", stats.Events)
+	}
+
+	// Per-rocket gauges are opt-in, since every exported rocket adds series to scrape
+	if cfg.Metrics.RocketGaugesLimit > 0 {
+		prom.ExportRockets(func() []*models.Rocket {
+			rockets, _ := rocketService.ListRockets(context.Background(), models.RocketFilter{}, nil)
+			return rockets
+		}, cfg.Metrics.RocketGaugesLimit)
+	}
+
+	reaper := retention.NewReaper(rocketService, retention.Policy{
+		MaxAge:         cfg.Ages.MaxAge,
+		ExplodedMaxAge: cfg.Ages.ExplodedMaxAge,
+		Action:         retention.Action(cfg.Retention.Action),
+		Interval:       cfg.Retention.Interval,
+	}, recorder)
+
+	notifications := notify.NewDispatcher(notificationSinks(cfg.Notify, secret), notify.DefaultOptions())
+
+	// Traffic is only routed to the instance while it processes messages and its dependencies respond
+	checker := health.NewChecker()
+	checker.Add("processor", func(context.Context) error {
+		if !messageService.Running() {
+			return errors.New("message processor not running")
+		}
+		return nil
+	})
+	checker.Add("repository", services.Repository.Ping)
+	checker.Add("pubsub", services.PubSub.Ping)
+	checker.Add("events", services.Events.Ping)
+
+	serverOpts := server.Options{
+		ReadHeaderTimeout:    cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:          cfg.Server.ReadTimeout,
+		WriteTimeout:         cfg.Server.WriteTimeout,
+		IdleTimeout:          cfg.Server.IdleTimeout,
+		MaxHeaderBytes:       cfg.Server.MaxHeaderBytes,
+		H2C:                  cfg.Server.H2C,
+		MaxConcurrentStreams: cfg.Server.MaxConcurrentStreams,
+	}
+
+	keys, err := apiKeys(cfg.Auth, secret)
+	if err != nil {
+		fatal("Invalid API keys", err)
+	}
+
+	var keyStore auth.KeyStore = keys
+	var oidcProvider *auth.OIDC
+	if cfg.OIDC.Issuer != "" {
+		oidcProvider, err = auth.NewOIDC(context.Background(), auth.OIDCOptions{
+			Issuer:       cfg.OIDC.Issuer,
+			ClientID:     cfg.OIDC.ClientID,
+			ClientSecret: secret("OIDC_CLIENT_SECRET"),
+			RedirectURL:  cfg.OIDC.RedirectURL,
+			AdminEmails:  cfg.OIDC.AdminEmails,
+			AdminGroups:  cfg.OIDC.AdminGroups,
+		})
+		if err != nil {
+			fatal("Invalid OIDC configuration", err)
+		}
+		keyStore = auth.Chain{keys, oidcProvider}
+	}
+
+	signing, err := auth.ParseSigningSecrets(secret("MESSAGE_SIGNING_SECRETS"))
+	if err != nil {
+		fatal("Invalid MESSAGE_SIGNING_SECRETS", err)
+	}
+
+	ingestNetworks, _ := middleware.ParseNetworks(cfg.Ingest.AllowedNetworks) // Validated with the configuration
+
+	quotas := ratelimit.NewQuotas(keyLimits(cfg.Limits))
+	limiter := middleware.NewLimiter(routeLimits(cfg.Limits), recorder)
+
+	var streamTokens *auth.StreamTokens
+	if streamSecret := secret("STREAM_TOKEN_SECRET"); streamSecret != "" {
+		streamTokens = auth.NewStreamTokens(streamSecret, cfg.Auth.StreamTokenMaxTTL)
+	}
+
+	tlsOpts := server.TLSOptions{
+		CertFile:     cfg.TLS.CertFile,
+		KeyFile:      cfg.TLS.KeyFile,
+		ClientCAFile: cfg.TLS.ClientCAFile,
+		ClientAuth:   cfg.TLS.ClientAuth,
+	}
+	var tlsConfig *tls.Config
+	if tlsOpts.Enabled() {
+		tlsConfig, err = server.TLSConfig(tlsOpts)
+		if err != nil {
+			fatal("Invalid TLS configuration", err)
+		}
+	}
+
+	router := app.NewRouter(services, notifications, checker, api.Options{
+		Keys:           keyStore,
+		OIDC:           oidcProvider,
+		Metrics:        metricsHandler,
+		Reporter:       reporter,
+		Recorder:       recorder,
+		Audit:          audit.NewLog(cfg.Audit.Size),
+		ClientCerts:    tlsConfig != nil && tlsConfig.ClientCAs != nil,
+		Signing:        signing,
+		IngestNetworks: ingestNetworks,
+		TrustedProxies: cfg.Server.TrustedProxies,
+		Limiter:        limiter,
+		Quotas:         quotas,
+		StreamTokens:   streamTokens,
+		Ring:           ring,
+		CORS: middleware.CORSOptions{
+			AllowedOrigins: cfg.CORS.AllowedOrigins,
+			AllowedMethods: cfg.CORS.AllowedMethods,
+			AllowedHeaders: cfg.CORS.AllowedHeaders,
+			MaxAge:         cfg.CORS.MaxAge,
+		},
+	})
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
-	// Start async message processor
-	go messageService.Start()
-	defer messageService.Stop()
-
-	// Start HTTP server
-	addr := fmt.Sprintf(":%s", port)
+	// SIGUSR1 toggles debug logs, for hosts where the admin API isn't reachable
+	toggleDebug := make(chan os.Signal, 1)
+	signal.Notify(toggleDebug, syscall.SIGUSR1)
 	go func() {
-		log.Printf("Starting Rockets API server on %s", addr)
-
-		if err := router.Run(addr); err != nil {
-			log.Fatalf("Failed to start server: %v", err)
+		for range toggleDebug {
+			slog.Warn("Log level changed", "level", logging.ToggleDebug())
 		}
 	}()
 
-	<-quit
-	log.Println("Server stopped")
+	// Only the elected instance processes messages; the others wait, unready, to take over. An instance losing the
+	// leadership stops, since processing can't be resumed once stopped
+	elector, err := electorOf(cfg.Election, secret)
+	if err != nil {
+		fatal("Invalid election configuration", err)
+	}
+
+	// Components start in dependency order and stop in reverse: the instance leaves the load balancers before the
+	// servers stop, and the servers stop before the processor and the workers they feed
+	components := newLifecycle()
+	electionCtx, stopCampaign := context.WithCancel(context.Background())
+	components.Add(component{
+		name: "processor",
+		start: func(fail func(error)) error {
+			go func() {
+				lost, err := elector.Campaign(electionCtx)
+				if electionCtx.Err() != nil {
+					slog.Info("Stopped campaigning for the leadership", "error", err)
+					return
+				}
+				if err != nil {
+					fail(fmt.Errorf("campaigning for the leadership: %w", err))
+					return
+				}
+				go messageService.Start()
+				select {
+				case <-lost:
+					fail(leader.ErrLost)
+				case <-electionCtx.Done():
+				}
+			}()
+			return nil
+		},
+		stop: func(ctx context.Context) error {
+			stopCampaign()
+			messageService.Stop()
+			return elector.Resign(ctx)
+		},
+		timeout: 5 * time.Second,
+	})
+
+	// Dispatch explosion alerts and webhook deliveries, reap stale rockets and reload the configuration in the background
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	components.Add(component{
+		name: "background",
+		start: func(func(error)) error {
+			go notifications.Run(backgroundCtx)
+			go notify.WatchExplosions(backgroundCtx, services.Changes, notifications)
+			go webhook.NewDeliverer(services.WebhookRepository, webhook.DefaultOptions()).Run(backgroundCtx, services.Changes)
+			go reaper.Run(backgroundCtx)
+			// SIGHUP and changes of the configuration file reload the settings that are safe to change while running
+			go (&reloader{current: cfg, limiter: limiter, quotas: quotas, reaper: reaper, messages: messageService}).Run(backgroundCtx)
+			if cfg.Dev.Rockets > 0 {
+				slog.Warn("Generating the telemetry of fake rockets", "rockets", cfg.Dev.Rockets, "interval", cfg.Dev.Interval)
+				go simulator.New(messageService.PublishMessage, simulator.Options{
+					Rockets:  cfg.Dev.Rockets,
+					Interval: cfg.Dev.Interval,
+				}).Run(backgroundCtx)
+			}
+			return nil
+		},
+		stop: func(context.Context) error {
+			stopBackground()
+			return nil
+		},
+	})
+
+	grpcServer := grpcapi.NewServer(messageService, rocketService, grpcapi.Options{
+		Keys:           keyStore,
+		Quotas:         quotas,
+		Recorder:       recorder,
+		IngestNetworks: ingestNetworks,
+		Signing:        signing,
+		ClientCerts:    tlsConfig != nil && tlsConfig.ClientCAs != nil,
+	})
+	components.Add(component{
+		name: "grpc",
+		start: func(fail func(error)) error {
+			listeners, err := server.ListenAll(cfg.Server.GRPCAddrs())
+			if err != nil {
+				return err
+			}
+			for _, listener := range listeners {
+				slog.Info("Starting Rockets gRPC server", "addr", listener.Addr().String())
+				go func() {
+					if err := grpcServer.Serve(listener); err != nil {
+						fail(fmt.Errorf("gRPC server: %w", err))
+					}
+				}()
+			}
+			return nil
+		},
+		stop:    func(ctx context.Context) error { return grpcapi.Shutdown(ctx, grpcServer) },
+		timeout: cfg.Shutdown.Timeout,
+	})
+
+	httpServer := server.New("", router, tlsConfig, serverOpts)
+	components.Add(component{
+		name: "http",
+		start: func(fail func(error)) error {
+			listeners, err := server.ListenAll(cfg.Server.HTTPAddrs())
+			if err != nil {
+				return err
+			}
+			for _, listener := range listeners {
+				slog.Info("Starting Rockets API server", "addr", listener.Addr().String(), "tls", tlsConfig != nil)
+				go func() {
+					if err := server.Serve(httpServer, listener); err != nil {
+						fail(fmt.Errorf("HTTP server: %w", err))
+					}
+				}()
+			}
+			return nil
+		},
+		stop:    func(ctx context.Context) error { return server.Shutdown(ctx, httpServer) },
+		timeout: cfg.Shutdown.Timeout,
+	})
+
+	// Readiness fails first on shutdown, with a delay so that load balancers stop routing to the instance before it stops
+	components.Add(component{
+		name: "readiness",
+		stop: func(ctx context.Context) error {
+			checker.Drain()
+			if drainDelay := cfg.Shutdown.DrainDelay; drainDelay > 0 {
+				slog.Info("Draining before shutdown", "delay", drainDelay)
+				select {
+				case <-time.After(drainDelay):
+				case <-ctx.Done():
+				}
+			}
+			return nil
+		},
+		timeout: cfg.Shutdown.DrainDelay + time.Second,
+	})
+
+	if err := components.Start(); err != nil {
+		fatal("Failed to start", err)
+	}
+	slog.Info("Rockets started", "json", jsoncodec.Name)
+
+	var failure error
+	select {
+	case <-quit:
+	case failure = <-components.Failed():
+		slog.Error("Stopping after a component failed", "error", failure)
+	}
+	components.Stop()
+	if failure != nil {
+		fatal("Server stopped", failure)
+	}
+	slog.Info("Server stopped")
+}
+
+// fatal logs an error preventing the service from running and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// apiKeys builds the key store from the secrets and configuration: the ADMIN_TOKEN of the "admin" operator, the ingest
+// keys of API_KEYS as comma-separated name:key pairs, and the keys of the JSON file at auth.keysFile with their scopes
+func apiKeys(cfg config.Auth, secret func(name string) string) (*auth.Keys, error) {
+	keys := auth.NewKeys()
+	if token := secret("ADMIN_TOKEN"); token != "" {
+		if err := keys.Add(token, auth.Identity{Name: "admin", Scopes: []auth.Scope{auth.ScopeAdmin}}); err != nil {
+			return nil, err
+		}
+	}
+	if err := keys.ParseKeys(secret("API_KEYS"), auth.ScopeIngest); err != nil {
+		return nil, err
+	}
+	if cfg.KeysFile != "" {
+		if err := keys.LoadFile(cfg.KeysFile); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// secretsProvider builds the provider of credentials chosen by secrets.provider: env (the default), file, vault or aws.
+// The token of Vault, needed to read the other credentials, is read from VAULT_TOKEN
+func secretsProvider(cfg config.Secrets) (secrets.Provider, error) {
+	switch cfg.Provider {
+	case config.SecretsFile:
+		return secrets.File{Dir: cfg.Dir}, nil
+	case config.SecretsVault:
+		return secrets.NewVault(context.Background(), secrets.VaultOptions{
+			Addr:  cfg.VaultAddr,
+			Token: os.Getenv("VAULT_TOKEN"),
+			Path:  cfg.VaultPath,
+		})
+	case config.SecretsAWS:
+		return secrets.NewAWSSecretsManager(context.Background(), cfg.AWSSecretID)
+	default:
+		return secrets.Env{}, nil
+	}
+}
+
+// electorOf builds the elector of the instance processing messages, with its credentials from the secrets
+func electorOf(cfg config.Election, secret func(name string) string) (leader.Elector, error) {
+	if cfg.Backend != config.ElectionRedis {
+		return leader.Single{}, nil
+	}
+	hostname, _ := os.Hostname()
+	return leader.NewRedis(leader.RedisOptions{
+		Addr:     cfg.RedisAddr,
+		Password: secret("ELECTION_REDIS_PASSWORD"),
+		Key:      cfg.Key,
+		ID:       fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+		TTL:      cfg.TTL,
+	})
+}
+
+// lockerOf builds the locker serializing the processing of channels, with its credentials from the secrets
+func lockerOf(cfg config.Lock, secret func(name string) string) (lock.Locker, error) {
+	if cfg.Backend != config.LockRedis {
+		return lock.None{}, nil
+	}
+	return lock.NewRedis(lock.RedisOptions{
+		Addr:     cfg.RedisAddr,
+		Password: secret("LOCK_REDIS_PASSWORD"),
+		Prefix:   cfg.Prefix,
+		TTL:      cfg.TTL,
+		Wait:     cfg.Wait,
+	})
+}
+
+// notificationSinks builds the notification sinks configured, with their credentials from the secrets
+func notificationSinks(cfg config.Notify, secret func(name string) string) []notify.Sink {
+	var sinks []notify.Sink
+
+	if url := secret("NOTIFY_SLACK_WEBHOOK_URL"); url != "" {
+		sinks = append(sinks, &notify.SlackSink{WebhookURL: url})
+	}
+
+	if cfg.WebhookURL != "" {
+		sink := &notify.WebhookSink{URL: cfg.WebhookURL}
+		if auth := secret("NOTIFY_WEBHOOK_AUTHORIZATION"); auth != "" {
+			sink.Headers = map[string]string{"Authorization": auth}
+		}
+		sinks = append(sinks, sink)
+	}
+
+	if cfg.SMTP.Addr != "" {
+		sinks = append(sinks, &notify.EmailSink{
+			Addr:     cfg.SMTP.Addr,
+			Username: cfg.SMTP.Username,
+			Password: secret("NOTIFY_SMTP_PASSWORD"),
+			From:     cfg.SMTP.From,
+			To:       cfg.SMTP.To,
+		})
+	}
+
+	return sinks
+}
+
+// logFile opens the log file, rotated as configured
+func logFile(cfg config.LogFile) (*logging.File, error) {
+	return logging.OpenFile(logging.FileOptions{
+		Path:       cfg.Path,
+		MaxSize:    cfg.MaxSizeMB << 20,
+		MaxAge:     cfg.MaxAge,
+		MaxBackups: cfg.MaxBackups,
+	})
+}
+
+// routeLimits returns the load limits by route group
+func routeLimits(limits config.Limits) map[string]middleware.LimitOptions {
+	return map[string]middleware.LimitOptions{
+		api.GroupIngest: limits.Ingest,
+		api.GroupAdmin:  limits.Admin,
+		api.GroupQuery:  limits.Query,
+	}
+}
+
+// keyLimits returns the limits of API callers without limits of their own
+func keyLimits(limits config.Limits) ratelimit.Limits {
+	return ratelimit.Limits{PerMinute: limits.KeyRate, PerDay: limits.KeyDailyQuota}
 }
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"reflect"
+	"syscall"
+
+	"github.com/ahernandez9/rockets/internal/config"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
+	"github.com/ahernandez9/rockets/internal/retention"
+	"github.com/ahernandez9/rockets/internal/service"
+)
+
+// reloader applies the reloadable settings of the configuration to the running components, keeping the in-memory
+// state. Other settings only take effect on restart
+type reloader struct {
+	current  config.Config
+	limiter  *middleware.Limiter
+	quotas   *ratelimit.Quotas
+	reaper   *retention.Reaper
+	messages service.MessageService
+}
+
+// Run reloads the configuration on SIGHUP and, when it comes from a file, on changes of the file, until ctx is done
+func (r *reloader) Run(ctx context.Context) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	defer signal.Stop(hangup)
+
+	var changed <-chan struct{}
+	if r.current.File != "" {
+		var err error
+		if changed, err = config.Watch(ctx, r.current.File); err != nil {
+			slog.Warn("Configuration file not watched, send SIGHUP to reload it", "file", r.current.File, "error", err)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hangup:
+			r.reload()
+		case <-changed:
+			r.reload()
+		}
+	}
+}
+
+// reload loads the configuration again and applies the reloadable settings that changed. Invalid configurations are
+// rejected as a whole
+func (r *reloader) reload() {
+	next, err := config.Load(os.Args[1:])
+	if err != nil {
+		slog.Error("Configuration not reloaded", "error", err)
+		return
+	}
+
+	prev := r.current.Reloadable
+	if next.Log != prev.Log {
+		level, _ := logging.ParseLevel(next.Log.Level)
+		logging.Configure(level)
+	}
+	if next.Limits != prev.Limits {
+		r.limiter.SetLimits(routeLimits(next.Limits))
+		r.quotas.SetDefaults(keyLimits(next.Limits))
+	}
+	if next.Ages != prev.Ages {
+		r.reaper.SetMaxAges(next.Ages.MaxAge, next.Ages.ExplodedMaxAge)
+	}
+	if next.Anomaly != prev.Anomaly {
+		r.messages.SetAnomalyDetector(service.AnomalyDetector{MaxSpeedDelta: next.Anomaly.MaxSpeedDelta})
+	}
+
+	restart := next
+	restart.Reloadable = prev
+	if !reflect.DeepEqual(restart, r.current) {
+		slog.Warn("Configuration changes other than log level, limits, retention ages and anomaly rules need a restart")
+	}
+	r.current.Reloadable = next.Reloadable
+	slog.Info("Configuration reloaded", "changed", next.Reloadable != prev)
+}
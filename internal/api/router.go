@@ -1,22 +1,220 @@
 package api
 
 import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/audit"
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/cluster"
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/feed"
+	"github.com/ahernandez9/rockets/internal/graphqlapi"
 	"github.com/ahernandez9/rockets/internal/handler"
+	"github.com/ahernandez9/rockets/internal/health"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/notify"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
 	"github.com/ahernandez9/rockets/internal/service"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Route groups limited separately
+const (
+	GroupIngest = "ingest" // Submitting messages
+	GroupAdmin  = "admin"  // Administrative endpoints and changes
+	GroupQuery  = "query"  // Reading rockets, missions, fleets and stats
+)
+
+// probePaths are the routes of probes and scrapes, left out of access logs and never limited so that they keep
+// working under load
+var probePaths = []string{"/health", "/ready", "/metrics"}
+
+// readOnlyPosts are POST routes that change nothing, queries whose parameters don't fit in a URL
+var readOnlyPosts = []string{"/rockets/batch-get", "/graphql"}
+
+// Options holds the router settings that are not services
+type Options struct {
+	Keys           auth.KeyStore          // API keys of producers and operators; nil disables administrative endpoints
+	OIDC           *auth.OIDC             // Serves the login of operators under /auth; nil disables it
+	CORS           middleware.CORSOptions // Cross-origin access; disabled when no origin is allowed
+	Metrics        http.Handler           // Serves GET /metrics; nil disables the endpoint
+	Reporter       errreport.Reporter     // Receives handler panics; nil only logs them
+	Recorder       metrics.Recorder       // Counts handler panics and denied requests; nil disables the counts
+	Audit          *audit.Log             // Records state-changing requests; nil keeps audit.DefaultSize entries
+	ClientCerts    bool                   // Submitting messages requires a verified TLS client certificate
+	Signing        auth.SigningSecrets    // Secrets of the producers signing the messages they submit; empty disables signing
+	IngestNetworks []netip.Prefix         // Client networks allowed to submit messages; empty allows every client
+	TrustedProxies []string               // Proxies whose forwarding headers tell the client address; empty trusts none
+	Quotas         *ratelimit.Quotas      // Rate limits and quotas of API callers; nil leaves them unlimited
+	Limiter        *middleware.Limiter    // Load limits by route group; nil leaves every group unlimited
+	StreamTokens   *auth.StreamTokens     // Issues tokens scoping stream subscriptions; nil leaves streams open
+	Ring           *cluster.Ring          // Forwards requests about channels owned by other instances; nil serves them all
+}
+
 // SetupRouter creates and configures the Gin router with explicit dependency injection
-func SetupRouter(messageService service.MessageService, rocketService service.RocketService) *gin.Engine {
-	router := gin.Default()
+func SetupRouter(
+	messageService service.MessageService,
+	rocketService service.RocketService,
+	backupService service.BackupService,
+	fleetService service.FleetService,
+	webhookService service.WebhookService,
+	changes *feed.Feed,
+	notifications *notify.Dispatcher,
+	checker *health.Checker,
+	opts Options,
+) *gin.Engine {
+	reporter := opts.Reporter
+	if reporter == nil {
+		reporter = errreport.Nop{}
+	}
+	recorder := opts.Recorder
+	if recorder == nil {
+		recorder = metrics.Nop{}
+	}
+	keys := opts.Keys
+	if keys == nil {
+		keys = auth.NewKeys()
+	}
+	auditLog := opts.Audit
+	if auditLog == nil {
+		auditLog = audit.NewLog(audit.DefaultSize)
+	}
+	limiter := opts.Limiter
+	if limiter == nil {
+		limiter = middleware.NewLimiter(nil, recorder)
+	}
+
+	router := gin.New()
+	if err := router.SetTrustedProxies(opts.TrustedProxies); err != nil {
+		panic(fmt.Sprintf("invalid trusted proxies: %v", err))
+	}
+	router.Use(middleware.AccessLog(probePaths...), middleware.Tracing(), middleware.RequestID(),
+		middleware.Recovery(reporter, recorder), limiter.Handler(routeGroup),
+		middleware.Audit(auditLog, readOnlyPosts...))
+
+	if len(opts.CORS.AllowedOrigins) > 0 {
+		router.Use(middleware.CORS(opts.CORS))
+	}
+
+	authn := middleware.NewAuth(keys, opts.Quotas, recorder)
+	adminAuth := authn.Admin()
+	ingestAuth := authn.Ingest()
+	allowlist := middleware.Allowlist(opts.IngestNetworks, recorder)
+	clientCert := middleware.ClientCert(opts.ClientCerts)
+	signature := middleware.Signature(opts.Signing)
+	// Streamed bodies are read line by line, each line bounded by its handler, and may last as long as the producer
+	decompress, decompressStream := middleware.Decompress(handler.MaxMessageSize), middleware.Decompress(0)
+	compress := middleware.Compress()
+	longLived := middleware.LongLived()
+	forwardMessage := middleware.Forward(opts.Ring, middleware.MessageChannel)
+	forwardRocket := middleware.Forward(opts.Ring, middleware.ParamChannel("id"))
+	partial := middleware.Partial(opts.Ring)
+
+	router.GET("/health", handler.Healthcheck(checker))
+	router.GET("/ready", handler.Readiness(checker))
+	if opts.Metrics != nil {
+		router.GET("/metrics", gin.WrapH(opts.Metrics))
+	}
 
-	router.GET("/health", handler.Healthcheck())
+	router.POST("/messages", allowlist, clientCert, ingestAuth, signature, forwardMessage, decompress, handler.PostMessage(messageService))
+	router.POST("/messages/stream", allowlist, clientCert, ingestAuth, longLived, signature, decompressStream, handler.StreamMessages(messageService))
+	router.GET("/rockets/:id/metrics", forwardRocket, handler.GetRocketMetrics(messageService))
+	router.GET("/stats/processing", handler.GetProcessingStats(messageService))
+	router.GET("/stats/ingestion", handler.GetIngestionStats(messageService))
 
-	router.POST("/messages", handler.PostMessage(messageService))
+	if opts.OIDC != nil {
+		router.GET("/auth/login", handler.Login(opts.OIDC))
+		router.GET("/auth/callback", handler.LoginCallback(opts.OIDC))
+	}
 
-	router.GET("/rockets", handler.ListRockets(rocketService))
-	router.GET("/rockets/:id", handler.GetRocket(rocketService))
+	if opts.StreamTokens != nil {
+		router.POST("/stream-tokens", authn.Stream(), handler.PostStreamToken(opts.StreamTokens))
+	}
+
+	router.GET("/rockets", partial, compress, handler.ListRockets(rocketService))
+	router.GET("/rockets/stream", partial, longLived, handler.StreamRockets(changes, opts.StreamTokens))
+	router.GET("/rockets/summary", partial, handler.SummarizeRockets(rocketService))
+	router.GET("/rockets/top", partial, handler.TopRockets(rocketService))
+	router.GET("/rockets/by-name/:name", partial, handler.GetRocketByName(rocketService))
+	router.GET("/rockets/:id", forwardRocket, handler.GetRocket(rocketService))
+	router.POST("/rockets/batch-get", partial, compress, handler.BatchGetRockets(rocketService))
+	router.GET("/rockets/:id/events", forwardRocket, compress, handler.GetRocketEvents(rocketService))
+	router.GET("/rockets/:id/track", forwardRocket, compress, handler.GetRocketTrack(rocketService))
+	router.GET("/rockets/:id/wait", longLived, forwardRocket, handler.WaitForRocket(rocketService, changes))
+	router.PATCH("/rockets/:id", adminAuth, forwardRocket, handler.PatchRocket(rocketService))
+	router.PUT("/rockets/:id/labels", adminAuth, forwardRocket, handler.PutRocketLabels(rocketService))
+	router.PUT("/rockets/:id/name", adminAuth, forwardRocket, handler.PutRocketName(rocketService))
+	router.DELETE("/rockets/:id", adminAuth, forwardRocket, handler.DeleteRocket(rocketService, messageService))
+	router.POST("/rockets/:id/archive", adminAuth, forwardRocket, handler.ArchiveRocket(rocketService))
+	router.POST("/rockets/:id/unarchive", adminAuth, forwardRocket, handler.UnarchiveRocket(rocketService))
+
+	router.GET("/rocket-types", partial, handler.ListRocketTypes(rocketService))
+
+	router.GET("/missions", partial, compress, handler.ListMissions(rocketService))
+	router.GET("/missions/:name", partial, handler.GetMission(rocketService))
+	router.GET("/missions/:name/rockets", partial, compress, handler.ListMissionRockets(rocketService))
+
+	router.GET("/fleets", partial, handler.ListFleets(fleetService))
+	router.POST("/fleets", adminAuth, handler.PostFleet(fleetService))
+	router.GET("/fleets/:id", partial, handler.GetFleet(fleetService))
+	router.PUT("/fleets/:id", adminAuth, handler.PutFleet(fleetService))
+	router.DELETE("/fleets/:id", adminAuth, handler.DeleteFleet(fleetService))
+	router.GET("/fleets/:id/rockets", partial, compress, handler.ListFleetRockets(fleetService))
+
+	webhooks := router.Group("/webhooks", adminAuth)
+	webhooks.GET("", handler.ListWebhooks(webhookService))
+	webhooks.POST("", handler.PostWebhook(webhookService))
+	webhooks.GET("/:id", handler.GetWebhook(webhookService))
+	webhooks.DELETE("/:id", handler.DeleteWebhook(webhookService))
+
+	schema, err := graphqlapi.NewSchema(rocketService)
+	if err != nil {
+		// The schema is static, failing to build it is a programming error
+		panic(fmt.Sprintf("invalid GraphQL schema: %v", err))
+	}
+	router.POST("/graphql", partial, handler.GraphQL(schema))
+
+	state := router.Group("/admin", adminAuth)
+	state.GET("/state/export", partial, longLived, compress, handler.ExportState(backupService))
+	state.POST("/state/import", longLived, decompressStream, handler.ImportState(backupService))
+	state.GET("/export", partial, longLived, compress, handler.ExportState(backupService))
+	state.POST("/import", longLived, decompressStream, handler.ImportState(backupService))
+	state.POST("/reset", partial, handler.ResetState(rocketService))
+	state.POST("/rockets/:id/purge", forwardRocket, handler.PurgeRocket(rocketService, messageService))
+	state.DELETE("/channels/:id/data", forwardRocket,
+		handler.EraseChannelData(rocketService, fleetService, messageService, changes, notifications))
+
+	// Administration of the instance itself
+	admin := router.Group("/admin", adminAuth)
+	admin.GET("/notifications", handler.ListNotificationDeliveries(notifications))
+	admin.GET("/audit", handler.ListAuditEntries(auditLog))
+	if opts.Quotas != nil {
+		admin.GET("/keys/usage", handler.ListKeyUsage(opts.Quotas))
+	}
+	admin.GET("/loglevel", handler.GetLogLevel())
+	admin.PUT("/loglevel", handler.PutLogLevel())
 
 	return router
 }
+
+// routeGroup returns the group limiting the route of a request, empty for unmatched routes and probes
+func routeGroup(c *gin.Context) string {
+	route := c.FullPath()
+	switch {
+	case route == "" || slices.Contains(probePaths, route):
+		return ""
+	case strings.HasPrefix(route, "/messages"):
+		return GroupIngest
+	case strings.HasPrefix(route, "/admin"), strings.HasPrefix(route, "/webhooks"):
+		return GroupAdmin
+	case c.Request.Method == http.MethodGet, c.Request.Method == http.MethodHead, slices.Contains(readOnlyPosts, route):
+		return GroupQuery
+	}
+	return GroupAdmin
+}
@@ -0,0 +1,168 @@
+package api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/cluster"
+	"github.com/ahernandez9/rockets/internal/handler"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/service/mocks"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// TestAdminRoutesRequireAuth guards against administrative routes and changes registered without the admin middleware
+func TestAdminRoutesRequireAuth(t *testing.T) {
+	keys := auth.NewKeys()
+	require.NoError(t, keys.Add("s3cret", auth.Identity{Name: "admin", Scopes: []auth.Scope{auth.ScopeAdmin}}))
+	require.NoError(t, keys.Add("k3y", auth.Identity{Name: "producer-eu", Scopes: []auth.Scope{auth.ScopeIngest}}))
+	require.NoError(t, keys.Add("d4sh", auth.Identity{Name: "dashboard", Scopes: []auth.Scope{auth.ScopeStream}}))
+	router := SetupRouter(nil, nil, nil, nil, nil, nil, nil, nil, Options{
+		Keys:         keys,
+		StreamTokens: auth.NewStreamTokens("s3cret", time.Hour),
+	})
+
+	var checked int
+	for _, route := range router.Routes() {
+		change := route.Method != http.MethodGet && !strings.HasPrefix(route.Path, "/messages") &&
+			!slices.Contains(readOnlyPosts, route.Path)
+		if !change && !strings.HasPrefix(route.Path, "/admin/") && !strings.HasPrefix(route.Path, "/webhooks") {
+			continue
+		}
+		checked++
+
+		for key, status := range map[string]int{"": http.StatusUnauthorized, "k3y": http.StatusForbidden} {
+			req := httptest.NewRequest(route.Method, strings.ReplaceAll(route.Path, ":id", "1"), nil)
+			if key != "" {
+				req.Header.Set("X-API-Key", key)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, status, rec.Code, "%s %s with key %q", route.Method, route.Path, key)
+		}
+	}
+	assert.NotZero(t, checked)
+}
+
+func TestClusterRoutes(t *testing.T) {
+	var forwarded []string
+	owner := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		forwarded = append(forwarded, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer owner.Close()
+
+	members, err := cluster.ParseMembers([]string{"rockets-0=http://rockets-0:8088", "rockets-1=" + owner.URL})
+	require.NoError(t, err)
+	ring, err := cluster.NewRing(members, "rockets-0")
+	require.NoError(t, err)
+
+	keys := auth.NewKeys()
+	require.NoError(t, keys.Add("s3cret", auth.Identity{Name: "admin", Scopes: []auth.Scope{auth.ScopeAdmin}}))
+	rockets := mocks.NewMockRocketService(gomock.NewController(t))
+	rockets.EXPECT().LastModified(gomock.Any())
+	rockets.EXPECT().ListRockets(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	// Served over HTTP, as forwarding needs a real connection
+	server := httptest.NewServer(SetupRouter(nil, rockets, nil, nil, nil, nil, nil, nil, Options{Keys: keys, Ring: ring}))
+	defer server.Close()
+
+	t.Run("lists only cover the rockets of the instance", func(t *testing.T) {
+		resp, err := http.Get(server.URL + "/rockets")
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "rockets-0", resp.Header.Get(middleware.PartialHeader))
+	})
+
+	t.Run("erasures are forwarded to the owner of the channel", func(t *testing.T) {
+		channel := uuid.NewString()
+		for ring.Owns(channel) {
+			channel = uuid.NewString()
+		}
+		req, err := http.NewRequest(http.MethodDelete, server.URL+"/admin/channels/"+channel+"/data", nil)
+		require.NoError(t, err)
+		req.Header.Set(middleware.APIKeyHeader, "s3cret")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, []string{"DELETE /admin/channels/" + channel + "/data"}, forwarded)
+	})
+}
+
+func TestMessageBodySize(t *testing.T) {
+	oversized := `{"metadata":{},"message":{"type":"` + strings.Repeat("x", handler.MaxMessageSize) + `"}}`
+	gzipped := func(body string) string {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte(body))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		return buf.String()
+	}
+
+	tests := []struct {
+		name         string
+		body         string
+		contentType  string
+		gzip         bool
+		expectedCode int
+	}{
+		{name: "plain body", body: oversized, contentType: "application/json", expectedCode: http.StatusRequestEntityTooLarge},
+		{name: "gzip bomb", body: gzipped(oversized), contentType: "application/json", gzip: true,
+			expectedCode: http.StatusRequestEntityTooLarge},
+		{name: "msgpack body", body: "\x81\xa7message\xdb\x7f\xff\xff\xff" + strings.Repeat("x", handler.MaxMessageSize),
+			contentType: "application/msgpack", expectedCode: http.StatusRequestEntityTooLarge},
+		{name: "gzip body within the limit", body: gzipped(`{"metadata":{}}`), contentType: "application/json", gzip: true,
+			expectedCode: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := mocks.NewMockMessageService(gomock.NewController(t))
+			messages.EXPECT().RecordRejected(gomock.Any(), gomock.Any()).AnyTimes()
+			router := SetupRouter(messages, nil, nil, nil, nil, nil, nil, nil, Options{})
+
+			req := httptest.NewRequest(http.MethodPost, "/messages", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.gzip {
+				req.Header.Set("Content-Encoding", "gzip")
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestVary(t *testing.T) {
+	rockets := mocks.NewMockRocketService(gomock.NewController(t))
+	rockets.EXPECT().LastModified(gomock.Any())
+	rockets.EXPECT().ListRockets(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	router := SetupRouter(nil, rockets, nil, nil, nil, nil, nil, nil, Options{})
+
+	req := httptest.NewRequest(http.MethodGet, "/rockets", nil)
+	req.Header.Set("Accept", "application/xml")
+	req.Header.Set("Accept-Encoding", "gzip")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, []string{"Accept-Encoding, Accept"}, rec.Header().Values("Vary"),
+		"responses negotiated from Accept and compressed from Accept-Encoding vary with both, in a single header")
+}
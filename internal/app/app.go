@@ -0,0 +1,108 @@
+// Package app assembles the repositories, message queue, services and router of the service with wire, so that
+// alternative backends and test compositions are declared as provider sets instead of being wired by hand.
+//
+// Run go generate after changing a provider set or an injector to regenerate wire_gen.go.
+package app
+
+//go:generate go run github.com/google/wire/cmd/wire
+
+import (
+	"github.com/ahernandez9/rockets/internal/config"
+	"github.com/ahernandez9/rockets/internal/feed"
+	"github.com/ahernandez9/rockets/internal/lock"
+	"github.com/ahernandez9/rockets/internal/pubsub"
+	"github.com/ahernandez9/rockets/internal/pubsub/channel"
+	"github.com/ahernandez9/rockets/internal/pubsub/nats"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/ahernandez9/rockets/internal/tracing"
+
+	"github.com/google/wire"
+)
+
+// Services holds the services of the application and the dependencies the rest of the process shares with them
+type Services struct {
+	Messages service.MessageService
+	Rockets  service.RocketService
+	Backup   service.BackupService
+	Fleets   service.FleetService
+	Webhooks service.WebhookService
+
+	Changes           *feed.Feed
+	Repository        repository.RocketRepository
+	Events            repository.EventRepository
+	WebhookRepository repository.WebhookRepository
+	PubSub            pubsub.Interface
+}
+
+// InMemorySet provides the in-memory repositories, lost on restart
+var InMemorySet = wire.NewSet(
+	NewChangeFeed,
+	NewRocketRepository,
+	NewEventRepository,
+	NewTrackRepository,
+	inmemory.NewMissionProjection,
+	wire.Bind(new(repository.MissionRepository), new(*inmemory.MissionProjection)),
+	inmemory.NewTypeCatalog,
+	wire.Bind(new(repository.RocketTypeRepository), new(*inmemory.TypeCatalog)),
+	inmemory.NewInMemoryFleetRepository,
+	wire.Bind(new(repository.FleetRepository), new(*inmemory.FleetRepository)),
+	inmemory.NewInMemoryWebhookRepository,
+	wire.Bind(new(repository.WebhookRepository), new(*inmemory.WebhookRepository)),
+)
+
+// PubSubSet provides the message queue of the configured backend
+var PubSubSet = wire.NewSet(NewPubSub)
+
+// ServiceSet provides the services on top of repositories and a message queue
+var ServiceSet = wire.NewSet(
+	service.NewMessageService,
+	service.NewRocketService,
+	service.NewBackupService,
+	service.NewFleetService,
+	service.NewWebhookService,
+	wire.Struct(new(Services), "*"),
+)
+
+// NewChangeFeed creates the feed of rocket changes, keeping the configured history for streams to resume from
+func NewChangeFeed(cfg config.Repository) *feed.Feed {
+	return feed.New(cfg.ChangeFeedSize)
+}
+
+// NewRocketRepository creates the in-memory rocket repository, keeping the mission and type projections up to date,
+// publishing changes to the feed and traced
+func NewRocketRepository(missions *inmemory.MissionProjection, types *inmemory.TypeCatalog, changes *feed.Feed) repository.RocketRepository {
+	return tracing.WrapRepository(feed.WrapRepository(types.Wrap(missions.Wrap(inmemory.NewInMemoryRepository())), changes))
+}
+
+// NewEventRepository creates the in-memory event repository, keeping the configured number of events per rocket
+func NewEventRepository(cfg config.Repository) repository.EventRepository {
+	return inmemory.NewInMemoryEventRepository(cfg.EventsPerRocket)
+}
+
+// NewTrackRepository creates the in-memory track repository, keeping the configured number of positions per rocket
+func NewTrackRepository(cfg config.Repository) repository.TrackRepository {
+	return inmemory.NewInMemoryTrackRepository(cfg.TrackLength)
+}
+
+// NewPubSub creates the message queue of the configured backend, whose messages are processed under the lock of
+// their channel: the in-process queue with the configured buffer, or a NATS JetStream stream
+func NewPubSub(cfg config.PubSub, locker lock.Locker) (pubsub.Interface, error) {
+	if cfg.Backend != config.PubSubNATS {
+		return lock.WrapPubSub(channel.NewPubSub(cfg.BufferSize), locker), nil
+	}
+	ps, err := nats.NewPubSub(nats.Options{
+		URL:           cfg.NATS.URL,
+		Stream:        cfg.NATS.Stream,
+		Subject:       cfg.NATS.Subject,
+		Consumer:      cfg.NATS.Consumer,
+		AckWait:       cfg.NATS.AckWait,
+		MaxDeliver:    cfg.NATS.MaxDeliver,
+		EncryptionKey: cfg.NATS.EncryptionKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return lock.WrapPubSub(ps, locker), nil
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/api"
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/config"
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/health"
+	"github.com/ahernandez9/rockets/internal/lock"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/notify"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInjectors(t *testing.T) {
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+
+	services, err := NewServices(cfg, service.AnomalyDetector{}, metrics.Nop{}, errreport.Nop{}, lock.None{})
+	require.NoError(t, err)
+	require.NoError(t, services.Repository.Ping(t.Context()))
+	require.NoError(t, services.PubSub.Ping(t.Context()))
+
+	router := NewRouter(services, notify.NewDispatcher(nil, notify.DefaultOptions()), health.NewChecker(), api.Options{})
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/rockets", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// recordingSink accepts every notification
+type recordingSink struct{}
+
+func (recordingSink) Name() string { return "recording" }
+
+func (recordingSink) Send(context.Context, *models.Notification) error { return nil }
+
+func TestEraseChannelLeavesNothingBehind(t *testing.T) {
+	const (
+		adminKey = "admin-key"
+		erased   = "193270a9-c9cf-404a-8f83-838e71d9ae67"
+		kept     = "5c1a3e2f-0d4b-4f7e-9a62-1b8c7d3e4f50"
+	)
+
+	cfg, err := config.Load(nil)
+	require.NoError(t, err)
+	services, err := NewServices(cfg, service.AnomalyDetector{}, metrics.Nop{}, errreport.Nop{}, lock.None{})
+	require.NoError(t, err)
+	go services.Messages.Start()
+	t.Cleanup(services.Messages.Stop)
+
+	notifications := notify.NewDispatcher([]notify.Sink{recordingSink{}}, notify.DefaultOptions())
+	go notifications.Run(t.Context())
+
+	keys := auth.NewKeys()
+	require.NoError(t, keys.Add(adminKey, auth.Identity{Name: "admin", Scopes: []auth.Scope{auth.ScopeAdmin}}))
+	router := NewRouter(services, notifications, health.NewChecker(), api.Options{Keys: keys})
+
+	serve := func(ctx context.Context, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequestWithContext(ctx, method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middleware.APIKeyHeader, adminKey)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	send := func(channel string, number int, messageType, message string) {
+		body := fmt.Sprintf(`{"metadata":{"channel":%q,"messageNumber":%d,"messageTime":"2022-02-02T19:39:0%d+01:00",`+
+			`"messageType":%q},"message":%s}`, channel, number, number, messageType, message)
+		rec := serve(t.Context(), http.MethodPost, "/messages?sync=true", body)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	}
+
+	// The change of the kept rocket comes first, so that every change of the erased one can be replayed after it
+	send(kept, 1, "RocketLaunched", `{"type":"Falcon-9","launchSpeed":500,"mission":"ARTEMIS"}`)
+	send(erased, 1, "RocketLaunched", `{"type":"Falcon-9","launchSpeed":500,"mission":"ARTEMIS"}`)
+	send(erased, 2, "RocketPositionUpdated", `{"latitude":28.5,"longitude":-80.6,"altitude":100}`)
+	send(erased, 3, "RocketExploded", `{"reason":"PRESSURE_VESSEL_FAILURE"}`)
+	notifications.Notify(&models.Notification{Kind: models.NotificationRocketExploded, RocketID: erased})
+	require.Eventually(t, func() bool { return len(notifications.Deliveries()) == 1 }, time.Second, 10*time.Millisecond)
+
+	rec := serve(t.Context(), http.MethodDelete, "/admin/channels/"+erased+"/data", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var report models.ErasureReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+	assert.True(t, report.RocketDeleted)
+	assert.Equal(t, 3, report.EventsDeleted)
+	assert.Equal(t, 1, report.TrackPointsDeleted)
+	assert.Equal(t, 4, report.ChangesDeleted, "3 updates and the deletion")
+	assert.Equal(t, 1, report.DeliveriesDeleted)
+
+	for _, path := range []string{"/rockets/" + erased, "/rockets/" + erased + "/metrics"} {
+		assert.Equal(t, http.StatusNotFound, serve(t.Context(), http.MethodGet, path, "").Code, path)
+	}
+	for _, path := range []string{"/rockets/" + erased + "/events", "/rockets/" + erased + "/track"} {
+		rec := serve(t.Context(), http.MethodGet, path, "")
+		if rec.Code == http.StatusOK {
+			assert.NotContains(t, rec.Body.String(), `"channel"`, path)
+		}
+	}
+	for _, path := range []string{"/rockets", "/rockets/summary", "/missions/ARTEMIS/rockets", "/admin/notifications",
+		"/admin/state/export?includeEvents=true"} {
+		rec := serve(t.Context(), http.MethodGet, path, "")
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.NotContains(t, rec.Body.String(), erased, path)
+	}
+
+	// Resuming the stream after the first change replays every retained change
+	send(kept, 2, "RocketSpeedIncreased", `{"by":100}`)
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+	rec = serve(ctx, http.MethodGet, "/rockets/stream?lastEventId=1", "")
+	assert.Contains(t, rec.Body.String(), kept, "other channels are kept")
+	assert.NotContains(t, rec.Body.String(), erased)
+}
@@ -0,0 +1,39 @@
+//go:build wireinject
+
+package app
+
+import (
+	"github.com/ahernandez9/rockets/internal/api"
+	"github.com/ahernandez9/rockets/internal/config"
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/health"
+	"github.com/ahernandez9/rockets/internal/lock"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/notify"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/wire"
+)
+
+// NewServices assembles the services on the backends of the configuration, processing messages under the locks of
+// locker. It fails when a backend is unreachable
+func NewServices(cfg config.Config, detector service.AnomalyDetector, recorder metrics.Recorder,
+	reporter errreport.Reporter, locker lock.Locker) (*Services, error) {
+	wire.Build(
+		wire.FieldsOf(new(config.Config), "Repository", "PubSub"),
+		InMemorySet,
+		PubSubSet,
+		ServiceSet,
+	)
+	return nil, nil
+}
+
+// NewRouter assembles the HTTP router of the services
+func NewRouter(services *Services, notifications *notify.Dispatcher, checker *health.Checker, opts api.Options) *gin.Engine {
+	wire.Build(
+		wire.FieldsOf(new(*Services), "Messages", "Rockets", "Backup", "Fleets", "Webhooks", "Changes"),
+		api.SetupRouter,
+	)
+	return nil
+}
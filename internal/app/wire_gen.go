@@ -0,0 +1,71 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package app
+
+import (
+	"github.com/ahernandez9/rockets/internal/api"
+	"github.com/ahernandez9/rockets/internal/config"
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/health"
+	"github.com/ahernandez9/rockets/internal/lock"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/notify"
+	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/gin-gonic/gin"
+)
+
+// Injectors from wire.go:
+
+// NewServices assembles the services on the backends of the configuration, processing messages under the locks of
+// locker. It fails when a backend is unreachable
+func NewServices(cfg config.Config, detector service.AnomalyDetector, recorder metrics.Recorder, reporter errreport.Reporter, locker lock.Locker) (*Services, error) {
+	pubSub := cfg.PubSub
+	pubsubInterface, err := NewPubSub(pubSub, locker)
+	if err != nil {
+		return nil, err
+	}
+	missionProjection := inmemory.NewMissionProjection()
+	typeCatalog := inmemory.NewTypeCatalog()
+	repository := cfg.Repository
+	feed := NewChangeFeed(repository)
+	rocketRepository := NewRocketRepository(missionProjection, typeCatalog, feed)
+	eventRepository := NewEventRepository(repository)
+	trackRepository := NewTrackRepository(repository)
+	messageService := service.NewMessageService(pubsubInterface, rocketRepository, eventRepository, trackRepository, detector, recorder, reporter)
+	rocketService := service.NewRocketService(rocketRepository, eventRepository, trackRepository, missionProjection, typeCatalog)
+	backupService := service.NewBackupService(rocketRepository, eventRepository)
+	fleetRepository := inmemory.NewInMemoryFleetRepository()
+	fleetService := service.NewFleetService(fleetRepository, rocketRepository)
+	webhookRepository := inmemory.NewInMemoryWebhookRepository()
+	webhookService := service.NewWebhookService(webhookRepository)
+	services := &Services{
+		Messages:          messageService,
+		Rockets:           rocketService,
+		Backup:            backupService,
+		Fleets:            fleetService,
+		Webhooks:          webhookService,
+		Changes:           feed,
+		Repository:        rocketRepository,
+		Events:            eventRepository,
+		WebhookRepository: webhookRepository,
+		PubSub:            pubsubInterface,
+	}
+	return services, nil
+}
+
+// NewRouter assembles the HTTP router of the services
+func NewRouter(services *Services, notifications *notify.Dispatcher, checker *health.Checker, opts api.Options) *gin.Engine {
+	messageService := services.Messages
+	rocketService := services.Rockets
+	backupService := services.Backup
+	fleetService := services.Fleets
+	webhookService := services.Webhooks
+	feed := services.Changes
+	engine := api.SetupRouter(messageService, rocketService, backupService, fleetService, webhookService, feed, notifications, checker, opts)
+	return engine
+}
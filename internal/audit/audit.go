@@ -0,0 +1,80 @@
+// Package audit keeps a record of the operations changing the service state (message posts, admin actions, manual
+// corrections), with who performed them and a digest of their payload, for compliance and incident forensics.
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// DefaultSize is the number of entries kept when none is configured
+const DefaultSize = 10000
+
+// Log keeps the most recent audit entries in memory. Every entry is also written to the service logs, so that the
+// record outlives restarts and evictions wherever logs are shipped
+type Log struct {
+	size int
+
+	mu      sync.Mutex
+	lastID  uint64
+	entries []*models.AuditEntry // oldest first
+}
+
+// NewLog creates a log keeping up to size entries
+func NewLog(size int) *Log {
+	return &Log{size: size}
+}
+
+// Record adds an entry to the log, evicting the oldest one when the log is full
+func (l *Log) Record(ctx context.Context, entry models.AuditEntry) {
+	l.mu.Lock()
+	l.lastID++
+	entry.ID = l.lastID
+	if len(l.entries) == l.size {
+		copy(l.entries, l.entries[1:])
+		l.entries = l.entries[:len(l.entries)-1]
+	}
+	l.entries = append(l.entries, &entry)
+	l.mu.Unlock()
+
+	// The actor is part of the logging context of authenticated requests
+	slog.InfoContext(ctx, "Audit", "auditId", entry.ID, "method", entry.Method, "path", entry.Path,
+		"status", entry.Status, "payloadDigest", entry.PayloadDigest)
+}
+
+// Query selects audit entries
+type Query struct {
+	Actor string    // Only entries of this actor when set
+	Since time.Time // Only entries recorded at or after this time when set
+	Limit int       // At most this many entries when positive
+}
+
+// Entries returns the entries matching q, most recent first
+func (l *Log) Entries(q Query) []*models.AuditEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entries := make([]*models.AuditEntry, 0)
+	for i := len(l.entries) - 1; i >= 0; i-- {
+		if q.Limit > 0 && len(entries) == q.Limit {
+			break
+		}
+
+		entry := l.entries[i]
+		if entry.Time.Before(q.Since) {
+			break // Older entries are before Since too
+		}
+		if q.Actor != "" && entry.Actor != q.Actor {
+			continue
+		}
+
+		copied := *entry
+		entries = append(entries, &copied)
+	}
+
+	return entries
+}
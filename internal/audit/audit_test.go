@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogEvictsOldestEntries(t *testing.T) {
+	log := NewLog(2)
+	for _, path := range []string{"/a", "/b", "/c"} {
+		log.Record(context.Background(), models.AuditEntry{Time: time.Now(), Path: path})
+	}
+
+	entries := log.Entries(Query{})
+	require.Len(t, entries, 2)
+	assert.Equal(t, "/c", entries[0].Path)
+	assert.Equal(t, uint64(3), entries[0].ID)
+	assert.Equal(t, "/b", entries[1].Path)
+}
+
+func TestLogEntriesQuery(t *testing.T) {
+	start := time.Date(2022, 2, 2, 19, 0, 0, 0, time.UTC)
+	log := NewLog(DefaultSize)
+	log.Record(context.Background(), models.AuditEntry{Time: start, Actor: "admin", Path: "/old"})
+	log.Record(context.Background(), models.AuditEntry{Time: start.Add(time.Minute), Path: "/messages"})
+	log.Record(context.Background(), models.AuditEntry{Time: start.Add(2 * time.Minute), Actor: "admin", Path: "/a"})
+	log.Record(context.Background(), models.AuditEntry{Time: start.Add(3 * time.Minute), Actor: "admin", Path: "/b"})
+
+	entries := log.Entries(Query{Actor: "admin", Since: start.Add(time.Minute)})
+	require.Len(t, entries, 2)
+	assert.Equal(t, "/b", entries[0].Path)
+	assert.Equal(t, "/a", entries[1].Path)
+
+	entries = log.Entries(Query{Limit: 1})
+	require.Len(t, entries, 1)
+	assert.Equal(t, "/b", entries[0].Path)
+}
@@ -0,0 +1,169 @@
+// Package auth identifies API callers by the keys they present, so that ingestion and administrative endpoints are
+// only reachable by known producers and operators, and so that requests can be attributed to them.
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Scope is a set of endpoints a key grants access to
+type Scope string
+
+const (
+	ScopeIngest Scope = "ingest" // Submitting messages
+	ScopeAdmin  Scope = "admin"  // Administrative endpoints
+	ScopeStream Scope = "stream" // Issuing stream tokens, e.g. for the backend of a dashboard
+)
+
+// Identity is the caller a key belongs to
+type Identity struct {
+	Name       string  // Identifies the caller in logs, audit entries and quotas
+	Scopes     []Scope // Endpoints the caller may use
+	RateLimit  int     // Requests per minute; 0 for the default limit
+	DailyQuota int     // Requests per UTC day; 0 for the default quota
+}
+
+// Allows tells whether the identity was granted scope
+func (id Identity) Allows(scope Scope) bool {
+	return slices.Contains(id.Scopes, scope)
+}
+
+// KeyStore resolves API keys to the identity of their owner
+type KeyStore interface {
+	// Lookup returns the identity owning key; false when the key is unknown
+	Lookup(ctx context.Context, key string) (Identity, bool)
+	// Enabled tells whether any key grants scope, i.e. whether its endpoints require a key
+	Enabled(scope Scope) bool
+}
+
+// Keys is a KeyStore holding keys from the configuration. Only digests of the keys are kept in memory
+type Keys struct {
+	identities map[[sha256.Size]byte]Identity
+	names      map[string]bool
+}
+
+// NewKeys creates an empty key store
+func NewKeys() *Keys {
+	return &Keys{identities: make(map[[sha256.Size]byte]Identity), names: make(map[string]bool)}
+}
+
+// Add registers a key. Names must be unique, so that callers can be told apart
+func (k *Keys) Add(key string, id Identity) error {
+	if key == "" || id.Name == "" {
+		return errors.New("API keys need a key and a name")
+	}
+	if k.names[id.Name] {
+		return fmt.Errorf("duplicate API key name %q", id.Name)
+	}
+	digest := sha256.Sum256([]byte(key))
+	if _, found := k.identities[digest]; found {
+		return fmt.Errorf("API key of %q is already registered", id.Name)
+	}
+	for _, scope := range id.Scopes {
+		if scope != ScopeIngest && scope != ScopeAdmin && scope != ScopeStream {
+			return fmt.Errorf("unknown scope %q for API key %q", scope, id.Name)
+		}
+	}
+
+	k.identities[digest] = id
+	k.names[id.Name] = true
+	return nil
+}
+
+func (k *Keys) Lookup(_ context.Context, key string) (Identity, bool) {
+	id, found := k.identities[sha256.Sum256([]byte(key))]
+	return id, found
+}
+
+func (k *Keys) Enabled(scope Scope) bool {
+	for _, id := range k.identities {
+		if id.Allows(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseKeys registers keys given as comma-separated name:key pairs, each granted scope,
+// e.g. "producer-eu:k3y,producer-us:0th3r"
+func (k *Keys) ParseKeys(value string, scope Scope) error {
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, key, found := strings.Cut(pair, ":")
+		if !found {
+			return errors.New("API keys must be given as name:key pairs")
+		}
+		if err := k.Add(key, Identity{Name: name, Scopes: []Scope{scope}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// keyFileEntry is a key of a key file
+type keyFileEntry struct {
+	Name       string  `json:"name"`
+	Key        string  `json:"key"`
+	Scopes     []Scope `json:"scopes"`
+	RateLimit  int     `json:"rateLimit"`
+	DailyQuota int     `json:"dailyQuota"`
+}
+
+// LoadFile registers the keys of a JSON file listing them with their scopes and optional limits,
+// e.g. [{"name": "producer-eu", "key": "k3y", "scopes": ["ingest"], "rateLimit": 600, "dailyQuota": 500000}]
+func (k *Keys) LoadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var entries []keyFileEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("invalid key file %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		id := Identity{Name: entry.Name, Scopes: entry.Scopes, RateLimit: entry.RateLimit, DailyQuota: entry.DailyQuota}
+		if err := k.Add(entry.Key, id); err != nil {
+			return fmt.Errorf("invalid key file %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Chain is a KeyStore trying each of its stores in turn, e.g. static API keys and then OIDC tokens
+type Chain []KeyStore
+
+func (c Chain) Lookup(ctx context.Context, key string) (Identity, bool) {
+	for _, store := range c {
+		if id, found := store.Lookup(ctx, key); found {
+			return id, true
+		}
+	}
+	return Identity{}, false
+}
+
+func (c Chain) Enabled(scope Scope) bool {
+	return slices.ContainsFunc(c, func(store KeyStore) bool { return store.Enabled(scope) })
+}
+
+type identityKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the identity of the caller
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the identity of the caller carried by ctx; false for anonymous requests
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
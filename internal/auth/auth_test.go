@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeys(t *testing.T) {
+	keys := NewKeys()
+	require.NoError(t, keys.ParseKeys("producer-eu:k3y, producer-us:0th3r,", ScopeIngest))
+
+	assert.True(t, keys.Enabled(ScopeIngest))
+	assert.False(t, keys.Enabled(ScopeAdmin))
+
+	id, found := keys.Lookup(context.Background(), "0th3r")
+	require.True(t, found)
+	assert.Equal(t, "producer-us", id.Name)
+	assert.True(t, id.Allows(ScopeIngest))
+	assert.False(t, id.Allows(ScopeAdmin))
+
+	_, found = keys.Lookup(context.Background(), "unknown")
+	assert.False(t, found)
+}
+
+func TestKeysRejectsInvalidKeys(t *testing.T) {
+	keys := NewKeys()
+	require.NoError(t, keys.Add("k3y", Identity{Name: "producer-eu", Scopes: []Scope{ScopeIngest}}))
+
+	assert.Error(t, keys.Add("other", Identity{Name: "producer-eu"}), "duplicate name")
+	assert.Error(t, keys.Add("k3y", Identity{Name: "producer-us"}), "duplicate key")
+	assert.Error(t, keys.Add("", Identity{Name: "empty"}))
+	assert.Error(t, keys.Add("s3cret", Identity{Name: "root", Scopes: []Scope{"root"}}))
+	assert.Error(t, keys.ParseKeys("k3y-without-name", ScopeIngest))
+}
+
+func TestKeysLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[{"name": "ops", "key": "4dm1n", "scopes": ["admin", "ingest"]}]`), 0o600))
+
+	keys := NewKeys()
+	require.NoError(t, keys.LoadFile(path))
+
+	id, found := keys.Lookup(context.Background(), "4dm1n")
+	require.True(t, found)
+	assert.Equal(t, "ops", id.Name)
+	assert.True(t, id.Allows(ScopeAdmin))
+	assert.True(t, keys.Enabled(ScopeIngest))
+}
+
+func TestSigningSecretsVerify(t *testing.T) {
+	secrets, err := ParseSigningSecrets("producer-eu:s3cret, producer-us:0th3r")
+	require.NoError(t, err)
+	body := []byte(`{"metadata":{}}`)
+
+	producer, ok := secrets.Verify("", body, Sign("0th3r", body))
+	assert.True(t, ok)
+	assert.Equal(t, "producer-us", producer, "unidentified callers are named after the matching secret")
+
+	_, ok = secrets.Verify("producer-eu", body, Sign("0th3r", body))
+	assert.False(t, ok, "identified producers must use their own secret")
+
+	_, ok = secrets.Verify("", []byte(`{"metadata":{"channel":"x"}}`), Sign("s3cret", body))
+	assert.False(t, ok)
+
+	_, err = ParseSigningSecrets("producer-eu")
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// OIDCOptions configures the login of operators through an OpenID Connect provider
+type OIDCOptions struct {
+	Issuer       string   // URL of the provider, e.g. https://accounts.google.com
+	ClientID     string   // Client registered with the provider; ID tokens must be issued for it
+	ClientSecret string   // Secret of the client, used to exchange authorization codes
+	RedirectURL  string   // Callback of the service known to the provider, e.g. https://rockets.example.com/auth/callback
+	AdminEmails  []string // Verified emails granted the admin scope
+	AdminGroups  []string // Values of the groups claim granted the admin scope
+}
+
+// OIDC is a KeyStore accepting ID tokens of an OpenID Connect provider in place of keys, so that operators log in
+// with their own account instead of sharing static keys. Operators are named after their email, or subject when the
+// provider doesn't share it, and granted the admin scope when listed in AdminEmails or AdminGroups
+type OIDC struct {
+	opts     OIDCOptions
+	verifier *oidc.IDTokenVerifier
+	oauth2   oauth2.Config
+}
+
+// NewOIDC discovers the endpoints and signing keys of the provider
+func NewOIDC(ctx context.Context, opts OIDCOptions) (*OIDC, error) {
+	if len(opts.AdminEmails) == 0 && len(opts.AdminGroups) == 0 {
+		return nil, errors.New("OIDC login needs admin emails or groups, otherwise nobody could use it")
+	}
+
+	provider, err := oidc.NewProvider(ctx, opts.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDC{
+		opts:     opts,
+		verifier: provider.Verifier(&oidc.Config{ClientID: opts.ClientID}),
+		oauth2: oauth2.Config{
+			ClientID:     opts.ClientID,
+			ClientSecret: opts.ClientSecret,
+			RedirectURL:  opts.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+		},
+	}, nil
+}
+
+// idTokenClaims are the claims of ID tokens identifying operators
+type idTokenClaims struct {
+	Email         string   `json:"email"`
+	EmailVerified bool     `json:"email_verified"`
+	Groups        []string `json:"groups"`
+}
+
+// Lookup verifies an ID token issued by the provider for the client and returns the identity of its owner
+func (o *OIDC) Lookup(ctx context.Context, token string) (Identity, bool) {
+	idToken, err := o.verifier.Verify(ctx, token)
+	if err != nil {
+		return Identity{}, false
+	}
+
+	id, err := o.identify(idToken)
+	return id, err == nil
+}
+
+// identify names the owner of a verified ID token and grants them the admin scope when they are listed
+func (o *OIDC) identify(idToken *oidc.IDToken) (Identity, error) {
+	var claims idTokenClaims
+	if err := idToken.Claims(&claims); err != nil {
+		return Identity{}, err
+	}
+
+	id := Identity{Name: idToken.Subject}
+	if claims.Email != "" && claims.EmailVerified {
+		id.Name = claims.Email
+	}
+	if claims.EmailVerified && slices.Contains(o.opts.AdminEmails, claims.Email) ||
+		slices.ContainsFunc(claims.Groups, func(group string) bool { return slices.Contains(o.opts.AdminGroups, group) }) {
+		id.Scopes = []Scope{ScopeAdmin}
+	}
+	return id, nil
+}
+
+// Enabled tells whether operators may log in for scope; only administrative endpoints are offered
+func (o *OIDC) Enabled(scope Scope) bool {
+	return scope == ScopeAdmin
+}
+
+// AuthCodeURL returns the login page of the provider, which redirects back to the callback with state
+func (o *OIDC) AuthCodeURL(state string) string {
+	return o.oauth2.AuthCodeURL(state)
+}
+
+// Exchange trades the authorization code received by the callback for an ID token, verified before it is returned
+// along with its expiry and the identity of the operator
+func (o *OIDC) Exchange(ctx context.Context, code string) (string, time.Time, Identity, error) {
+	token, err := o.oauth2.Exchange(ctx, code)
+	if err != nil {
+		return "", time.Time{}, Identity{}, err
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return "", time.Time{}, Identity{}, errors.New("no ID token in the token response")
+	}
+	idToken, err := o.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return "", time.Time{}, Identity{}, err
+	}
+	id, err := o.identify(idToken)
+	if err != nil {
+		return "", time.Time{}, Identity{}, err
+	}
+	return rawIDToken, idToken.Expiry, id, nil
+}
@@ -0,0 +1,98 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProvider serves the discovery document and signing keys of an OIDC provider, and signs ID tokens
+type fakeProvider struct {
+	*httptest.Server
+	signer jose.Signer
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: "1"}}, nil)
+	require.NoError(t, err)
+
+	p := &fakeProvider{signer: signer}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                 p.URL,
+			"authorization_endpoint": p.URL + "/authorize",
+			"token_endpoint":         p.URL + "/token",
+			"jwks_uri":               p.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "1", Algorithm: string(jose.RS256), Use: "sig"},
+		}})
+	})
+	p.Server = httptest.NewServer(mux)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func (p *fakeProvider) token(t *testing.T, claims map[string]any) string {
+	claims["iss"] = p.URL
+	claims["exp"] = time.Now().Add(time.Hour).Unix()
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signed, err := p.signer.Sign(payload)
+	require.NoError(t, err)
+	token, err := signed.CompactSerialize()
+	require.NoError(t, err)
+	return token
+}
+
+func TestOIDCLookup(t *testing.T) {
+	provider := newFakeProvider(t)
+	o, err := NewOIDC(context.Background(), OIDCOptions{
+		Issuer:      provider.URL,
+		ClientID:    "rockets",
+		AdminEmails: []string{"alice@example.com"},
+		AdminGroups: []string{"launch-ops"},
+	})
+	require.NoError(t, err)
+
+	id, found := o.Lookup(context.Background(), provider.token(t, map[string]any{
+		"sub": "1", "aud": "rockets", "email": "alice@example.com", "email_verified": true,
+	}))
+	require.True(t, found)
+	assert.Equal(t, "alice@example.com", id.Name)
+	assert.True(t, id.Allows(ScopeAdmin))
+
+	id, found = o.Lookup(context.Background(), provider.token(t, map[string]any{
+		"sub": "2", "aud": "rockets", "groups": []string{"launch-ops"},
+	}))
+	require.True(t, found)
+	assert.Equal(t, "2", id.Name)
+	assert.True(t, id.Allows(ScopeAdmin))
+
+	id, found = o.Lookup(context.Background(), provider.token(t, map[string]any{
+		"sub": "3", "aud": "rockets", "email": "alice@example.com", "email_verified": false,
+	}))
+	require.True(t, found)
+	assert.False(t, id.Allows(ScopeAdmin), "unverified emails are not trusted")
+
+	_, found = o.Lookup(context.Background(), provider.token(t, map[string]any{"sub": "1", "aud": "other"}))
+	assert.False(t, found, "tokens issued for other clients are rejected")
+
+	_, found = o.Lookup(context.Background(), "k3y")
+	assert.False(t, found)
+}
@@ -0,0 +1,58 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// SigningSecrets are the secrets shared with producers to sign the messages they submit, by producer name
+type SigningSecrets map[string]string
+
+// ParseSigningSecrets reads secrets given as comma-separated name:secret pairs, e.g. "producer-eu:s3cret"
+func ParseSigningSecrets(value string) (SigningSecrets, error) {
+	secrets := make(SigningSecrets)
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		name, secret, found := strings.Cut(pair, ":")
+		if !found || name == "" || secret == "" {
+			return nil, errors.New("signing secrets must be given as name:secret pairs")
+		}
+		if _, found := secrets[name]; found {
+			return nil, fmt.Errorf("duplicate signing secret for %q", name)
+		}
+		secrets[name] = secret
+	}
+	return secrets, nil
+}
+
+// Sign returns the signature of body keyed with secret, formatted as "sha256=<hex>" like webhook deliveries
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of body. The secret of producer is used when the producer is known, e.g. from its API
+// key; otherwise every secret is tried and the producer is identified by the one that matches
+func (s SigningSecrets) Verify(producer string, body []byte, signature string) (string, bool) {
+	if secret, found := s[producer]; found {
+		return producer, hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+	}
+
+	// Every secret is tried, so that the time taken doesn't tell which one matched
+	matched := ""
+	for _, name := range slices.Sorted(maps.Keys(s)) {
+		if hmac.Equal([]byte(Sign(s[name], body)), []byte(signature)) && matched == "" {
+			matched = name
+		}
+	}
+	return matched, matched != ""
+}
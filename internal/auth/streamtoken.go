@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidStreamToken is returned for stream tokens that are malformed, tampered with or expired
+var ErrInvalidStreamToken = errors.New("invalid stream token")
+
+// StreamGrant is what a stream token authorizes: changes of the listed channels and of the rockets of the listed
+// missions
+type StreamGrant struct {
+	Subject   string    `json:"sub"` // Caller the token was issued to
+	Channels  []string  `json:"channels,omitempty"`
+	Missions  []string  `json:"missions,omitempty"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// Allows tells whether the grant covers a rocket, given its channel and mission
+func (g StreamGrant) Allows(channel, mission string) bool {
+	return slices.Contains(g.Channels, channel) || mission != "" && slices.Contains(g.Missions, mission)
+}
+
+// StreamTokens issues and verifies short-lived tokens scoping subscriptions to streams, so that browser dashboards
+// can subscribe without holding long-lived keys. Tokens are the grant in JSON and its HMAC-SHA256, both base64url
+// encoded and separated by a dot; they can't be revoked and should be kept short-lived
+type StreamTokens struct {
+	secret []byte
+	maxTTL time.Duration
+}
+
+// NewStreamTokens creates the issuer of tokens signed with secret, valid for up to maxTTL
+func NewStreamTokens(secret string, maxTTL time.Duration) *StreamTokens {
+	return &StreamTokens{secret: []byte(secret), maxTTL: maxTTL}
+}
+
+// MaxTTL is the longest validity of issued tokens
+func (t *StreamTokens) MaxTTL() time.Duration {
+	return t.maxTTL
+}
+
+// Issue signs a token for grant, valid for ttl from now, capped to the longest validity
+func (t *StreamTokens) Issue(grant StreamGrant, ttl time.Duration, now time.Time) (string, StreamGrant, error) {
+	if ttl <= 0 || ttl > t.maxTTL {
+		ttl = t.maxTTL
+	}
+	grant.ExpiresAt = now.Add(ttl).UTC().Truncate(time.Second)
+
+	payload, err := json.Marshal(grant)
+	if err != nil {
+		return "", grant, err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(t.sign(encoded)), grant, nil
+}
+
+// Verify checks the signature and expiry of a token and returns its grant
+func (t *StreamTokens) Verify(token string, now time.Time) (StreamGrant, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return StreamGrant{}, ErrInvalidStreamToken
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, t.sign(encoded)) {
+		return StreamGrant{}, ErrInvalidStreamToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return StreamGrant{}, ErrInvalidStreamToken
+	}
+	var grant StreamGrant
+	if err := json.Unmarshal(payload, &grant); err != nil || !now.Before(grant.ExpiresAt) {
+		return StreamGrant{}, ErrInvalidStreamToken
+	}
+	return grant, nil
+}
+
+func (t *StreamTokens) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
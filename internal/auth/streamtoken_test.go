@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStreamTokens(t *testing.T) {
+	tokens := NewStreamTokens("s3cret", time.Hour)
+	now := time.Date(2022, 2, 2, 19, 0, 0, 0, time.UTC)
+
+	token, grant, err := tokens.Issue(StreamGrant{Subject: "dashboard", Missions: []string{"ARTEMIS"}}, 24*time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), grant.ExpiresAt, "TTL is capped")
+
+	verified, err := tokens.Verify(token, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, grant, verified)
+	assert.True(t, verified.Allows("193270a9-c9cf-404a-8f83-838e71d9ae67", "ARTEMIS"))
+	assert.False(t, verified.Allows("193270a9-c9cf-404a-8f83-838e71d9ae67", "APOLLO"))
+	assert.False(t, verified.Allows("193270a9-c9cf-404a-8f83-838e71d9ae67", ""))
+
+	_, err = tokens.Verify(token, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidStreamToken, "expired")
+
+	payload, signature, _ := strings.Cut(token, ".")
+	_, err = tokens.Verify(payload[1:]+"."+signature, now)
+	assert.ErrorIs(t, err, ErrInvalidStreamToken, "tampered")
+
+	_, err = NewStreamTokens("other", time.Hour).Verify(token, now)
+	assert.ErrorIs(t, err, ErrInvalidStreamToken, "other secret")
+}
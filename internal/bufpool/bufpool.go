@@ -0,0 +1,51 @@
+// Package bufpool recycles the buffers of the hot paths: the request bodies and lines decoded at ingestion, the
+// messages encoded for the broker and the events written to streams. Once the pools are warm, handling a message
+// allocates no buffer, which spares the garbage collector at high message rates.
+package bufpool
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+)
+
+const (
+	// maxPooledSize bounds the capacity of the buffers returned to the pool, so that an occasional large body does
+	// not stay allocated
+	maxPooledSize = 64 << 10
+	// lineSize is the initial capacity of the line buffers of the scanners, as bufio.Scanner allocates it
+	lineSize = 64 << 10
+)
+
+var (
+	buffers = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+	lines   = sync.Pool{New: func() any {
+		line := make([]byte, lineSize)
+		return &line
+	}}
+)
+
+// Get returns an empty buffer from the pool. It must be returned with Put once its bytes are no longer used
+func Get() *bytes.Buffer {
+	return buffers.Get().(*bytes.Buffer)
+}
+
+// Put empties buf and returns it to the pool, unless it grew too large to be kept
+func Put(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledSize {
+		return
+	}
+	buf.Reset()
+	buffers.Put(buf)
+}
+
+// NewScanner returns a scanner of the lines of r, up to maxLineSize bytes, reading into a pooled buffer. release
+// returns the buffer to the pool once the scanner and the lines it returned are no longer used
+func NewScanner(r io.Reader, maxLineSize int) (scanner *bufio.Scanner, release func()) {
+	line := lines.Get().(*[]byte)
+	scanner = bufio.NewScanner(r)
+	// Scanners accept lines as long as their buffer, which must not exceed maxLineSize
+	scanner.Buffer((*line)[:0:min(lineSize, maxLineSize)], maxLineSize)
+	return scanner, func() { lines.Put(line) }
+}
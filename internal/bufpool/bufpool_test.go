@@ -0,0 +1,172 @@
+package bufpool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var message = &models.RocketMessage{
+	Metadata: models.MessageMetadata{Channel: "193270a9-c9cf-404a-8f83-838e71d9ae67", MessageNumber: 1,
+		MessageTime: time.Date(2022, 2, 2, 19, 39, 5, 0, time.UTC), MessageType: "RocketLaunched"},
+	Message: json.RawMessage(`{"type":"Falcon-9","launchSpeed":500,"mission":"ARTEMIS"}`),
+}
+
+// stream returns a streamed ingest of n messages, one per line
+func stream(n int) []byte {
+	line, _ := json.Marshal(message)
+	return bytes.Repeat(append(line, '\n'), n)
+}
+
+func TestGetReturnsEmptyBuffers(t *testing.T) {
+	buf := Get()
+	buf.WriteString("used")
+	Put(buf)
+	assert.Zero(t, Get().Len())
+
+	large := Get()
+	large.Grow(2 * maxPooledSize)
+	Put(large)
+	assert.NotSame(t, large, Get(), "large buffers are not kept")
+}
+
+func TestNewScannerBoundsLines(t *testing.T) {
+	body := "first\n" + strings.Repeat("x", 100) + "\nlast\n"
+	scanner, release := NewScanner(strings.NewReader(body), 10)
+	defer release()
+
+	require.True(t, scanner.Scan())
+	assert.Equal(t, "first", scanner.Text())
+	assert.False(t, scanner.Scan())
+	assert.ErrorIs(t, scanner.Err(), bufio.ErrTooLong)
+}
+
+// The benchmarks compare the allocations of the hot paths before pooling, first, with the pooled buffers:
+// go test -run '^$' -bench . -benchmem ./internal/bufpool
+
+// BenchmarkDecodeRequest decodes the body of POST /messages
+func BenchmarkDecodeRequest(b *testing.B) {
+	body := stream(1)
+	b.Run("decoder", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var msg models.RocketMessage
+			if err := json.NewDecoder(bytes.NewReader(body)).Decode(&msg); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			var msg models.RocketMessage
+			buf := Get()
+			if _, err := buf.ReadFrom(bytes.NewReader(body)); err != nil {
+				b.Fatal(err)
+			}
+			if err := jsoncodec.Unmarshal(buf.Bytes(), &msg); err != nil {
+				b.Fatal(err)
+			}
+			Put(buf)
+		}
+	})
+}
+
+// BenchmarkScanStream reads the lines of a streamed ingest of 100 messages
+func BenchmarkScanStream(b *testing.B) {
+	body := stream(100)
+	scan := func(b *testing.B, scanner *bufio.Scanner) {
+		for scanner.Scan() {
+		}
+		if err := scanner.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.Run("allocated", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			scanner := bufio.NewScanner(bytes.NewReader(body))
+			scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+			scan(b, scanner)
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			scanner, release := NewScanner(bytes.NewReader(body), 1<<20)
+			scan(b, scanner)
+			release()
+		}
+	})
+}
+
+// BenchmarkEncodeMessage encodes a message for the broker
+func BenchmarkEncodeMessage(b *testing.B) {
+	b.Run("marshal", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			data, err := jsoncodec.Marshal(message)
+			if err != nil {
+				b.Fatal(err)
+			}
+			_, _ = io.Discard.Write(data)
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			buf := Get()
+			if err := jsoncodec.MarshalTo(buf, message); err != nil {
+				b.Fatal(err)
+			}
+			_, _ = io.Discard.Write(buf.Bytes())
+			Put(buf)
+		}
+	})
+}
+
+// BenchmarkWriteEvent writes a change of a rocket as a Server-Sent Event
+func BenchmarkWriteEvent(b *testing.B) {
+	change := &models.RocketChange{ID: 42, Kind: "updated", RocketID: message.Metadata.Channel,
+		Rocket: &models.Rocket{ID: message.Metadata.Channel, Type: "Falcon-9", Speed: 500, Mission: "ARTEMIS",
+			Status: models.StatusActive}, Time: message.Metadata.MessageTime}
+	b.Run("fprintf", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			data, err := jsoncodec.Marshal(change)
+			if err != nil {
+				b.Fatal(err)
+			}
+			_, _ = fmt.Fprintf(io.Discard, "id: %d\nevent: rocket.%s\ndata: %s\n\n", change.ID, change.Kind, data)
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			buf := Get()
+			buf.WriteString("id: ")
+			buf.Write(strconv.AppendUint(buf.AvailableBuffer(), change.ID, 10))
+			buf.WriteString("\nevent: rocket.")
+			buf.WriteString(change.Kind)
+			buf.WriteString("\ndata: ")
+			if err := jsoncodec.MarshalTo(buf, change); err != nil {
+				b.Fatal(err)
+			}
+			buf.WriteString("\n\n")
+			_, _ = io.Discard.Write(buf.Bytes())
+			Put(buf)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+// Package cluster shards the rockets across instances by consistent hashing of their channels, so that the in-memory
+// model scales horizontally: each instance only applies the messages and serves the rockets of the channels it owns,
+// and requests reaching another instance are forwarded to the owner.
+package cluster
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// virtualNodes is the number of points of each member on the ring, spreading the channels evenly between members
+const virtualNodes = 128
+
+// ErrNotOwner is returned for a channel owned by another member
+var ErrNotOwner = errors.New("channel owned by another instance")
+
+// Member is an instance of the cluster
+type Member struct {
+	Name string
+	URL  *url.URL // Base URL of the HTTP API of the instance, which requests for its channels are forwarded to
+}
+
+// ParseMembers parses members given as name=url, e.g. rockets-0=http://rockets-0.rockets:8088
+func ParseMembers(values []string) ([]Member, error) {
+	members := make([]Member, 0, len(values))
+	for _, value := range values {
+		name, raw, ok := strings.Cut(value, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("cluster member %q must be name=url", value)
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("cluster member %s must have an http or https URL, got %q", name, raw)
+		}
+		if slices.ContainsFunc(members, func(m Member) bool { return m.Name == name }) {
+			return nil, fmt.Errorf("cluster member %s is listed twice", name)
+		}
+		members = append(members, Member{Name: name, URL: u})
+	}
+	return members, nil
+}
+
+// point is a position of a member on the ring
+type point struct {
+	hash   uint64
+	member int
+}
+
+// Ring assigns every channel to a member. Adding or removing a member only moves the channels of the hash ranges it
+// gains or loses
+type Ring struct {
+	members []Member
+	self    int
+	points  []point
+}
+
+// NewRing creates the ring of members, seen from the member named self
+func NewRing(members []Member, self string) (*Ring, error) {
+	r := &Ring{members: members, self: -1}
+	for i, m := range members {
+		if m.Name == self {
+			r.self = i
+		}
+		for v := range virtualNodes {
+			r.points = append(r.points, point{hash: hash(m.Name + "#" + strconv.Itoa(v)), member: i})
+		}
+	}
+	if r.self < 0 {
+		return nil, fmt.Errorf("instance %q is not a member of the cluster", self)
+	}
+	slices.SortFunc(r.points, func(a, b point) int { return cmp.Compare(a.hash, b.hash) })
+	return r, nil
+}
+
+// Owner returns the member owning channel: the first one clockwise from the hash of the channel
+func (r *Ring) Owner(channel string) Member {
+	h := hash(channel)
+	i, _ := slices.BinarySearchFunc(r.points, h, func(p point, h uint64) int { return cmp.Compare(p.hash, h) })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.members[r.points[i].member]
+}
+
+// Self returns the member the ring is seen from
+func (r *Ring) Self() Member {
+	return r.members[r.self]
+}
+
+// Owns tells whether channel belongs to the member the ring is seen from
+func (r *Ring) Owns(channel string) bool {
+	return r.Owner(channel).Name == r.Self().Name
+}
+
+// hash spreads keys evenly on the ring, unlike faster hashes on keys as similar as the names of virtual nodes
+func hash(key string) uint64 {
+	sum := sha256.Sum256([]byte(key))
+	return binary.BigEndian.Uint64(sum[:8])
+}
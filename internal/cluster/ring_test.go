@@ -0,0 +1,69 @@
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRing(t *testing.T, self string, names ...string) *Ring {
+	var values []string
+	for _, name := range names {
+		values = append(values, fmt.Sprintf("%s=http://%s:8088", name, name))
+	}
+	members, err := ParseMembers(values)
+	require.NoError(t, err)
+	ring, err := NewRing(members, self)
+	require.NoError(t, err)
+	return ring
+}
+
+func TestRingAgreesOnOwners(t *testing.T) {
+	a, b := newTestRing(t, "a", "a", "b", "c"), newTestRing(t, "b", "c", "b", "a")
+
+	owned := map[string]int{}
+	for range 3000 {
+		channel := uuid.NewString()
+		owner := a.Owner(channel)
+		assert.Equal(t, owner.Name, b.Owner(channel).Name, "members listed in another order")
+		assert.Equal(t, owner.Name == "a", a.Owns(channel))
+		owned[owner.Name]++
+	}
+	for name, count := range owned {
+		assert.InDelta(t, 1000, count, 300, "channels of %s", name)
+	}
+}
+
+func TestRingMovesFewChannels(t *testing.T) {
+	before, after := newTestRing(t, "a", "a", "b", "c"), newTestRing(t, "a", "a", "b", "c", "d")
+
+	var moved int
+	for range 3000 {
+		channel := uuid.NewString()
+		if owner := after.Owner(channel).Name; owner != before.Owner(channel).Name {
+			assert.Equal(t, "d", owner, "channels only move to the new member")
+			moved++
+		}
+	}
+	assert.InDelta(t, 750, moved, 250)
+}
+
+func TestParseMembersRejectsInvalidMembers(t *testing.T) {
+	for _, values := range [][]string{
+		{"a"},
+		{"=http://a:8088"},
+		{"a=a:8088"},
+		{"a=http://a:8088", "a=http://b:8088"},
+	} {
+		_, err := ParseMembers(values)
+		assert.Error(t, err, "%v", values)
+	}
+
+	members, err := ParseMembers([]string{"a=http://a:8088"})
+	require.NoError(t, err)
+	_, err = NewRing(members, "b")
+	assert.Error(t, err, "the instance must be a member")
+}
@@ -0,0 +1,45 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/service"
+)
+
+// shardedMessageService only publishes the messages of the channels owned by the instance
+type shardedMessageService struct {
+	service.MessageService
+	ring *Ring
+}
+
+// WrapMessageService returns a message service rejecting the messages of channels owned by other members, so that the
+// state of a rocket is only ever applied by its owner. Single submissions are forwarded to the owner before reaching
+// it, see middleware.Forward; messages of streams and gRPC calls are rejected one by one
+func WrapMessageService(ms service.MessageService, ring *Ring) service.MessageService {
+	return &shardedMessageService{MessageService: ms, ring: ring}
+}
+
+// PublishMessage publishes a message of an owned channel
+func (s *shardedMessageService) PublishMessage(ctx context.Context, msg *models.RocketMessage) error {
+	if err := s.owns(msg); err != nil {
+		return err
+	}
+	return s.MessageService.PublishMessage(ctx, msg)
+}
+
+// PublishMessageAndWait publishes a message of an owned channel and waits for it to be processed
+func (s *shardedMessageService) PublishMessageAndWait(ctx context.Context, msg *models.RocketMessage) (*models.Rocket, error) {
+	if err := s.owns(msg); err != nil {
+		return nil, err
+	}
+	return s.MessageService.PublishMessageAndWait(ctx, msg)
+}
+
+func (s *shardedMessageService) owns(msg *models.RocketMessage) error {
+	if owner := s.ring.Owner(msg.Metadata.Channel); owner.Name != s.ring.Self().Name {
+		return fmt.Errorf("%w: channel %s belongs to %s (%s)", ErrNotOwner, msg.Metadata.Channel, owner.Name, owner.URL)
+	}
+	return nil
+}
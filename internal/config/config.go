@@ -0,0 +1,669 @@
+// Package config loads the settings of the service (listening ports, backends, buffer sizes, timeouts, limits,
+// observability and security) from a configuration file, the environment and command-line flags, in increasing order
+// of precedence, and validates them. Credentials are not settings, they are read from the secrets provider. The
+// settings of Reloadable can be changed without restarting, see Watch.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/audit"
+	"github.com/ahernandez9/rockets/internal/cluster"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/pubsub/nats"
+	"github.com/ahernandez9/rockets/internal/retention"
+	"github.com/ahernandez9/rockets/internal/server"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// Supported backends
+const (
+	PubSubChannel    = "channel" // In-process Go channels
+	PubSubNATS       = "nats"    // NATS JetStream
+	RepositoryMemory = "memory"  // In-memory maps, lost on restart
+	ElectionNone     = "none"    // The instance processes messages alone
+	ElectionRedis    = "redis"   // A lease held in Redis
+	LockNone         = "none"    // Messages are processed without locks
+	LockRedis        = "redis"   // Locks held in Redis
+)
+
+// Supported metrics backends
+const (
+	MetricsPrometheus = "prometheus" // Scraped from GET /metrics
+	MetricsStatsD     = "statsd"     // Pushed to a StatsD or Datadog agent
+)
+
+// Supported secrets providers
+const (
+	SecretsEnv   = "env"   // Environment variables
+	SecretsFile  = "file"  // One file per secret, as mounted by Docker and Kubernetes
+	SecretsVault = "vault" // A KV version 2 secret of HashiCorp Vault
+	SecretsAWS   = "aws"   // A JSON secret of AWS Secrets Manager
+)
+
+// ErrHelp is returned by Load when the flags ask for the usage, which is then printed
+var ErrHelp = pflag.ErrHelp
+
+// Config holds the core settings of the service
+type Config struct {
+	File       string     `mapstructure:"-"`    // Configuration file read, if any
+	Seed       string     `mapstructure:"seed"` // File of rockets loaded into the repository at startup, if any
+	Server     Server     `mapstructure:"server"`
+	PubSub     PubSub     `mapstructure:"pubsub"`
+	Repository Repository `mapstructure:"repository"`
+	Shutdown   Shutdown   `mapstructure:"shutdown"`
+	Retention  Retention  `mapstructure:"retention"`
+	Election   Election   `mapstructure:"election"`
+	Cluster    Cluster    `mapstructure:"cluster"`
+	Lock       Lock       `mapstructure:"lock"`
+	Dev        Dev        `mapstructure:"dev"`
+	LogOutput  LogOutput  `mapstructure:"log"`
+	Metrics    Metrics    `mapstructure:"metrics"`
+	Sentry     Sentry     `mapstructure:"sentry"`
+	TLS        TLS        `mapstructure:"tls"`
+	CORS       CORS       `mapstructure:"cors"`
+	Auth       Auth       `mapstructure:"auth"`
+	OIDC       OIDC       `mapstructure:"oidc"`
+	Ingest     Ingest     `mapstructure:"ingest"`
+	Audit      Audit      `mapstructure:"audit"`
+	Notify     Notify     `mapstructure:"notify"`
+	Secrets    Secrets    `mapstructure:"secrets"`
+
+	Reloadable `mapstructure:",squash"`
+}
+
+// Reloadable holds the settings that are safe to change while running, without losing the in-memory state
+type Reloadable struct {
+	Log     Log     `mapstructure:"log"`
+	Limits  Limits  `mapstructure:"limits"`
+	Ages    Ages    `mapstructure:"retention"`
+	Anomaly Anomaly `mapstructure:"anomaly"`
+}
+
+// Log configures the default logger
+type Log struct {
+	Level string `mapstructure:"level"` // debug, info, warn or error
+}
+
+// Limits configures the load limits of the route groups and the default limits of API callers
+type Limits struct {
+	Ingest        middleware.LimitOptions `mapstructure:"ingest"`
+	Admin         middleware.LimitOptions `mapstructure:"admin"`
+	Query         middleware.LimitOptions `mapstructure:"query"`
+	KeyRate       int                     `mapstructure:"keyRate"`       // Requests per minute of a caller
+	KeyDailyQuota int                     `mapstructure:"keyDailyQuota"` // Requests per UTC day of a caller
+}
+
+// Retention configures how stale rockets are reaped; their ages are in Ages
+type Retention struct {
+	Action   string        `mapstructure:"action"`
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// Ages are the ages after which rockets are reaped, zero keeping them forever
+type Ages struct {
+	MaxAge         time.Duration `mapstructure:"maxAge"`
+	ExplodedMaxAge time.Duration `mapstructure:"explodedMaxAge"`
+}
+
+// Anomaly configures the detection of implausible telemetry
+type Anomaly struct {
+	MaxSpeedDelta int `mapstructure:"maxSpeedDelta"` // Largest plausible speed change of a message, in km/h
+}
+
+// Server configures the HTTP and gRPC servers
+type Server struct {
+	Port                 int           `mapstructure:"port"`
+	GRPCPort             int           `mapstructure:"grpcPort"`
+	Listen               []string      `mapstructure:"listen"`     // Addresses of the HTTP API, host:port or unix:/path, instead of the port
+	GRPCListen           []string      `mapstructure:"grpcListen"` // Addresses of the gRPC API, instead of grpcPort
+	ReadHeaderTimeout    time.Duration `mapstructure:"readHeaderTimeout"`
+	ReadTimeout          time.Duration `mapstructure:"readTimeout"`
+	WriteTimeout         time.Duration `mapstructure:"writeTimeout"`
+	IdleTimeout          time.Duration `mapstructure:"idleTimeout"`
+	MaxHeaderBytes       int           `mapstructure:"maxHeaderBytes"`
+	H2C                  bool          `mapstructure:"h2c"`                  // HTTP/2 without TLS, behind load balancers speaking it
+	MaxConcurrentStreams int           `mapstructure:"maxConcurrentStreams"` // Requests in flight on an HTTP/2 connection
+	TrustedProxies       []string      `mapstructure:"trustedProxies"`       // Networks whose forwarded client addresses are believed
+}
+
+// HTTPAddrs returns the addresses the HTTP API listens on
+func (s Server) HTTPAddrs() []string {
+	if len(s.Listen) > 0 {
+		return s.Listen
+	}
+	return []string{fmt.Sprintf(":%d", s.Port)}
+}
+
+// GRPCAddrs returns the addresses the gRPC API listens on
+func (s Server) GRPCAddrs() []string {
+	if len(s.GRPCListen) > 0 {
+		return s.GRPCListen
+	}
+	return []string{fmt.Sprintf(":%d", s.GRPCPort)}
+}
+
+// PubSub configures the queue of messages waiting to be processed
+type PubSub struct {
+	Backend    string `mapstructure:"backend"`
+	BufferSize int    `mapstructure:"bufferSize"` // Messages queued before submissions are turned away
+	NATS       NATS   `mapstructure:"nats"`
+}
+
+// NATS configures the NATS backend of the pub/sub
+type NATS struct {
+	URL        string        `mapstructure:"url"`
+	Stream     string        `mapstructure:"stream"`
+	Subject    string        `mapstructure:"subject"`
+	Consumer   string        `mapstructure:"consumer"`
+	AckWait    time.Duration `mapstructure:"ackWait"`
+	MaxDeliver int           `mapstructure:"maxDeliver"`
+	// Embedded runs a NATS server within the process, for local development, instead of connecting to URL
+	Embedded     bool   `mapstructure:"embedded"`
+	EmbeddedAddr string `mapstructure:"embeddedAddr"`
+	StoreDir     string `mapstructure:"storeDir"` // Files of the embedded server, temporary when empty
+	// EncryptionKey seals the messages stored by the broker, read from the PUBSUB_ENCRYPTION_KEY secret rather than
+	// from the configuration
+	EncryptionKey []byte `mapstructure:"-"`
+}
+
+// Repository configures the storage of rockets and their history
+type Repository struct {
+	Backend         string `mapstructure:"backend"`
+	EventsPerRocket int    `mapstructure:"eventsPerRocket"` // Events kept per rocket, oldest dropped first
+	TrackLength     int    `mapstructure:"trackLength"`     // Positions kept per rocket
+	ChangeFeedSize  int    `mapstructure:"changeFeedSize"`  // Changes kept for streams to resume from
+}
+
+// Shutdown configures how the service stops
+type Shutdown struct {
+	DrainDelay time.Duration `mapstructure:"drainDelay"` // Time between failing readiness and stopping
+	Timeout    time.Duration `mapstructure:"timeout"`    // Time left to requests in flight
+}
+
+// Election configures the election of the single instance processing messages, among instances sharing a broker
+// and a repository
+type Election struct {
+	Backend   string        `mapstructure:"backend"`
+	RedisAddr string        `mapstructure:"redisAddr"`
+	Key       string        `mapstructure:"key"` // Key of the lease, one per partition elected separately
+	TTL       time.Duration `mapstructure:"ttl"` // Lease duration, how long a failed leader holds the others back
+}
+
+// Lock configures the locks serializing the processing of the messages of a channel, among consumers sharing a
+// repository
+type Lock struct {
+	Backend   string        `mapstructure:"backend"`
+	RedisAddr string        `mapstructure:"redisAddr"`
+	Prefix    string        `mapstructure:"prefix"` // Prefix of the keys of the locks
+	TTL       time.Duration `mapstructure:"ttl"`    // Lease of a lock, released by then even if its holder failed
+	Wait      time.Duration `mapstructure:"wait"`   // Time waited for a lock before the message is given up
+}
+
+// Cluster configures the sharding of the channels between instances; without members the instance owns them all
+type Cluster struct {
+	Self    string   `mapstructure:"self"`    // Name of the instance among the members
+	Members []string `mapstructure:"members"` // Instances sharing the channels, as name=url
+}
+
+// Dev configures the telemetry of fake rockets generated in development
+type Dev struct {
+	Rockets  int           `mapstructure:"rockets"`  // Rockets flying at once, none by default
+	Interval time.Duration `mapstructure:"interval"` // Delay between two messages of a rocket
+}
+
+// LogOutput configures the format and destination of the logs. Unlike their level, they are set at startup
+type LogOutput struct {
+	Format string  `mapstructure:"format"` // text or json
+	File   LogFile `mapstructure:"file"`
+}
+
+// LogFile configures the file the logs are also written to, rotated by size and age
+type LogFile struct {
+	Path       string        `mapstructure:"path"`       // No file when empty
+	MaxSizeMB  int64         `mapstructure:"maxSizeMB"`  // 0 for no limit
+	MaxAge     time.Duration `mapstructure:"maxAge"`     // 0 for no limit
+	MaxBackups int           `mapstructure:"maxBackups"` // Rotated files kept, 0 keeps them all
+}
+
+// Metrics configures where the metrics go
+type Metrics struct {
+	Backend           string `mapstructure:"backend"`
+	RocketGaugesLimit int    `mapstructure:"rocketGaugesLimit"` // Rockets exported as gauges, none by default
+	StatsD            StatsD `mapstructure:"statsd"`
+}
+
+// StatsD configures the agent the metrics are pushed to by the statsd backend
+type StatsD struct {
+	Addr      string `mapstructure:"addr"`
+	Prefix    string `mapstructure:"prefix"`
+	DogStatsD bool   `mapstructure:"dogstatsd"` // Labels sent as DogStatsD tags rather than appended to the names
+}
+
+// Sentry describes the deployment in the failures reported to Sentry, whose DSN is a secret
+type Sentry struct {
+	Environment string `mapstructure:"environment"`
+	Release     string `mapstructure:"release"`
+}
+
+// TLS configures HTTPS, disabled while no certificate is set
+type TLS struct {
+	CertFile     string `mapstructure:"certFile"`
+	KeyFile      string `mapstructure:"keyFile"`
+	ClientCAFile string `mapstructure:"clientCAFile"` // CAs of the client certificates
+	ClientAuth   string `mapstructure:"clientAuth"`   // none, optional or required; required once clientCAFile is set
+}
+
+// CORS configures the cross-origin requests of browser dashboards, disabled while no origin is allowed
+type CORS struct {
+	AllowedOrigins []string      `mapstructure:"allowedOrigins"`
+	AllowedMethods []string      `mapstructure:"allowedMethods"`
+	AllowedHeaders []string      `mapstructure:"allowedHeaders"`
+	MaxAge         time.Duration `mapstructure:"maxAge"`
+}
+
+// Auth configures the API keys and stream tokens, whose secrets come from the secrets provider
+type Auth struct {
+	KeysFile          string        `mapstructure:"keysFile"`          // JSON file of API keys with their scopes
+	StreamTokenMaxTTL time.Duration `mapstructure:"streamTokenMaxTTL"` // Longest lifetime of a stream token
+}
+
+// OIDC configures the login of operators through an OpenID Connect provider, disabled while no issuer is set
+type OIDC struct {
+	Issuer      string   `mapstructure:"issuer"`
+	ClientID    string   `mapstructure:"clientID"`
+	RedirectURL string   `mapstructure:"redirectURL"`
+	AdminEmails []string `mapstructure:"adminEmails"`
+	AdminGroups []string `mapstructure:"adminGroups"`
+}
+
+// Ingest restricts the telemetry accepted
+type Ingest struct {
+	AllowedNetworks    []string `mapstructure:"allowedNetworks"`    // Networks of the producers, any by default
+	AllowedRocketTypes []string `mapstructure:"allowedRocketTypes"` // Rocket types launches may have, any by default
+}
+
+// Audit configures the audit log of the requests changing the state
+type Audit struct {
+	Size int `mapstructure:"size"` // Entries kept, oldest dropped first
+}
+
+// Notify configures the notification sinks other than Slack, whose webhook URL is a secret
+type Notify struct {
+	WebhookURL string `mapstructure:"webhookURL"`
+	SMTP       SMTP   `mapstructure:"smtp"`
+}
+
+// SMTP configures the e-mail notifications, disabled while no server address is set
+type SMTP struct {
+	Addr     string   `mapstructure:"addr"`
+	Username string   `mapstructure:"username"`
+	From     string   `mapstructure:"from"`
+	To       []string `mapstructure:"to"`
+}
+
+// Secrets configures the provider of the credentials
+type Secrets struct {
+	Provider    string `mapstructure:"provider"`
+	Dir         string `mapstructure:"dir"`         // Directory of the file provider
+	VaultAddr   string `mapstructure:"vaultAddr"`   // The token of the vault provider is read from VAULT_TOKEN only
+	VaultPath   string `mapstructure:"vaultPath"`   // Path of the KV secret, e.g. secret/data/rockets
+	AWSSecretID string `mapstructure:"awsSecretID"` // Name or ARN of the secret of the aws provider
+}
+
+// setting is a configuration key with its default, environment variable and flag, if any
+type setting struct {
+	key   string
+	def   any
+	env   string
+	flag  string
+	usage string
+}
+
+// settings keeps the environment variables the service has always read, so that existing deployments keep working
+var settings = []setting{
+	{"seed", "", "SEED_FILE", "seed", "file of rockets loaded at startup: a JSON array or an exported dump"},
+	{"server.port", 8088, "PORT", "port", "port of the HTTP API"},
+	{"server.grpcPort", 9090, "GRPC_PORT", "grpc-port", "port of the gRPC API"},
+	{"server.listen", []string{}, "HTTP_LISTEN", "", ""},
+	{"server.grpcListen", []string{}, "GRPC_LISTEN", "", ""},
+	{"server.readHeaderTimeout", server.DefaultOptions().ReadHeaderTimeout, "HTTP_READ_HEADER_TIMEOUT", "", ""},
+	{"server.readTimeout", server.DefaultOptions().ReadTimeout, "HTTP_READ_TIMEOUT", "", ""},
+	{"server.writeTimeout", server.DefaultOptions().WriteTimeout, "HTTP_WRITE_TIMEOUT", "", ""},
+	{"server.idleTimeout", server.DefaultOptions().IdleTimeout, "HTTP_IDLE_TIMEOUT", "", ""},
+	{"server.maxHeaderBytes", server.DefaultOptions().MaxHeaderBytes, "HTTP_MAX_HEADER_BYTES", "", ""},
+	{"server.h2c", false, "HTTP_H2C", "", ""},
+	{"server.maxConcurrentStreams", server.DefaultOptions().MaxConcurrentStreams, "HTTP2_MAX_CONCURRENT_STREAMS", "", ""},
+	{"server.trustedProxies", []string{}, "TRUSTED_PROXIES", "", ""},
+	{"pubsub.backend", PubSubChannel, "PUBSUB_BACKEND", "pubsub-backend", "queue of messages: channel or nats"},
+	{"pubsub.bufferSize", 1000, "PUBSUB_BUFFER_SIZE", "", ""},
+	{"pubsub.nats.url", nats.DefaultOptions().URL, "PUBSUB_NATS_URL", "", ""},
+	{"pubsub.nats.stream", nats.DefaultOptions().Stream, "PUBSUB_NATS_STREAM", "", ""},
+	{"pubsub.nats.subject", nats.DefaultOptions().Subject, "PUBSUB_NATS_SUBJECT", "", ""},
+	{"pubsub.nats.consumer", nats.DefaultOptions().Consumer, "PUBSUB_NATS_CONSUMER", "", ""},
+	{"pubsub.nats.ackWait", nats.DefaultOptions().AckWait, "PUBSUB_NATS_ACK_WAIT", "", ""},
+	{"pubsub.nats.maxDeliver", nats.DefaultOptions().MaxDeliver, "PUBSUB_NATS_MAX_DELIVER", "", ""},
+	{"pubsub.nats.embedded", false, "PUBSUB_NATS_EMBEDDED", "", ""},
+	{"pubsub.nats.embeddedAddr", "127.0.0.1:4222", "PUBSUB_NATS_EMBEDDED_ADDR", "", ""},
+	{"pubsub.nats.storeDir", "", "PUBSUB_NATS_STORE_DIR", "", ""},
+	{"repository.backend", RepositoryMemory, "REPOSITORY_BACKEND", "repository-backend", "storage of rockets: memory"},
+	{"repository.eventsPerRocket", 1000, "REPOSITORY_EVENTS_PER_ROCKET", "", ""},
+	{"repository.trackLength", 500, "REPOSITORY_TRACK_LENGTH", "", ""},
+	{"repository.changeFeedSize", 1000, "REPOSITORY_CHANGE_FEED_SIZE", "", ""},
+	{"shutdown.drainDelay", time.Duration(0), "SHUTDOWN_DRAIN_DELAY", "", ""},
+	{"shutdown.timeout", 30 * time.Second, "SHUTDOWN_TIMEOUT", "", ""},
+	{"election.backend", ElectionNone, "ELECTION_BACKEND", "", ""},
+	{"election.redisAddr", "", "ELECTION_REDIS_ADDR", "", ""},
+	{"election.key", "rockets:leader", "ELECTION_KEY", "", ""},
+	{"election.ttl", 15 * time.Second, "ELECTION_TTL", "", ""},
+	{"lock.backend", LockNone, "LOCK_BACKEND", "", ""},
+	{"lock.redisAddr", "", "LOCK_REDIS_ADDR", "", ""},
+	{"lock.prefix", "rockets:lock:", "LOCK_PREFIX", "", ""},
+	{"lock.ttl", 10 * time.Second, "LOCK_TTL", "", ""},
+	{"lock.wait", 5 * time.Second, "LOCK_WAIT", "", ""},
+	{"cluster.self", "", "CLUSTER_SELF", "", ""},
+	{"cluster.members", []string{}, "CLUSTER_MEMBERS", "", ""},
+	{"dev.rockets", 0, "DEV_ROCKETS", "dev", "generate the telemetry of N fake rockets, 10 with --dev alone"},
+	{"dev.interval", time.Second, "DEV_INTERVAL", "", ""},
+	{"retention.action", string(retention.ActionArchive), "RETENTION_ACTION", "", ""},
+	{"retention.interval", time.Hour, "RETENTION_INTERVAL", "", ""},
+	{"retention.maxAge", time.Duration(0), "RETENTION_MAX_AGE", "", ""},
+	{"retention.explodedMaxAge", time.Duration(0), "RETENTION_EXPLODED_MAX_AGE", "", ""},
+	{"log.level", "info", "LOG_LEVEL", "", ""},
+	{"limits.ingest.rate", 0.0, "RATE_LIMIT_INGEST", "", ""},
+	{"limits.ingest.burst", 0, "RATE_LIMIT_INGEST_BURST", "", ""},
+	{"limits.ingest.maxInFlight", 0, "MAX_IN_FLIGHT_INGEST", "", ""},
+	{"limits.admin.rate", 0.0, "RATE_LIMIT_ADMIN", "", ""},
+	{"limits.admin.burst", 0, "RATE_LIMIT_ADMIN_BURST", "", ""},
+	{"limits.admin.maxInFlight", 0, "MAX_IN_FLIGHT_ADMIN", "", ""},
+	{"limits.query.rate", 0.0, "RATE_LIMIT_QUERY", "", ""},
+	{"limits.query.burst", 0, "RATE_LIMIT_QUERY_BURST", "", ""},
+	{"limits.query.maxInFlight", 0, "MAX_IN_FLIGHT_QUERY", "", ""},
+	{"limits.keyRate", 0, "API_KEY_RATE_LIMIT", "", ""},
+	{"limits.keyDailyQuota", 0, "API_KEY_DAILY_QUOTA", "", ""},
+	{"anomaly.maxSpeedDelta", 10000, "ANOMALY_MAX_SPEED_DELTA", "", ""},
+	{"log.format", logging.FormatText, "LOG_FORMAT", "log-format", "format of the logs: text or json"},
+	{"log.file.path", "", "LOG_FILE", "log-file", "file the logs are also written to"},
+	{"log.file.maxSizeMB", int64(100), "LOG_FILE_MAX_SIZE_MB", "", ""},
+	{"log.file.maxAge", 24 * time.Hour, "LOG_FILE_MAX_AGE", "", ""},
+	{"log.file.maxBackups", 7, "LOG_FILE_MAX_BACKUPS", "", ""},
+	{"metrics.backend", MetricsPrometheus, "METRICS_BACKEND", "metrics-backend", "metrics backend: prometheus or statsd"},
+	{"metrics.rocketGaugesLimit", 0, "METRICS_ROCKET_GAUGES_LIMIT", "", ""},
+	{"metrics.statsd.addr", "localhost:8125", "STATSD_ADDR", "", ""},
+	{"metrics.statsd.prefix", "rockets.", "STATSD_PREFIX", "", ""},
+	{"metrics.statsd.dogstatsd", false, "STATSD_DOGSTATSD", "", ""},
+	{"sentry.environment", "", "SENTRY_ENVIRONMENT", "", ""},
+	{"sentry.release", "", "SENTRY_RELEASE", "", ""},
+	{"tls.certFile", "", "TLS_CERT_FILE", "tls-cert-file", "certificate of the HTTPS API (PEM)"},
+	{"tls.keyFile", "", "TLS_KEY_FILE", "tls-key-file", "private key of the certificate (PEM)"},
+	{"tls.clientCAFile", "", "TLS_CLIENT_CA_FILE", "", ""},
+	{"tls.clientAuth", "", "TLS_CLIENT_AUTH", "", ""},
+	{"cors.allowedOrigins", []string{}, "CORS_ALLOWED_ORIGINS", "", ""},
+	{"cors.allowedMethods", []string{}, "CORS_ALLOWED_METHODS", "", ""},
+	{"cors.allowedHeaders", []string{}, "CORS_ALLOWED_HEADERS", "", ""},
+	{"cors.maxAge", 12 * time.Hour, "CORS_MAX_AGE", "", ""},
+	{"auth.keysFile", "", "API_KEYS_FILE", "", ""},
+	{"auth.streamTokenMaxTTL", time.Hour, "STREAM_TOKEN_MAX_TTL", "", ""},
+	{"oidc.issuer", "", "OIDC_ISSUER", "", ""},
+	{"oidc.clientID", "", "OIDC_CLIENT_ID", "", ""},
+	{"oidc.redirectURL", "", "OIDC_REDIRECT_URL", "", ""},
+	{"oidc.adminEmails", []string{}, "OIDC_ADMIN_EMAILS", "", ""},
+	{"oidc.adminGroups", []string{}, "OIDC_ADMIN_GROUPS", "", ""},
+	{"ingest.allowedNetworks", []string{}, "INGEST_ALLOWED_NETWORKS", "", ""},
+	{"ingest.allowedRocketTypes", []string{}, "ALLOWED_ROCKET_TYPES", "", ""},
+	{"audit.size", audit.DefaultSize, "AUDIT_LOG_SIZE", "", ""},
+	{"notify.webhookURL", "", "NOTIFY_WEBHOOK_URL", "", ""},
+	{"notify.smtp.addr", "", "NOTIFY_SMTP_ADDR", "", ""},
+	{"notify.smtp.username", "", "NOTIFY_SMTP_USERNAME", "", ""},
+	{"notify.smtp.from", "", "NOTIFY_EMAIL_FROM", "", ""},
+	{"notify.smtp.to", []string{}, "NOTIFY_EMAIL_TO", "", ""},
+	{"secrets.provider", SecretsEnv, "SECRETS_PROVIDER", "", ""},
+	{"secrets.dir", "/run/secrets", "SECRETS_DIR", "", ""},
+	{"secrets.vaultAddr", "", "VAULT_ADDR", "", ""},
+	{"secrets.vaultPath", "", "VAULT_SECRET_PATH", "", ""},
+	{"secrets.awsSecretID", "", "AWS_SECRET_ID", "", ""},
+}
+
+// Load reads the configuration from the file given by the --config flag or CONFIG_FILE (YAML, JSON or TOML), the
+// environment and the flags of args, and validates it
+func Load(args []string) (Config, error) {
+	v := viper.New()
+
+	flags := pflag.NewFlagSet("rockets", pflag.ContinueOnError)
+	file := flags.String("config", os.Getenv("CONFIG_FILE"), "configuration file (YAML, JSON or TOML)")
+	for _, s := range settings {
+		v.SetDefault(s.key, s.def)
+		if err := v.BindEnv(s.key, s.env); err != nil {
+			return Config{}, err
+		}
+		if s.flag == "" {
+			continue
+		}
+		switch def := s.def.(type) {
+		case int:
+			flags.Int(s.flag, def, s.usage)
+		case string:
+			flags.String(s.flag, def, s.usage)
+		}
+		if err := v.BindPFlag(s.key, flags.Lookup(s.flag)); err != nil {
+			return Config{}, err
+		}
+	}
+	// --dev alone flies a small fleet
+	flags.Lookup("dev").NoOptDefVal = "10"
+	if err := flags.Parse(args); err != nil {
+		return Config{}, err
+	}
+
+	if *file != "" {
+		v.SetConfigFile(*file)
+		if err := v.ReadInConfig(); err != nil {
+			return Config{}, fmt.Errorf("reading %s: %w", *file, err)
+		}
+	}
+
+	cfg := Config{File: *file}
+	hooks := mapstructure.ComposeDecodeHookFunc(mapstructure.StringToTimeDurationHookFunc(), splitList)
+	if err := v.Unmarshal(&cfg, viper.DecodeHook(hooks)); err != nil {
+		return Config{}, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// splitList decodes the comma-separated lists of the environment, ignoring blank items
+func splitList(from, to reflect.Type, data any) (any, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Slice {
+		return data, nil
+	}
+
+	items := []string{}
+	for _, item := range strings.Split(data.(string), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// Validate reports every invalid setting at once
+func (c Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(c.Server.Port > 0 && c.Server.Port < 65536, "server.port must be between 1 and 65535, got %d", c.Server.Port)
+	check(c.Server.GRPCPort > 0 && c.Server.GRPCPort < 65536, "server.grpcPort must be between 1 and 65535, got %d", c.Server.GRPCPort)
+	check(c.Server.Port != c.Server.GRPCPort, "server.port and server.grpcPort must differ")
+	for _, addr := range append(slices.Clone(c.Server.Listen), c.Server.GRPCListen...) {
+		if path, ok := strings.CutPrefix(addr, "unix:"); ok {
+			check(path != "", "listen address %q must name the path of the socket", addr)
+		} else if _, _, err := net.SplitHostPort(addr); err != nil {
+			errs = append(errs, fmt.Errorf("listen address %q must be host:port or unix:/path: %w", addr, err))
+		}
+	}
+	check(c.Server.ReadHeaderTimeout >= 0 && c.Server.ReadTimeout >= 0 && c.Server.WriteTimeout >= 0 &&
+		c.Server.IdleTimeout >= 0, "server timeouts must not be negative")
+	check(c.Server.MaxHeaderBytes > 0, "server.maxHeaderBytes must be positive, got %d", c.Server.MaxHeaderBytes)
+	check(c.Server.MaxConcurrentStreams > 0, "server.maxConcurrentStreams must be positive, got %d",
+		c.Server.MaxConcurrentStreams)
+
+	check(c.PubSub.Backend == PubSubChannel || c.PubSub.Backend == PubSubNATS, "pubsub.backend %q must be %s or %s",
+		c.PubSub.Backend, PubSubChannel, PubSubNATS)
+	check(c.PubSub.BufferSize > 0, "pubsub.bufferSize must be positive, got %d", c.PubSub.BufferSize)
+	if c.PubSub.Backend == PubSubNATS {
+		n := c.PubSub.NATS
+		check(n.URL != "" || n.Embedded, "pubsub.nats.url is required by the %s backend", PubSubNATS)
+		check(n.Stream != "" && n.Subject != "" && n.Consumer != "",
+			"pubsub.nats.stream, subject and consumer must not be empty")
+		check(n.AckWait > 0 && n.MaxDeliver > 0, "pubsub.nats.ackWait and maxDeliver must be positive")
+		if _, _, err := net.SplitHostPort(n.EmbeddedAddr); n.Embedded && err != nil {
+			errs = append(errs, fmt.Errorf("pubsub.nats.embeddedAddr %q must be host:port: %w", n.EmbeddedAddr, err))
+		}
+	}
+
+	check(c.Repository.Backend == RepositoryMemory, "repository.backend %q must be %s", c.Repository.Backend, RepositoryMemory)
+	check(c.Repository.EventsPerRocket > 0, "repository.eventsPerRocket must be positive, got %d", c.Repository.EventsPerRocket)
+	check(c.Repository.TrackLength > 0, "repository.trackLength must be positive, got %d", c.Repository.TrackLength)
+	check(c.Repository.ChangeFeedSize > 0, "repository.changeFeedSize must be positive, got %d", c.Repository.ChangeFeedSize)
+
+	check(c.Shutdown.DrainDelay >= 0, "shutdown.drainDelay must not be negative")
+	check(c.Shutdown.Timeout > 0, "shutdown.timeout must be positive")
+
+	check(c.Election.Backend == ElectionNone || c.Election.Backend == ElectionRedis, "election.backend %q must be %s or %s",
+		c.Election.Backend, ElectionNone, ElectionRedis)
+	check(c.Election.Backend != ElectionRedis || c.Election.RedisAddr != "", "election.redisAddr is required by the %s backend",
+		ElectionRedis)
+	// Followers must leave their messages to the leader, and the leader must start from the state of the previous one
+	check(c.Election.Backend != ElectionRedis || c.PubSub.Backend == PubSubNATS && c.Repository.Backend != RepositoryMemory,
+		"election.backend %s requires a shared broker and repository, pubsub.backend %s and a repository.backend other "+
+			"than %s", ElectionRedis, PubSubNATS, RepositoryMemory)
+	check(c.Election.Key != "", "election.key must not be empty")
+	check(c.Election.TTL >= time.Second, "election.ttl must be at least a second")
+
+	check(c.Lock.Backend == LockNone || c.Lock.Backend == LockRedis, "lock.backend %q must be %s or %s",
+		c.Lock.Backend, LockNone, LockRedis)
+	check(c.Lock.Backend != LockRedis || c.Lock.RedisAddr != "", "lock.redisAddr is required by the %s backend", LockRedis)
+	// Locks only serialize the updates of consumers writing to the same rockets
+	check(c.Lock.Backend != LockRedis || c.Repository.Backend != RepositoryMemory,
+		"lock.backend %s requires a shared repository, repository.backend %s is local to the instance", LockRedis,
+		RepositoryMemory)
+	check(c.Lock.TTL > 0 && c.Lock.Wait > 0, "lock.ttl and lock.wait must be positive")
+
+	check(c.Dev.Rockets >= 0, "dev.rockets must not be negative, got %d", c.Dev.Rockets)
+	check(c.Dev.Interval > 0, "dev.interval must be positive")
+
+	if len(c.Cluster.Members) > 0 {
+		if members, err := cluster.ParseMembers(c.Cluster.Members); err != nil {
+			errs = append(errs, err)
+		} else if _, err := cluster.NewRing(members, c.Cluster.Self); err != nil {
+			errs = append(errs, fmt.Errorf("cluster.self: %w", err))
+		}
+	}
+
+	if _, err := retention.ParseAction(c.Retention.Action); err != nil {
+		errs = append(errs, err)
+	}
+	check(c.Retention.Interval > 0, "retention.interval must be positive")
+	check(c.Ages.MaxAge >= 0 && c.Ages.ExplodedMaxAge >= 0, "retention ages must not be negative")
+
+	if _, err := logging.ParseLevel(c.Log.Level); err != nil {
+		errs = append(errs, err)
+	}
+	for group, limits := range map[string]middleware.LimitOptions{"ingest": c.Limits.Ingest, "admin": c.Limits.Admin,
+		"query": c.Limits.Query} {
+		check(limits.Rate >= 0 && limits.Burst >= 0 && limits.MaxInFlight >= 0, "limits.%s must not be negative", group)
+	}
+	check(c.Limits.KeyRate >= 0 && c.Limits.KeyDailyQuota >= 0, "limits of API keys must not be negative")
+	check(c.Anomaly.MaxSpeedDelta >= 0, "anomaly.maxSpeedDelta must not be negative")
+
+	check(c.LogOutput.Format == logging.FormatText || c.LogOutput.Format == logging.FormatJSON,
+		"log.format %q must be %s or %s", c.LogOutput.Format, logging.FormatText, logging.FormatJSON)
+	check(c.LogOutput.File.MaxSizeMB >= 0 && c.LogOutput.File.MaxAge >= 0 && c.LogOutput.File.MaxBackups >= 0,
+		"log.file limits must not be negative")
+
+	check(c.Metrics.Backend == MetricsPrometheus || c.Metrics.Backend == MetricsStatsD, "metrics.backend %q must be %s or %s",
+		c.Metrics.Backend, MetricsPrometheus, MetricsStatsD)
+	check(c.Metrics.RocketGaugesLimit >= 0, "metrics.rocketGaugesLimit must not be negative, got %d",
+		c.Metrics.RocketGaugesLimit)
+	// Per-rocket gauges are only served at GET /metrics
+	check(c.Metrics.RocketGaugesLimit == 0 || c.Metrics.Backend == MetricsPrometheus,
+		"metrics.rocketGaugesLimit requires the %s metrics backend", MetricsPrometheus)
+	if _, _, err := net.SplitHostPort(c.Metrics.StatsD.Addr); c.Metrics.Backend == MetricsStatsD && err != nil {
+		errs = append(errs, fmt.Errorf("metrics.statsd.addr %q must be host:port: %w", c.Metrics.StatsD.Addr, err))
+	}
+
+	check((c.TLS.CertFile == "") == (c.TLS.KeyFile == ""), "tls.certFile and tls.keyFile must be set together")
+	check(c.TLS.CertFile != "" || c.TLS.ClientCAFile == "" && c.TLS.ClientAuth == "",
+		"tls.clientCAFile and tls.clientAuth require tls.certFile")
+	check(slices.Contains([]string{"", server.ClientAuthNone, server.ClientAuthOptional, server.ClientAuthRequired},
+		c.TLS.ClientAuth), "tls.clientAuth %q must be %s, %s or %s", c.TLS.ClientAuth, server.ClientAuthNone,
+		server.ClientAuthOptional, server.ClientAuthRequired)
+	check(c.TLS.ClientAuth == "" || c.TLS.ClientAuth == server.ClientAuthNone || c.TLS.ClientCAFile != "",
+		"tls.clientAuth %s requires tls.clientCAFile", c.TLS.ClientAuth)
+
+	check(c.CORS.MaxAge >= 0, "cors.maxAge must not be negative")
+
+	check(c.Auth.StreamTokenMaxTTL > 0, "auth.streamTokenMaxTTL must be positive")
+	if c.OIDC.Issuer != "" {
+		check(absoluteURL(c.OIDC.Issuer), "oidc.issuer %q must be an absolute URL", c.OIDC.Issuer)
+		check(c.OIDC.ClientID != "", "oidc.clientID is required with oidc.issuer")
+		check(absoluteURL(c.OIDC.RedirectURL), "oidc.redirectURL %q must be an absolute URL", c.OIDC.RedirectURL)
+		// Logins of anybody else are refused
+		check(len(c.OIDC.AdminEmails) > 0 || len(c.OIDC.AdminGroups) > 0,
+			"oidc.adminEmails or oidc.adminGroups is required with oidc.issuer")
+	}
+
+	for key, networks := range map[string][]string{"server.trustedProxies": c.Server.TrustedProxies,
+		"ingest.allowedNetworks": c.Ingest.AllowedNetworks} {
+		if _, err := middleware.ParseNetworks(networks); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	check(c.Audit.Size > 0, "audit.size must be positive, got %d", c.Audit.Size)
+
+	check(c.Notify.WebhookURL == "" || absoluteURL(c.Notify.WebhookURL), "notify.webhookURL %q must be an absolute URL",
+		c.Notify.WebhookURL)
+	if c.Notify.SMTP.Addr != "" {
+		if _, _, err := net.SplitHostPort(c.Notify.SMTP.Addr); err != nil {
+			errs = append(errs, fmt.Errorf("notify.smtp.addr %q must be host:port: %w", c.Notify.SMTP.Addr, err))
+		}
+		check(c.Notify.SMTP.From != "" && len(c.Notify.SMTP.To) > 0, "notify.smtp.from and to are required with notify.smtp.addr")
+	}
+
+	switch c.Secrets.Provider {
+	case SecretsEnv:
+	case SecretsFile:
+		check(c.Secrets.Dir != "", "secrets.dir is required by the %s provider", SecretsFile)
+	case SecretsVault:
+		check(absoluteURL(c.Secrets.VaultAddr), "secrets.vaultAddr %q must be an absolute URL", c.Secrets.VaultAddr)
+		check(c.Secrets.VaultPath != "", "secrets.vaultPath is required by the %s provider", SecretsVault)
+	case SecretsAWS:
+		check(c.Secrets.AWSSecretID != "", "secrets.awsSecretID is required by the %s provider", SecretsAWS)
+	default:
+		errs = append(errs, fmt.Errorf("secrets.provider %q must be %s, %s, %s or %s", c.Secrets.Provider, SecretsEnv,
+			SecretsFile, SecretsVault, SecretsAWS))
+	}
+
+	return errors.Join(errs...)
+}
+
+// absoluteURL reports whether value is a URL with a scheme and a host
+func absoluteURL(value string) bool {
+	u, err := url.Parse(value)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, 8088, cfg.Server.Port)
+	assert.Equal(t, 30*time.Second, cfg.Server.WriteTimeout)
+	assert.Equal(t, PubSubChannel, cfg.PubSub.Backend)
+	assert.Equal(t, 1000, cfg.PubSub.BufferSize)
+	assert.Equal(t, RepositoryMemory, cfg.Repository.Backend)
+	assert.Equal(t, 30*time.Second, cfg.Shutdown.Timeout)
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rockets.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+server:
+  port: 8000
+  grpcPort: 9000
+  writeTimeout: 1m
+pubsub:
+  bufferSize: 5000
+`), 0o600))
+	t.Setenv("GRPC_PORT", "9001")
+	t.Setenv("PUBSUB_BUFFER_SIZE", "")
+
+	cfg, err := Load([]string{"--config", file, "--port", "8001"})
+	require.NoError(t, err)
+
+	assert.Equal(t, 8001, cfg.Server.Port, "flags override the environment and the file")
+	assert.Equal(t, 9001, cfg.Server.GRPCPort, "the environment overrides the file")
+	assert.Equal(t, time.Minute, cfg.Server.WriteTimeout)
+	assert.Equal(t, 5000, cfg.PubSub.BufferSize, "empty variables are ignored")
+}
+
+func TestLoadRejectsInvalidSettings(t *testing.T) {
+	t.Setenv("PUBSUB_BACKEND", "kafka")
+	t.Setenv("SHUTDOWN_TIMEOUT", "0s")
+	t.Setenv("ELECTION_BACKEND", ElectionRedis)
+
+	_, err := Load([]string{"--port", "70000"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "server.port")
+	assert.ErrorContains(t, err, "pubsub.backend")
+	assert.ErrorContains(t, err, "shutdown.timeout")
+	assert.ErrorContains(t, err, "election.redisAddr")
+}
+
+func TestLoadRejectsInvalidObservabilityAndSecurity(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "xml")
+	t.Setenv("METRICS_BACKEND", "graphite")
+	t.Setenv("TLS_CERT_FILE", "server.pem")
+	t.Setenv("OIDC_ISSUER", "https://accounts.example.com")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/33")
+	t.Setenv("AUDIT_LOG_SIZE", "0")
+	t.Setenv("SECRETS_PROVIDER", "vault")
+
+	_, err := Load(nil)
+	require.Error(t, err)
+	for _, key := range []string{"log.format", "metrics.backend", "tls.certFile and tls.keyFile", "oidc.clientID",
+		"oidc.redirectURL", "oidc.adminEmails", "server.trustedProxies", "audit.size", "secrets.vaultAddr"} {
+		assert.ErrorContains(t, err, key)
+	}
+}
+
+func TestLoadLists(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rockets.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+ingest:
+  allowedNetworks: [10.20.0.0/16, 192.168.1.7]
+cors:
+  allowedOrigins: https://dashboard.example.com
+`), 0o600))
+	t.Setenv("ALLOWED_ROCKET_TYPES", "Falcon-9, Starship,,")
+
+	cfg, err := Load([]string{"--config", file})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"10.20.0.0/16", "192.168.1.7"}, cfg.Ingest.AllowedNetworks)
+	assert.Equal(t, []string{"https://dashboard.example.com"}, cfg.CORS.AllowedOrigins)
+	assert.Equal(t, []string{"Falcon-9", "Starship"}, cfg.Ingest.AllowedRocketTypes, "blank items are ignored")
+	assert.Empty(t, cfg.CORS.AllowedMethods)
+}
+
+func TestLoadRejectsCoordinationWithoutSharedState(t *testing.T) {
+	t.Setenv("ELECTION_BACKEND", ElectionRedis)
+	t.Setenv("ELECTION_REDIS_ADDR", "redis:6379")
+	t.Setenv("LOCK_BACKEND", LockRedis)
+	t.Setenv("LOCK_REDIS_ADDR", "redis:6379")
+
+	for _, args := range [][]string{nil, {"--pubsub-backend", PubSubNATS}} {
+		_, err := Load(args)
+		assert.ErrorContains(t, err, "election.backend redis requires a shared broker and repository")
+		assert.ErrorContains(t, err, "lock.backend redis requires a shared repository")
+	}
+}
+
+func TestLoadReloadable(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "rockets.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+retention:
+  action: delete
+  maxAge: 24h
+limits:
+  query:
+    maxInFlight: 64
+`), 0o600))
+	t.Setenv("RATE_LIMIT_INGEST", "2000")
+
+	cfg, err := Load([]string{"--config", file})
+	require.NoError(t, err)
+
+	assert.Equal(t, "delete", cfg.Retention.Action)
+	assert.Equal(t, 24*time.Hour, cfg.Ages.MaxAge)
+	assert.Equal(t, 2000.0, cfg.Limits.Ingest.Rate)
+	assert.Equal(t, 64, cfg.Limits.Query.MaxInFlight)
+	assert.Equal(t, "info", cfg.Log.Level)
+}
+
+func TestLoadDev(t *testing.T) {
+	cfg, err := Load(nil)
+	require.NoError(t, err)
+	assert.Zero(t, cfg.Dev.Rockets)
+
+	cfg, err = Load([]string{"--dev"})
+	require.NoError(t, err)
+	assert.Equal(t, 10, cfg.Dev.Rockets)
+
+	cfg, err = Load([]string{"--dev=3"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, cfg.Dev.Rockets)
+}
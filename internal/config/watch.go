@@ -0,0 +1,54 @@
+package config
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchSettle is how long writes to the configuration file must settle before a change is signaled, since editors
+// save in several steps
+const watchSettle = 200 * time.Millisecond
+
+// Watch signals changes of the configuration file on the returned channel until ctx is done. The directory of the
+// file is watched, as editors and Kubernetes replace configuration files rather than write them in place
+func Watch(ctx context.Context, file string) (<-chan struct{}, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	if err := watcher.Add(filepath.Dir(file)); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	changed := make(chan struct{}, 1)
+	go func() {
+		defer watcher.Close()
+
+		settle := time.NewTimer(watchSettle)
+		settle.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event := <-watcher.Events:
+				// Kubernetes swaps a ..data symlink, leaving the name of the file itself untouched
+				if filepath.Clean(event.Name) == filepath.Clean(file) || filepath.Base(event.Name) == "..data" {
+					settle.Reset(watchSettle)
+				}
+			case err := <-watcher.Errors:
+				slog.Warn("Failed to watch configuration file", "file", file, "error", err)
+			case <-settle.C:
+				select {
+				case changed <- struct{}{}:
+				default:
+				}
+			}
+		}
+	}()
+	return changed, nil
+}
@@ -0,0 +1,88 @@
+// Package errreport reports failures to an error tracking service, so that they aren't only visible in the logs
+package errreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/logging"
+
+	"github.com/getsentry/sentry-go"
+)
+
+// Reporter reports failures along with tags describing their context, e.g. the channel of a failed message.
+// Implementations must be safe for concurrent use and must not block the caller on the network
+type Reporter interface {
+	// ReportError reports an error that was handled but needs attention
+	ReportError(ctx context.Context, err error, tags map[string]string)
+	// ReportPanic reports the value of a recovered panic, from the goroutine that panicked
+	ReportPanic(ctx context.Context, recovered any, tags map[string]string)
+}
+
+// Nop is a Reporter discarding every failure, for tests and deployments without error tracking
+type Nop struct{}
+
+func (Nop) ReportError(context.Context, error, map[string]string) {}
+
+func (Nop) ReportPanic(context.Context, any, map[string]string) {}
+
+// SentryOptions configures a Sentry reporter
+type SentryOptions struct {
+	DSN         string
+	Environment string
+	Release     string
+}
+
+// Sentry is a Reporter sending events to Sentry, or any service accepting its protocol. Events are sent in the
+// background; call Flush before exiting so that pending events aren't lost
+type Sentry struct {
+	hub *sentry.Hub
+}
+
+// NewSentry creates a reporter sending events to the project of opts.DSN
+func NewSentry(opts SentryOptions) (*Sentry, error) {
+	return newSentry(sentry.ClientOptions{
+		Dsn:              opts.DSN,
+		Environment:      opts.Environment,
+		Release:          opts.Release,
+		AttachStacktrace: true,
+	})
+}
+
+func newSentry(opts sentry.ClientOptions) (*Sentry, error) {
+	client, err := sentry.NewClient(opts)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sentry configuration: %w", err)
+	}
+	return &Sentry{hub: sentry.NewHub(client, sentry.NewScope())}, nil
+}
+
+func (s *Sentry) ReportError(ctx context.Context, err error, tags map[string]string) {
+	s.withScope(ctx, tags, func(hub *sentry.Hub) {
+		hub.CaptureException(err)
+	})
+}
+
+func (s *Sentry) ReportPanic(ctx context.Context, recovered any, tags map[string]string) {
+	s.withScope(ctx, tags, func(hub *sentry.Hub) {
+		hub.RecoverWithContext(ctx, recovered)
+	})
+}
+
+// Flush waits for pending events to be sent, up to timeout, and tells if they all were
+func (s *Sentry) Flush(timeout time.Duration) bool {
+	return s.hub.Flush(timeout)
+}
+
+// withScope calls capture with a hub whose scope holds the tags and the ID of the request being served, if any
+func (s *Sentry) withScope(ctx context.Context, tags map[string]string, capture func(hub *sentry.Hub)) {
+	hub := s.hub.Clone()
+	hub.WithScope(func(scope *sentry.Scope) {
+		scope.SetTags(tags)
+		if id := logging.RequestID(ctx); id != "" {
+			scope.SetTag("requestId", id)
+		}
+		capture(hub)
+	})
+}
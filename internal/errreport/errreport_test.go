@@ -0,0 +1,53 @@
+package errreport
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/logging"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTransport keeps the events instead of sending them
+type recordingTransport struct {
+	mu     sync.Mutex
+	events []*sentry.Event
+}
+
+func (t *recordingTransport) Flush(time.Duration) bool { return true }
+
+func (t *recordingTransport) Configure(sentry.ClientOptions) {}
+
+func (t *recordingTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func TestSentry(t *testing.T) {
+	transport := &recordingTransport{}
+	reporter, err := newSentry(sentry.ClientOptions{
+		Dsn:       "https://key@sentry.example.com/1",
+		Transport: transport,
+	})
+	require.NoError(t, err)
+
+	ctx := logging.WithRequestID(context.Background(), "req-42")
+	reporter.ReportError(ctx, errors.New("rocket not found"), map[string]string{"channel": "193270a9"})
+	reporter.ReportPanic(context.Background(), "boom", nil)
+
+	require.Len(t, transport.events, 2)
+
+	assert.Equal(t, "rocket not found", transport.events[0].Exception[0].Value)
+	assert.Equal(t, map[string]string{"channel": "193270a9", "requestId": "req-42"}, transport.events[0].Tags)
+
+	// Tags of a report don't leak into the next one
+	assert.Equal(t, "boom", transport.events[1].Message)
+	assert.Empty(t, transport.events[1].Tags)
+}
@@ -0,0 +1,156 @@
+// Package feed broadcasts rocket state changes to live subscribers and keeps a bounded
+// history of recent changes so that subscribers can resume after a disconnection.
+package feed
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// subscriberBuffer is the number of changes buffered per subscriber before it is considered too slow
+const subscriberBuffer = 256
+
+// Feed fans out rocket changes to subscribers
+type Feed struct {
+	mu          sync.Mutex
+	lastID      uint64
+	history     []models.RocketChange
+	historySize int
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription receives the changes published after it was created.
+// C is closed when the subscription is closed or when the subscriber falls too far behind
+type Subscription struct {
+	C    <-chan models.RocketChange
+	ch   chan models.RocketChange
+	feed *Feed
+}
+
+// New creates a feed remembering the last historySize changes for resumption
+func New(historySize int) *Feed {
+	return &Feed{
+		history:     make([]models.RocketChange, 0, historySize),
+		historySize: historySize,
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish assigns the next ID to a change and delivers it to every subscriber
+func (f *Feed) Publish(kind, rocketID string, rocket *models.Rocket) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.lastID++
+	change := models.RocketChange{
+		ID:       f.lastID,
+		Kind:     kind,
+		RocketID: rocketID,
+		Rocket:   rocket,
+		Time:     time.Now().UTC(),
+	}
+
+	if len(f.history) == f.historySize {
+		copy(f.history, f.history[1:])
+		f.history = f.history[:len(f.history)-1]
+	}
+	f.history = append(f.history, change)
+
+	for sub := range f.subscribers {
+		select {
+		case sub.ch <- change:
+		default:
+			// Never block publishers on a slow subscriber: drop it, it can resume from the history
+			f.unsubscribe(sub)
+		}
+	}
+}
+
+// Forget removes the retained changes of a rocket, so that resuming subscribers are no longer replayed them, and
+// returns how many were removed. Changes already delivered to subscribers are out of reach
+func (f *Feed) Forget(rocketID string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	retained := len(f.history)
+	f.history = slices.DeleteFunc(f.history, func(change models.RocketChange) bool {
+		return change.RocketID == rocketID
+	})
+	return retained - len(f.history)
+}
+
+// Subscribe registers a new subscriber. It returns the retained changes published after lastID
+// (none when lastID is 0) so the caller can replay them before consuming the subscription
+func (f *Feed) Subscribe(lastID uint64) (*Subscription, []models.RocketChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan models.RocketChange, subscriberBuffer)
+	sub := &Subscription{C: ch, ch: ch, feed: f}
+	f.subscribers[sub] = struct{}{}
+
+	if lastID == 0 {
+		return sub, nil
+	}
+
+	var backlog []models.RocketChange
+	for _, change := range f.history {
+		if change.ID > lastID {
+			backlog = append(backlog, change)
+		}
+	}
+
+	return sub, backlog
+}
+
+// Follow calls fn with every change published from now on until ctx is done. When the subscription falls
+// behind and is dropped, Follow resubscribes and replays the retained changes it missed
+func (f *Feed) Follow(ctx context.Context, fn func(change models.RocketChange)) {
+	var lastID uint64
+	handle := func(change models.RocketChange) {
+		fn(change)
+		lastID = change.ID
+	}
+
+	for ctx.Err() == nil {
+		sub, backlog := f.Subscribe(lastID)
+		for _, change := range backlog {
+			handle(change)
+		}
+
+		func() {
+			defer sub.Close()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case change, ok := <-sub.C:
+					if !ok {
+						return
+					}
+					handle(change)
+				}
+			}
+		}()
+	}
+}
+
+// Close stops the subscription and releases its resources
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+
+	s.feed.unsubscribe(s)
+}
+
+// unsubscribe removes a subscriber. Callers must hold the lock
+func (f *Feed) unsubscribe(sub *Subscription) {
+	if _, ok := f.subscribers[sub]; ok {
+		delete(f.subscribers, sub)
+		close(sub.ch)
+	}
+}
@@ -0,0 +1,79 @@
+package feed
+
+import (
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeed(t *testing.T) {
+	t.Run("subscribers receive changes published after subscribing", func(t *testing.T) {
+		f := New(10)
+		f.Publish(models.ChangeUpdated, "before", nil)
+
+		sub, backlog := f.Subscribe(0)
+		defer sub.Close()
+
+		f.Publish(models.ChangeDeleted, "after", nil)
+
+		assert.Empty(t, backlog)
+		change := <-sub.C
+		assert.Equal(t, uint64(2), change.ID)
+		assert.Equal(t, models.ChangeDeleted, change.Kind)
+		assert.Equal(t, "after", change.RocketID)
+	})
+
+	t.Run("resuming replays retained changes after the last ID", func(t *testing.T) {
+		f := New(3)
+		for _, id := range []string{"a", "b", "c", "d", "e"} {
+			f.Publish(models.ChangeUpdated, id, nil)
+		}
+
+		sub, backlog := f.Subscribe(3)
+		defer sub.Close()
+
+		assert.Len(t, backlog, 2)
+		assert.Equal(t, "d", backlog[0].RocketID)
+		assert.Equal(t, "e", backlog[1].RocketID)
+
+		// Changes evicted from the history cannot be replayed
+		_, backlog = f.Subscribe(1)
+		assert.Len(t, backlog, 3)
+		assert.Equal(t, uint64(3), backlog[0].ID)
+	})
+
+	t.Run("forgotten changes are not replayed", func(t *testing.T) {
+		f := New(10)
+		for _, id := range []string{"a", "b", "a", "c"} {
+			f.Publish(models.ChangeUpdated, id, nil)
+		}
+
+		assert.Equal(t, 2, f.Forget("a"))
+		sub, backlog := f.Subscribe(1)
+		defer sub.Close()
+		require.Len(t, backlog, 2)
+		assert.Equal(t, "b", backlog[0].RocketID)
+		assert.Equal(t, "c", backlog[1].RocketID)
+	})
+
+	t.Run("slow subscribers are dropped", func(t *testing.T) {
+		f := New(10)
+		sub, _ := f.Subscribe(0)
+
+		for i := 0; i <= subscriberBuffer; i++ {
+			f.Publish(models.ChangeUpdated, "rocket", nil)
+		}
+
+		received := 0
+		for range sub.C {
+			received++
+		}
+		assert.Equal(t, subscriberBuffer, received)
+
+		// Closing an already dropped subscription is a no-op
+		sub.Close()
+	})
+}
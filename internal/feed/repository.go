@@ -0,0 +1,57 @@
+package feed
+
+import (
+	"context"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+)
+
+// observedRepository decorates a RocketRepository, publishing every successful mutation on the feed
+type observedRepository struct {
+	repository.RocketRepository
+	feed *Feed
+}
+
+// WrapRepository returns a repository that publishes its changes on the feed
+func WrapRepository(repo repository.RocketRepository, feed *Feed) repository.RocketRepository {
+	return &observedRepository{
+		RocketRepository: repo,
+		feed:             feed,
+	}
+}
+
+// Save stores the rocket and publishes an update
+func (r *observedRepository) Save(ctx context.Context, rocket *models.Rocket) error {
+	if err := r.RocketRepository.Save(ctx, rocket); err != nil {
+		return err
+	}
+
+	r.feed.Publish(models.ChangeUpdated, rocket.ID, rocket.Clone())
+	return nil
+}
+
+// Update modifies the rocket and publishes an update
+func (r *observedRepository) Update(
+	ctx context.Context,
+	id string,
+	fn func(rocket *models.Rocket) error,
+) (*models.Rocket, error) {
+	rocket, err := r.RocketRepository.Update(ctx, id, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	r.feed.Publish(models.ChangeUpdated, id, rocket.Clone())
+	return rocket, nil
+}
+
+// Delete removes the rocket and publishes a deletion
+func (r *observedRepository) Delete(ctx context.Context, id string) error {
+	if err := r.RocketRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.feed.Publish(models.ChangeDeleted, id, nil)
+	return nil
+}
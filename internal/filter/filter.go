@@ -0,0 +1,167 @@
+// Package filter parses filter expressions such as `speed>1000 AND status=ACTIVE`
+// into predicates the repository can evaluate.
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// conjunction separates the clauses of an expression; only AND is supported
+var conjunction = regexp.MustCompile(`(?i)^\s+AND\s+`)
+
+// operators are ordered so that two-character operators are matched before their prefixes
+var operators = []models.FilterOperator{
+	models.OpGreaterOrEqual,
+	models.OpLessOrEqual,
+	models.OpNotEqual,
+	models.OpEqual,
+	models.OpGreater,
+	models.OpLess,
+}
+
+// Parse turns an expression into a list of predicates that must all hold
+func Parse(expr string) ([]models.Predicate, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+
+	clauses, err := splitClauses(expr)
+	if err != nil {
+		return nil, err
+	}
+	predicates := make([]models.Predicate, 0, len(clauses))
+
+	for _, clause := range clauses {
+		predicate, err := parseClause(strings.TrimSpace(clause))
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, predicate)
+	}
+
+	return predicates, nil
+}
+
+// splitClauses splits an expression on its conjunctions, leaving those within quoted values alone. A quote opens a
+// value when it follows an operator or white space, so that apostrophes within words are kept as they are
+func splitClauses(expr string) ([]string, error) {
+	var clauses []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		switch c := expr[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case (c == '"' || c == '\'') && i > 0 && strings.IndexByte("=<>! \t", expr[i-1]) >= 0:
+			quote = c
+		default:
+			if loc := conjunction.FindStringIndex(expr[i:]); loc != nil {
+				clauses = append(clauses, expr[start:i])
+				start = i + loc[1]
+				i = start - 1
+			}
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote in filter expression")
+	}
+	return append(clauses, expr[start:]), nil
+}
+
+// parseClause parses a single `field<op>value` comparison
+func parseClause(clause string) (models.Predicate, error) {
+	if clause == "" {
+		return models.Predicate{}, fmt.Errorf("empty clause in filter expression")
+	}
+
+	idx, op := findOperator(clause)
+	if idx < 0 {
+		return models.Predicate{}, fmt.Errorf("missing comparison operator in %q", clause)
+	}
+
+	field := strings.TrimSpace(clause[:idx])
+	value := unquote(strings.TrimSpace(clause[idx+len(op):]))
+
+	kind, ok := lookupField(&field)
+	if !ok {
+		return models.Predicate{}, fmt.Errorf("unknown field %q in %q", field, clause)
+	}
+
+	if value == "" {
+		return models.Predicate{}, fmt.Errorf("missing value in %q", clause)
+	}
+
+	switch kind {
+	case models.NumericField:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return models.Predicate{}, fmt.Errorf("field %q expects a numeric value, got %q", field, value)
+		}
+	case models.StringField:
+		if op != models.OpEqual && op != models.OpNotEqual {
+			return models.Predicate{}, fmt.Errorf("field %q only supports = and != comparisons", field)
+		}
+	}
+
+	return models.Predicate{Field: field, Operator: op, Value: value}, nil
+}
+
+// findOperator returns the position and value of the first operator found in the clause
+func findOperator(clause string) (int, models.FilterOperator) {
+	for i := range clause {
+		for _, op := range operators {
+			if strings.HasPrefix(clause[i:], string(op)) {
+				return i, op
+			}
+		}
+	}
+	return -1, ""
+}
+
+// lookupField resolves a field name case-insensitively, normalizing it to its canonical JSON name
+func lookupField(field *string) (models.FieldKind, bool) {
+	for name, kind := range models.FilterableFields {
+		if strings.EqualFold(name, *field) {
+			*field = name
+			return kind, true
+		}
+	}
+	return 0, false
+}
+
+// unquote strips a single pair of matching quotes around a value
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if (first == '"' || first == '\'') && first == last {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
+
+// ParseLabels turns label selectors such as "team=alpha" into the labels a rocket must carry
+func ParseLabels(selectors []string) (models.Labels, error) {
+	if len(selectors) == 0 {
+		return nil, nil
+	}
+
+	labels := make(models.Labels, len(selectors))
+	for _, selector := range selectors {
+		key, value, ok := strings.Cut(selector, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("label selector %q must be of the form key=value", selector)
+		}
+		labels[key] = value
+	}
+
+	return labels, nil
+}
@@ -0,0 +1,143 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		expr          string
+		expected      []models.Predicate
+		expectedError bool
+	}{
+		{
+			name:     "empty expression",
+			expr:     "  ",
+			expected: nil,
+		},
+		{
+			name: "numeric and string clauses",
+			expr: "speed>1000 AND status=ACTIVE",
+			expected: []models.Predicate{
+				{Field: "speed", Operator: models.OpGreater, Value: "1000"},
+				{Field: "status", Operator: models.OpEqual, Value: "ACTIVE"},
+			},
+		},
+		{
+			name: "two-character operators, spacing and lowercase conjunction",
+			expr: "speed >= 10 and lastmessagenumber<=5 and mission != 'SHUTTLE MIR'",
+			expected: []models.Predicate{
+				{Field: "speed", Operator: models.OpGreaterOrEqual, Value: "10"},
+				{Field: "lastMessageNumber", Operator: models.OpLessOrEqual, Value: "5"},
+				{Field: "mission", Operator: models.OpNotEqual, Value: "SHUTTLE MIR"},
+			},
+		},
+		{
+			name: "conjunction within quoted values",
+			expr: `mission="Apollo and Artemis" AND type='Falcon AND Heavy' and name=O'Neil`,
+			expected: []models.Predicate{
+				{Field: "mission", Operator: models.OpEqual, Value: "Apollo and Artemis"},
+				{Field: "type", Operator: models.OpEqual, Value: "Falcon AND Heavy"},
+				{Field: "name", Operator: models.OpEqual, Value: "O'Neil"},
+			},
+		},
+		{
+			name:          "unterminated quote",
+			expr:          `mission="Apollo AND speed>5`,
+			expectedError: true,
+		},
+		{
+			name:          "unknown field",
+			expr:          "altitude>5",
+			expectedError: true,
+		},
+		{
+			name:          "non-numeric value for numeric field",
+			expr:          "speed>fast",
+			expectedError: true,
+		},
+		{
+			name:          "ordering operator on string field",
+			expr:          "mission>ARTEMIS",
+			expectedError: true,
+		},
+		{
+			name:          "missing operator",
+			expr:          "speed 1000",
+			expectedError: true,
+		},
+		{
+			name:          "dangling conjunction",
+			expr:          "speed>1000 AND ",
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			predicates, err := Parse(tt.expr)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, predicates)
+		})
+	}
+}
+
+func TestParseLabels(t *testing.T) {
+	tests := []struct {
+		name          string
+		selectors     []string
+		expected      models.Labels
+		expectedError bool
+	}{
+		{
+			name:      "no selectors",
+			selectors: nil,
+			expected:  nil,
+		},
+		{
+			name:      "several selectors",
+			selectors: []string{"team=alpha", " env = staging "},
+			expected:  models.Labels{"team": "alpha", "env": "staging"},
+		},
+		{
+			name:      "empty value",
+			selectors: []string{"campaign="},
+			expected:  models.Labels{"campaign": ""},
+		},
+		{
+			name:          "missing value",
+			selectors:     []string{"team"},
+			expectedError: true,
+		},
+		{
+			name:          "missing key",
+			selectors:     []string{"=alpha"},
+			expectedError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labels, err := ParseLabels(tt.selectors)
+
+			if tt.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, labels)
+		})
+	}
+}
@@ -0,0 +1,452 @@
+// Package graphqlapi exposes the rocket services through a GraphQL schema
+package graphqlapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ahernandez9/rockets/internal/filter"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/graphql-go/graphql"
+)
+
+// resolver holds the services used by the field resolvers
+type resolver struct {
+	rocketService service.RocketService
+}
+
+// missionCache memoizes the mission aggregates for the duration of a single query,
+// so that resolving the mission of many rockets aggregates the fleet only once
+type missionCache struct {
+	once     sync.Once
+	missions map[string]*models.Mission
+	err      error
+}
+
+type missionCacheKey struct{}
+
+// WithRequestCache prepares the per-query caches used by the resolvers
+func WithRequestCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, missionCacheKey{}, &missionCache{})
+}
+
+// NewSchema builds the GraphQL schema backed by the rocket service
+func NewSchema(rocketService service.RocketService) (graphql.Schema, error) {
+	r := &resolver{rocketService: rocketService}
+
+	speedStatsType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "SpeedStats",
+		Description: "Aggregate speed of a group of rockets",
+		Fields: graphql.Fields{
+			"min":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"max":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"average": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"total":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	statusCountType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "StatusCount",
+		Description: "Number of rockets in a given status",
+		Fields: graphql.Fields{
+			"status": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"count":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+
+	positionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Position",
+		Description: "Reported position of a rocket",
+		Fields: graphql.Fields{
+			"latitude":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"longitude": &graphql.Field{Type: graphql.NewNonNull(graphql.Float)},
+			"altitude":  &graphql.Field{Type: graphql.NewNonNull(graphql.Float), Description: "Meters above sea level"},
+		},
+	})
+
+	stageSeparationType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "StageSeparation",
+		Description: "Separation of a stage of a multi-stage rocket",
+		Fields: graphql.Fields{
+			"stage":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"messageNumber": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"time":          &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	payloadType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Payload",
+		Description: "Payload deployed by a rocket",
+		Fields: graphql.Fields{
+			"name":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"messageNumber": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"deployedAt":    &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	anomalyType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Anomaly",
+		Description: "Implausible transition detected in the telemetry of a rocket",
+		Fields: graphql.Fields{
+			"kind":          &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"detail":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"messageNumber": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"time":          &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+		},
+	})
+
+	labelType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Label",
+		Description: "Key/value pair attached to a rocket by an operator",
+		Fields: graphql.Fields{
+			"key":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"value": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	rocketType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Rocket",
+		Description: "Current state of a rocket",
+		Fields: graphql.Fields{
+			"id":                &graphql.Field{Type: graphql.NewNonNull(graphql.ID)},
+			"name":              &graphql.Field{Type: graphql.String, Resolve: r.rocketName},
+			"type":              &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"speed":             &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"maxSpeed":          &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Description: "Highest speed reported since launch"},
+			"averageSpeed":      &graphql.Field{Type: graphql.NewNonNull(graphql.Float), Description: "Mean of the speeds reported since launch"},
+			"estimatedDistance": &graphql.Field{Type: graphql.NewNonNull(graphql.Float), Description: "Kilometers covered since launch, integrating the speed between updates"},
+			"mission":           &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"fuelLevel":         &graphql.Field{Type: graphql.Float, Resolve: r.rocketFuelLevel},
+			"position":          &graphql.Field{Type: positionType, Resolve: r.rocketPosition},
+			"currentStage":      &graphql.Field{Type: graphql.Int, Resolve: r.rocketCurrentStage},
+			"stages":            &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(stageSeparationType))},
+			"payloads":          &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(payloadType))},
+			"anomalies":         &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(anomalyType))},
+			"labels":            &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(labelType)), Resolve: r.rocketLabels},
+			"status":            &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: r.rocketStatus},
+			"explosionReason":   &graphql.Field{Type: graphql.String, Resolve: r.rocketExplosionReason},
+			"lastMessageNumber": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"launchTime":        &graphql.Field{Type: graphql.DateTime, Resolve: r.rocketLaunchTime},
+			"flightDuration":    &graphql.Field{Type: graphql.NewNonNull(graphql.Float), Description: "Seconds from launch to the latest applied message"},
+			"lastUpdated":       &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"archivedAt":        &graphql.Field{Type: graphql.DateTime, Resolve: r.rocketArchivedAt},
+		},
+	})
+
+	missionType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Mission",
+		Description: "Aggregate of the rockets assigned to a mission",
+		Fields: graphql.Fields{
+			"name":        &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"rocketCount": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"byStatus":    &graphql.Field{Type: graphql.NewList(graphql.NewNonNull(statusCountType)), Resolve: r.missionByStatus},
+			"speed":       &graphql.Field{Type: graphql.NewNonNull(speedStatsType)},
+			"firstSeen":   &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"lastUpdated": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime)},
+			"rockets": &graphql.Field{
+				Type:    graphql.NewList(graphql.NewNonNull(rocketType)),
+				Args:    listArgs(false),
+				Resolve: r.missionRockets,
+			},
+		},
+	})
+
+	// Declared after missionType, as both types reference each other
+	rocketType.AddFieldConfig("missionSummary", &graphql.Field{
+		Type:        missionType,
+		Description: "Aggregate of the mission the rocket is assigned to",
+		Resolve:     r.rocketMission,
+	})
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"rocket": &graphql.Field{
+				Type: rocketType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)},
+				},
+				Resolve: r.rocket,
+			},
+			"rocketByName": &graphql.Field{
+				Type: rocketType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: r.rocketByName,
+			},
+			"rockets": &graphql.Field{
+				Type:    graphql.NewList(graphql.NewNonNull(rocketType)),
+				Args:    listArgs(true),
+				Resolve: r.rockets,
+			},
+			"mission": &graphql.Field{
+				Type: missionType,
+				Args: graphql.FieldConfigArgument{
+					"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: r.mission,
+			},
+			"missions": &graphql.Field{
+				Type:    graphql.NewList(graphql.NewNonNull(missionType)),
+				Resolve: r.missions,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query})
+}
+
+// listArgs returns the filtering and sorting arguments of rocket lists, the mission ones being implied by the parent
+func listArgs(withMission bool) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{
+		"status":          &graphql.ArgumentConfig{Type: graphql.String},
+		"type":            &graphql.ArgumentConfig{Type: graphql.String},
+		"query":           &graphql.ArgumentConfig{Type: graphql.String, Description: "Free-text search"},
+		"filter":          &graphql.ArgumentConfig{Type: graphql.String, Description: "Filter expression, as in GET /rockets"},
+		"lowFuel":         &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+		"labels":          &graphql.ArgumentConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.String)), Description: "Label selectors, e.g. team=alpha"},
+		"sort":            &graphql.ArgumentConfig{Type: graphql.String, DefaultValue: "id"},
+		"includeArchived": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+		"limit":           &graphql.ArgumentConfig{Type: graphql.Int},
+	}
+	if withMission {
+		args["mission"] = &graphql.ArgumentConfig{Type: graphql.String}
+	}
+	return args
+}
+
+func (r *resolver) rocket(p graphql.ResolveParams) (interface{}, error) {
+	rocket, err := r.rocketService.GetRocket(p.Context, p.Args["id"].(string))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return rocket, err
+}
+
+func (r *resolver) rocketByName(p graphql.ResolveParams) (interface{}, error) {
+	rocket, err := r.rocketService.GetRocketByName(p.Context, p.Args["name"].(string))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, nil
+	}
+	return rocket, err
+}
+
+func (r *resolver) rockets(p graphql.ResolveParams) (interface{}, error) {
+	mission, _ := p.Args["mission"].(string)
+	return r.listRockets(p, mission)
+}
+
+func (r *resolver) missionRockets(p graphql.ResolveParams) (interface{}, error) {
+	mission := p.Source.(*models.Mission)
+	rockets, err := r.listRockets(p, mission.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// The mission filter is case-insensitive, keep only the exact mission
+	exact := rockets[:0]
+	for _, rocket := range rockets {
+		if rocket.Mission == mission.Name {
+			exact = append(exact, rocket)
+		}
+	}
+	return exact, nil
+}
+
+// listRockets applies the list arguments shared by every rocket list
+func (r *resolver) listRockets(p graphql.ResolveParams, mission string) ([]*models.Rocket, error) {
+	sortFields, err := service.ParseSort(p.Args["sort"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	status, _ := p.Args["status"].(string)
+	if status != "" {
+		if _, ok := models.ParseStatus(status); !ok {
+			return nil, fmt.Errorf("unknown status %q", status)
+		}
+	}
+
+	expr, _ := p.Args["filter"].(string)
+	predicates, err := filter.Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var selectors []string
+	if args, ok := p.Args["labels"].([]interface{}); ok {
+		for _, selector := range args {
+			selectors = append(selectors, selector.(string))
+		}
+	}
+	labels, err := filter.ParseLabels(selectors)
+	if err != nil {
+		return nil, err
+	}
+
+	rocketType, _ := p.Args["type"].(string)
+	query, _ := p.Args["query"].(string)
+
+	rockets, err := r.rocketService.ListRockets(p.Context, models.RocketFilter{
+		Status:          status,
+		Mission:         mission,
+		Type:            rocketType,
+		Query:           strings.TrimSpace(query),
+		LowFuel:         p.Args["lowFuel"].(bool),
+		Labels:          labels,
+		Predicates:      predicates,
+		IncludeArchived: p.Args["includeArchived"].(bool),
+	}, sortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit, ok := p.Args["limit"].(int); ok {
+		if limit < 0 {
+			return nil, fmt.Errorf("limit must not be negative")
+		}
+		if limit < len(rockets) {
+			rockets = rockets[:limit]
+		}
+	}
+
+	return rockets, nil
+}
+
+func (r *resolver) mission(p graphql.ResolveParams) (interface{}, error) {
+	mission, err := r.rocketService.GetMission(p.Context, p.Args["name"].(string))
+	if errors.Is(err, repository.ErrMissionNotFound) {
+		return nil, nil
+	}
+	return mission, err
+}
+
+func (r *resolver) missions(p graphql.ResolveParams) (interface{}, error) {
+	return r.rocketService.ListMissions(p.Context)
+}
+
+func (r *resolver) rocketMission(p graphql.ResolveParams) (interface{}, error) {
+	missions, err := r.loadMissions(p.Context)
+	if err != nil {
+		return nil, err
+	}
+
+	if summary, ok := missions[p.Source.(*models.Rocket).Mission]; ok {
+		return summary, nil
+	}
+	return nil, nil
+}
+
+// loadMissions returns the mission aggregates by name, computed once per query when a request cache is set
+func (r *resolver) loadMissions(ctx context.Context) (map[string]*models.Mission, error) {
+	cache, ok := ctx.Value(missionCacheKey{}).(*missionCache)
+	if !ok {
+		cache = &missionCache{}
+	}
+
+	cache.once.Do(func() {
+		summaries, err := r.rocketService.ListMissions(ctx)
+		if err != nil {
+			cache.err = err
+			return
+		}
+
+		cache.missions = make(map[string]*models.Mission, len(summaries))
+		for _, summary := range summaries {
+			cache.missions[summary.Name] = summary
+		}
+	})
+
+	return cache.missions, cache.err
+}
+
+func (r *resolver) rocketStatus(p graphql.ResolveParams) (interface{}, error) {
+	return string(p.Source.(*models.Rocket).Status), nil
+}
+
+func (r *resolver) rocketName(p graphql.ResolveParams) (interface{}, error) {
+	if name := p.Source.(*models.Rocket).Name; name != "" {
+		return name, nil
+	}
+	return nil, nil
+}
+
+func (r *resolver) rocketExplosionReason(p graphql.ResolveParams) (interface{}, error) {
+	if reason := p.Source.(*models.Rocket).ExplosionReason; reason != "" {
+		return reason, nil
+	}
+	return nil, nil
+}
+
+func (r *resolver) rocketFuelLevel(p graphql.ResolveParams) (interface{}, error) {
+	if level := p.Source.(*models.Rocket).FuelLevel; level != nil {
+		return *level, nil
+	}
+	return nil, nil
+}
+
+func (r *resolver) rocketPosition(p graphql.ResolveParams) (interface{}, error) {
+	if position := p.Source.(*models.Rocket).Position; position != nil {
+		return position, nil
+	}
+	return nil, nil
+}
+
+func (r *resolver) rocketCurrentStage(p graphql.ResolveParams) (interface{}, error) {
+	if stage := p.Source.(*models.Rocket).CurrentStage; stage != 0 {
+		return stage, nil
+	}
+	return nil, nil
+}
+
+// rocketLabels flattens the labels into a list sorted by key, as GraphQL has no map type
+func (r *resolver) rocketLabels(p graphql.ResolveParams) (interface{}, error) {
+	labels := p.Source.(*models.Rocket).Labels
+
+	list := make([]map[string]interface{}, 0, len(labels))
+	for key, value := range labels {
+		list = append(list, map[string]interface{}{"key": key, "value": value})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i]["key"].(string) < list[j]["key"].(string)
+	})
+
+	return list, nil
+}
+
+func (r *resolver) rocketLaunchTime(p graphql.ResolveParams) (interface{}, error) {
+	if launchTime := p.Source.(*models.Rocket).LaunchTime; launchTime != nil {
+		return *launchTime, nil
+	}
+	return nil, nil
+}
+
+func (r *resolver) rocketArchivedAt(p graphql.ResolveParams) (interface{}, error) {
+	if archivedAt := p.Source.(*models.Rocket).ArchivedAt; archivedAt != nil {
+		return *archivedAt, nil
+	}
+	return nil, nil
+}
+
+// missionByStatus flattens the status breakdown into a list, as GraphQL has no map type
+func (r *resolver) missionByStatus(p graphql.ResolveParams) (interface{}, error) {
+	byStatus := p.Source.(*models.Mission).ByStatus
+
+	counts := make([]map[string]interface{}, 0, len(byStatus))
+	for status, count := range byStatus {
+		counts = append(counts, map[string]interface{}{"status": string(status), "count": count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i]["status"].(string) < counts[j]["status"].(string)
+	})
+
+	return counts, nil
+}
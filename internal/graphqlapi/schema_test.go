@@ -0,0 +1,118 @@
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service/mocks"
+
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSchema(t *testing.T) {
+	rocket := &models.Rocket{
+		ID:      "193270a9-c9cf-404a-8f83-838e71d9ae67",
+		Type:    "Falcon-9",
+		Speed:   5000,
+		Mission: "ARTEMIS",
+		Status:  models.StatusActive,
+	}
+	artemis := &models.Mission{
+		Name:        "ARTEMIS",
+		RocketCount: 1,
+		ByStatus:    map[models.RocketStatus]int{models.StatusActive: 1},
+		Speed:       models.SpeedStats{Min: 5000, Max: 5000, Average: 5000, Total: 5000},
+	}
+
+	tests := []struct {
+		name         string
+		query        string
+		mockSetup    func(*mocks.MockRocketService)
+		expectedData string
+		expectErrors bool
+	}{
+		{
+			name:  "rocket with selected fields",
+			query: `{ rocket(id: "193270a9-c9cf-404a-8f83-838e71d9ae67") { id speed explosionReason } }`,
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().GetRocket(gomock.Any(), rocket.ID).Return(rocket, nil)
+			},
+			expectedData: `{"rocket":{"id":"193270a9-c9cf-404a-8f83-838e71d9ae67","speed":5000,"explosionReason":null}}`,
+		},
+		{
+			name:  "unknown rocket resolves to null",
+			query: `{ rocket(id: "missing") { id } }`,
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().GetRocket(gomock.Any(), "missing").Return(nil, repository.ErrNotFound)
+			},
+			expectedData: `{"rocket":null}`,
+		},
+		{
+			name:  "rockets with filters and nested mission aggregate",
+			query: `{ rockets(status: "active", sort: "-speed", filter: "speed>1000") { id missionSummary { name rocketCount byStatus { status count } } } }`,
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), gomock.Any(), []models.SortField{{Field: "speed", Descending: true}}).
+					DoAndReturn(func(_ context.Context, f models.RocketFilter, _ []models.SortField) ([]*models.Rocket, error) {
+						assert.Equal(t, "active", f.Status)
+						assert.Len(t, f.Predicates, 1)
+						return []*models.Rocket{rocket, rocket}, nil
+					})
+				// Aggregated once for the whole query
+				m.EXPECT().ListMissions(gomock.Any()).Return([]*models.Mission{artemis}, nil).Times(1)
+			},
+			expectedData: `{"rockets":[` +
+				`{"id":"193270a9-c9cf-404a-8f83-838e71d9ae67","missionSummary":{"name":"ARTEMIS","rocketCount":1,"byStatus":[{"status":"ACTIVE","count":1}]}},` +
+				`{"id":"193270a9-c9cf-404a-8f83-838e71d9ae67","missionSummary":{"name":"ARTEMIS","rocketCount":1,"byStatus":[{"status":"ACTIVE","count":1}]}}]}`,
+		},
+		{
+			name:  "missions with nested rockets",
+			query: `{ missions { name speed { max } rockets(limit: 1) { id } } }`,
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().ListMissions(gomock.Any()).Return([]*models.Mission{artemis}, nil)
+				m.EXPECT().
+					ListRockets(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return([]*models.Rocket{rocket, {ID: "other", Mission: "artemis"}}, nil)
+			},
+			expectedData: `{"missions":[{"name":"ARTEMIS","speed":{"max":5000},"rockets":[{"id":"193270a9-c9cf-404a-8f83-838e71d9ae67"}]}]}`,
+		},
+		{
+			name:         "invalid status",
+			query:        `{ rockets(status: "LOST") { id } }`,
+			mockSetup:    func(m *mocks.MockRocketService) {},
+			expectedData: `{"rockets":null}`,
+			expectErrors: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockRocketService(ctrl)
+			tt.mockSetup(mockService)
+
+			schema, err := NewSchema(mockService)
+			require.NoError(t, err)
+
+			result := graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: tt.query,
+				Context:       WithRequestCache(context.Background()),
+			})
+
+			assert.Equal(t, tt.expectErrors, result.HasErrors(), "errors: %v", result.Errors)
+
+			data, err := json.Marshal(result.Data)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expectedData, string(data))
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package grpcapi
+
+import (
+	"context"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/grpcapi/rocketsv1"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// apiKeyMetadata carries the API key of a caller, as the X-API-Key header of the HTTP API
+const apiKeyMetadata = "x-api-key"
+
+// methodScopes are the scopes the methods require, like the routes of the HTTP API. Other methods are open
+var methodScopes = map[string]auth.Scope{
+	rocketsv1.RocketService_IngestTelemetry_FullMethodName: auth.ScopeIngest,
+}
+
+// Options holds the checks applied to calls, the same as the HTTP API applies to its routes
+type Options struct {
+	Keys           auth.KeyStore       // API keys of producers; nil leaves ingestion open
+	Quotas         *ratelimit.Quotas   // Rate limits and quotas of API callers; nil leaves them unlimited
+	Recorder       metrics.Recorder    // Counts denied calls; nil disables the counts
+	IngestNetworks []netip.Prefix      // Client networks allowed to submit messages; empty allows every client
+	Signing        auth.SigningSecrets // Messages must be signed, which gRPC calls can't be: ingestion is refused
+	ClientCerts    bool                // Submitting requires a client certificate, which gRPC calls lack: refused
+}
+
+// guard applies Options to the calls of the server
+type guard struct {
+	Options
+}
+
+func newGuard(opts Options) *guard {
+	if opts.Keys == nil {
+		opts.Keys = auth.NewKeys()
+	}
+	if opts.Recorder == nil {
+		opts.Recorder = metrics.Nop{}
+	}
+	return &guard{Options: opts}
+}
+
+func (g *guard) unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := g.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (g *guard) stream(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := g.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &guardedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorize checks that the caller may call method, in the order of the middlewares of the HTTP API: client address,
+// certificate, API key and limits, signature. It returns the context of the call carrying the identity of the caller
+func (g *guard) authorize(ctx context.Context, method string) (context.Context, error) {
+	scope, guarded := methodScopes[method]
+	if !guarded {
+		return ctx, nil
+	}
+
+	if scope == auth.ScopeIngest {
+		if len(g.IngestNetworks) > 0 && !g.allowed(ctx) {
+			g.Recorder.RequestDenied(method, metrics.DeniedAddress)
+			slog.WarnContext(ctx, "Denied call from address outside the allowlist", "method", method)
+			return nil, status.Error(codes.PermissionDenied, "calls from this address are not allowed")
+		}
+		if g.ClientCerts {
+			return nil, status.Error(codes.Unauthenticated,
+				"submitting messages requires a client certificate, which the gRPC API does not verify: use the HTTP API")
+		}
+	}
+
+	if g.Keys.Enabled(scope) {
+		id, err := g.authenticate(ctx, method, scope)
+		if err != nil {
+			return nil, err
+		}
+		ctx = logging.With(auth.WithIdentity(ctx, id), "actor", id.Name)
+	}
+
+	if scope == auth.ScopeIngest && len(g.Signing) > 0 {
+		return nil, status.Error(codes.PermissionDenied,
+			"messages must be signed, which only the HTTP API supports")
+	}
+	return ctx, nil
+}
+
+// allowed tells whether the caller connected from one of the ingest networks. Callers over a Unix socket have no
+// address and are refused
+func (g *guard) allowed(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return false
+	}
+	addrPort, err := netip.ParseAddrPort(p.Addr.String())
+	if err != nil {
+		return false
+	}
+	addr := addrPort.Addr().Unmap()
+	return slices.ContainsFunc(g.IngestNetworks, func(network netip.Prefix) bool { return network.Contains(addr) })
+}
+
+// authenticate resolves the key of the caller, checks it grants scope and counts the call against its limits
+func (g *guard) authenticate(ctx context.Context, method string, scope auth.Scope) (auth.Identity, error) {
+	key := ""
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(apiKeyMetadata); len(values) > 0 {
+		key = values[0]
+	} else if values := md.Get("authorization"); len(values) > 0 {
+		key, _ = strings.CutPrefix(values[0], "Bearer ")
+	}
+
+	id, found := g.Keys.Lookup(ctx, key)
+	if key == "" || !found {
+		return auth.Identity{}, status.Error(codes.Unauthenticated,
+			"a valid API key must be provided in the x-api-key metadata or as a bearer token in authorization")
+	}
+	if !id.Allows(scope) {
+		return auth.Identity{}, status.Error(codes.PermissionDenied, "the credentials are not allowed to use this method")
+	}
+
+	if g.Quotas != nil {
+		decision := g.Quotas.Take(id.Name, ratelimit.Limits{PerMinute: id.RateLimit, PerDay: id.DailyQuota}, time.Now())
+		if !decision.Allowed {
+			reason, detail := metrics.DeniedRateLimit, "the limit of requests per minute is exceeded"
+			if decision.Exceeded == ratelimit.ExceededQuota {
+				reason, detail = metrics.DeniedQuota, "the daily quota of requests is exhausted"
+			}
+			g.Recorder.RequestDenied(method, reason)
+			slog.WarnContext(ctx, "Call over the limits of its caller", "method", method, "actor", id.Name, "reason", reason)
+			return auth.Identity{}, status.Errorf(codes.ResourceExhausted, "%s, retry in %s", detail,
+				time.Until(decision.Reset).Round(time.Second))
+		}
+	}
+	return id, nil
+}
+
+// guardedStream is a server stream whose context carries the identity of the caller
+type guardedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *guardedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,146 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/grpcapi/rocketsv1"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
+	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/ahernandez9/rockets/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// newTestClient serves the rocket service in memory and returns a client of it
+func newTestClient(t *testing.T, ms service.MessageService, rs service.RocketService, opts Options) rocketsv1.RocketServiceClient {
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(ms, rs, opts)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return rocketsv1.NewRocketServiceClient(conn)
+}
+
+// launch returns a valid streamed RocketLaunched message
+func launch(number int64) *rocketsv1.IngestTelemetryRequest {
+	return &rocketsv1.IngestTelemetryRequest{
+		Metadata: &rocketsv1.MessageMetadata{
+			Channel:       "193270a9-c9cf-404a-8f83-838e71d9ae67",
+			MessageNumber: number,
+			MessageTime:   timestamppb.New(time.Date(2022, 2, 2, 19, 39, 5, 0, time.UTC)),
+		},
+		Payload: &rocketsv1.IngestTelemetryRequest_RocketLaunched{
+			RocketLaunched: &rocketsv1.RocketLaunched{Type: "Falcon-9", LaunchSpeed: 500, Mission: "ARTEMIS"},
+		},
+	}
+}
+
+// ingest streams a single message with the given API key, empty for none
+func ingest(ctx context.Context, client rocketsv1.RocketServiceClient, key string) (*rocketsv1.IngestTelemetryResponse, error) {
+	if key != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, apiKeyMetadata, key)
+	}
+	stream, err := client.IngestTelemetry(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// A stream refused by the server fails to send with io.EOF, its status is the one of CloseAndRecv
+	_ = stream.Send(launch(1))
+	return stream.CloseAndRecv()
+}
+
+func TestIngestGuard(t *testing.T) {
+	keys := auth.NewKeys()
+	require.NoError(t, keys.Add("k3y", auth.Identity{Name: "producer-eu", Scopes: []auth.Scope{auth.ScopeIngest}}))
+	require.NoError(t, keys.Add("s3cret", auth.Identity{Name: "admin", Scopes: []auth.Scope{auth.ScopeAdmin}}))
+	require.NoError(t, keys.Add("l1mited", auth.Identity{Name: "producer-us", Scopes: []auth.Scope{auth.ScopeIngest},
+		RateLimit: 1}))
+
+	tests := []struct {
+		name         string
+		opts         Options
+		key          string
+		calls        int // Earlier calls with the same key
+		expectedCode codes.Code
+	}{
+		{name: "open without ingest keys", expectedCode: codes.OK},
+		{name: "key required once configured", opts: Options{Keys: keys}, expectedCode: codes.Unauthenticated},
+		{name: "unknown key", opts: Options{Keys: keys}, key: "nope", expectedCode: codes.Unauthenticated},
+		{name: "key without the ingest scope", opts: Options{Keys: keys}, key: "s3cret", expectedCode: codes.PermissionDenied},
+		{name: "ingest key", opts: Options{Keys: keys}, key: "k3y", expectedCode: codes.OK},
+		{
+			name:         "key over its rate limit",
+			opts:         Options{Keys: keys, Quotas: ratelimit.NewQuotas(ratelimit.Limits{})},
+			key:          "l1mited",
+			calls:        1,
+			expectedCode: codes.ResourceExhausted,
+		},
+		{
+			name:         "caller outside the ingest networks",
+			opts:         Options{IngestNetworks: []netip.Prefix{netip.MustParsePrefix("10.20.0.0/16")}},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "signed messages required",
+			opts:         Options{Keys: keys, Signing: auth.SigningSecrets{"producer-eu": "s3cret"}},
+			key:          "k3y",
+			expectedCode: codes.PermissionDenied,
+		},
+		{name: "client certificates required", opts: Options{ClientCerts: true}, expectedCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mocks.NewMockMessageService(ctrl)
+			accepted := tt.expectedCode == codes.OK || tt.calls > 0
+			if accepted {
+				ms.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			}
+			client := newTestClient(t, ms, mocks.NewMockRocketService(ctrl), tt.opts)
+
+			for range tt.calls {
+				_, err := ingest(t.Context(), client, tt.key)
+				require.NoError(t, err)
+			}
+			resp, err := ingest(t.Context(), client, tt.key)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err), "%v", err)
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, int64(1), resp.GetAccepted())
+			}
+		})
+	}
+}
+
+func TestQueriesStayOpen(t *testing.T) {
+	keys := auth.NewKeys()
+	require.NoError(t, keys.Add("k3y", auth.Identity{Name: "producer-eu", Scopes: []auth.Scope{auth.ScopeIngest}}))
+
+	ctrl := gomock.NewController(t)
+	rs := mocks.NewMockRocketService(ctrl)
+	rs.EXPECT().ListRockets(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	client := newTestClient(t, nil, rs, Options{Keys: keys})
+
+	_, err := client.ListRockets(t.Context(), &rocketsv1.ListRocketsRequest{})
+	assert.NoError(t, err, "reading rockets needs no key, as over HTTP")
+}
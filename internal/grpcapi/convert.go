@@ -0,0 +1,148 @@
+package grpcapi
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/ahernandez9/rockets/internal/grpcapi/rocketsv1"
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toProtoRocket converts a rocket to its protobuf representation
+func toProtoRocket(rocket *models.Rocket) *rocketsv1.Rocket {
+	pb := &rocketsv1.Rocket{
+		Id:                rocket.ID,
+		Name:              rocket.Name,
+		Type:              rocket.Type,
+		Speed:             int64(rocket.Speed),
+		MaxSpeed:          int64(rocket.MaxSpeed),
+		AverageSpeed:      rocket.AverageSpeed,
+		Mission:           rocket.Mission,
+		Status:            string(rocket.Status),
+		ExplosionReason:   rocket.ExplosionReason,
+		LastMessageNumber: rocket.LastMessageNumber,
+		LastUpdated:       timestamppb.New(rocket.LastUpdated),
+		CurrentStage:      int32(rocket.CurrentStage),
+		FlightDuration:    rocket.FlightDuration,
+		EstimatedDistance: rocket.EstimatedDistance,
+		Labels:            maps.Clone(rocket.Labels),
+	}
+
+	if rocket.LaunchTime != nil {
+		pb.LaunchTime = timestamppb.New(*rocket.LaunchTime)
+	}
+	if rocket.ArchivedAt != nil {
+		pb.ArchivedAt = timestamppb.New(*rocket.ArchivedAt)
+	}
+	if rocket.FuelLevel != nil {
+		level := *rocket.FuelLevel
+		pb.FuelLevel = &level
+	}
+	if rocket.Position != nil {
+		pb.Position = &rocketsv1.Position{
+			Latitude:  rocket.Position.Latitude,
+			Longitude: rocket.Position.Longitude,
+			Altitude:  rocket.Position.Altitude,
+		}
+	}
+	for _, separation := range rocket.Stages {
+		pb.Stages = append(pb.Stages, &rocketsv1.StageSeparation{
+			Stage:         int32(separation.Stage),
+			MessageNumber: separation.MessageNumber,
+			Time:          timestamppb.New(separation.Time),
+		})
+	}
+	for _, payload := range rocket.Payloads {
+		pb.Payloads = append(pb.Payloads, &rocketsv1.Payload{
+			Name:          payload.Name,
+			MessageNumber: payload.MessageNumber,
+			DeployedAt:    timestamppb.New(payload.DeployedAt),
+		})
+	}
+	for _, anomaly := range rocket.Anomalies {
+		pb.Anomalies = append(pb.Anomalies, &rocketsv1.Anomaly{
+			Kind:          string(anomaly.Kind),
+			Detail:        anomaly.Detail,
+			MessageNumber: anomaly.MessageNumber,
+			Time:          timestamppb.New(anomaly.Time),
+		})
+	}
+
+	return pb
+}
+
+// fromProtoMessage converts a streamed telemetry message to a RocketMessage, deriving the message type from its payload
+func fromProtoMessage(req *rocketsv1.IngestTelemetryRequest) (*models.RocketMessage, error) {
+	metadata := req.GetMetadata()
+	if metadata == nil {
+		return nil, fmt.Errorf("metadata is required")
+	}
+
+	msg := &models.RocketMessage{
+		Metadata: models.MessageMetadata{
+			Channel:       metadata.GetChannel(),
+			MessageNumber: metadata.GetMessageNumber(),
+		},
+	}
+	if metadata.GetMessageTime() != nil {
+		msg.Metadata.MessageTime = metadata.GetMessageTime().AsTime()
+	}
+
+	// The content is encoded as if submitted in JSON, the format validation and processing decode
+	var content any
+	switch payload := req.GetPayload().(type) {
+	case *rocketsv1.IngestTelemetryRequest_RocketLaunched:
+		msg.Metadata.MessageType = "RocketLaunched"
+		content = models.RocketLaunchedMessage{
+			Type:        payload.RocketLaunched.GetType(),
+			LaunchSpeed: int(payload.RocketLaunched.GetLaunchSpeed()),
+			Mission:     payload.RocketLaunched.GetMission(),
+		}
+	case *rocketsv1.IngestTelemetryRequest_RocketSpeedIncreased:
+		msg.Metadata.MessageType = "RocketSpeedIncreased"
+		content = models.RocketSpeedChangedMessage{By: int(payload.RocketSpeedIncreased.GetBy())}
+	case *rocketsv1.IngestTelemetryRequest_RocketSpeedDecreased:
+		msg.Metadata.MessageType = "RocketSpeedDecreased"
+		content = models.RocketSpeedChangedMessage{By: int(payload.RocketSpeedDecreased.GetBy())}
+	case *rocketsv1.IngestTelemetryRequest_RocketExploded:
+		msg.Metadata.MessageType = "RocketExploded"
+		content = models.RocketExplodedMessage{Reason: payload.RocketExploded.GetReason()}
+	case *rocketsv1.IngestTelemetryRequest_RocketLanded:
+		msg.Metadata.MessageType = "RocketLanded"
+		content = models.RocketLandedMessage{}
+	case *rocketsv1.IngestTelemetryRequest_RocketDecommissioned:
+		msg.Metadata.MessageType = "RocketDecommissioned"
+		content = models.RocketDecommissionedMessage{}
+	case *rocketsv1.IngestTelemetryRequest_RocketMissionChanged:
+		msg.Metadata.MessageType = "RocketMissionChanged"
+		content = models.RocketMissionChangedMessage{NewMission: payload.RocketMissionChanged.GetNewMission()}
+	case *rocketsv1.IngestTelemetryRequest_RocketFuelUpdated:
+		msg.Metadata.MessageType = "RocketFuelUpdated"
+		content = models.RocketFuelUpdatedMessage{FuelLevel: payload.RocketFuelUpdated.FuelLevel}
+	case *rocketsv1.IngestTelemetryRequest_RocketPositionUpdated:
+		msg.Metadata.MessageType = "RocketPositionUpdated"
+		content = models.RocketPositionUpdatedMessage{
+			Latitude:  payload.RocketPositionUpdated.Latitude,
+			Longitude: payload.RocketPositionUpdated.Longitude,
+			Altitude:  payload.RocketPositionUpdated.Altitude,
+		}
+	case *rocketsv1.IngestTelemetryRequest_RocketStageSeparated:
+		msg.Metadata.MessageType = "RocketStageSeparated"
+		content = models.RocketStageSeparatedMessage{Stage: int(payload.RocketStageSeparated.GetStage())}
+	case *rocketsv1.IngestTelemetryRequest_RocketPayloadDeployed:
+		msg.Metadata.MessageType = "RocketPayloadDeployed"
+		content = models.RocketPayloadDeployedMessage{Name: payload.RocketPayloadDeployed.GetName()}
+	default:
+		return nil, fmt.Errorf("payload is required")
+	}
+
+	var err error
+	if msg.Message, err = jsoncodec.Marshal(content); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
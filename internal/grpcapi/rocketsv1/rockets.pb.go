@@ -0,0 +1,2334 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: rockets/v1/rockets.proto
+
+package rocketsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Rocket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type              string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Speed             int64                  `protobuf:"varint,3,opt,name=speed,proto3" json:"speed,omitempty"`
+	Mission           string                 `protobuf:"bytes,4,opt,name=mission,proto3" json:"mission,omitempty"`
+	Status            string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	ExplosionReason   string                 `protobuf:"bytes,6,opt,name=explosion_reason,json=explosionReason,proto3" json:"explosion_reason,omitempty"`
+	LastMessageNumber int64                  `protobuf:"varint,7,opt,name=last_message_number,json=lastMessageNumber,proto3" json:"last_message_number,omitempty"`
+	LastUpdated       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	ArchivedAt        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// Remaining fuel in percent, unset until the rocket reports it.
+	FuelLevel *float64 `protobuf:"fixed64,10,opt,name=fuel_level,json=fuelLevel,proto3,oneof" json:"fuel_level,omitempty"`
+	// Last reported position, unset until the rocket reports it.
+	Position     *Position `protobuf:"bytes,11,opt,name=position,proto3" json:"position,omitempty"`
+	CurrentStage int32     `protobuf:"varint,12,opt,name=current_stage,json=currentStage,proto3" json:"current_stage,omitempty"`
+	// Stage separations, oldest first.
+	Stages []*StageSeparation `protobuf:"bytes,13,rep,name=stages,proto3" json:"stages,omitempty"`
+	Labels map[string]string  `protobuf:"bytes,14,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Display name, empty until an operator names the rocket.
+	Name       string                 `protobuf:"bytes,15,opt,name=name,proto3" json:"name,omitempty"`
+	LaunchTime *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=launch_time,json=launchTime,proto3" json:"launch_time,omitempty"`
+	// Seconds from launch to the latest applied message.
+	FlightDuration float64 `protobuf:"fixed64,17,opt,name=flight_duration,json=flightDuration,proto3" json:"flight_duration,omitempty"`
+	// Highest speed reported since launch.
+	MaxSpeed int64 `protobuf:"varint,18,opt,name=max_speed,json=maxSpeed,proto3" json:"max_speed,omitempty"`
+	// Mean of the speeds reported since launch.
+	AverageSpeed float64 `protobuf:"fixed64,19,opt,name=average_speed,json=averageSpeed,proto3" json:"average_speed,omitempty"`
+	// Deployed payloads, oldest first.
+	Payloads []*Payload `protobuf:"bytes,20,rep,name=payloads,proto3" json:"payloads,omitempty"`
+	// Implausible transitions detected in telemetry, oldest first.
+	Anomalies []*Anomaly `protobuf:"bytes,21,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	// Kilometers covered since launch, integrating the speed between applied messages.
+	EstimatedDistance float64 `protobuf:"fixed64,22,opt,name=estimated_distance,json=estimatedDistance,proto3" json:"estimated_distance,omitempty"`
+}
+
+func (x *Rocket) Reset() {
+	*x = Rocket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Rocket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Rocket) ProtoMessage() {}
+
+func (x *Rocket) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Rocket.ProtoReflect.Descriptor instead.
+func (*Rocket) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Rocket) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Rocket) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Rocket) GetSpeed() int64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+func (x *Rocket) GetMission() string {
+	if x != nil {
+		return x.Mission
+	}
+	return ""
+}
+
+func (x *Rocket) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Rocket) GetExplosionReason() string {
+	if x != nil {
+		return x.ExplosionReason
+	}
+	return ""
+}
+
+func (x *Rocket) GetLastMessageNumber() int64 {
+	if x != nil {
+		return x.LastMessageNumber
+	}
+	return 0
+}
+
+func (x *Rocket) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *Rocket) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Rocket) GetFuelLevel() float64 {
+	if x != nil && x.FuelLevel != nil {
+		return *x.FuelLevel
+	}
+	return 0
+}
+
+func (x *Rocket) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Rocket) GetCurrentStage() int32 {
+	if x != nil {
+		return x.CurrentStage
+	}
+	return 0
+}
+
+func (x *Rocket) GetStages() []*StageSeparation {
+	if x != nil {
+		return x.Stages
+	}
+	return nil
+}
+
+func (x *Rocket) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Rocket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Rocket) GetLaunchTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LaunchTime
+	}
+	return nil
+}
+
+func (x *Rocket) GetFlightDuration() float64 {
+	if x != nil {
+		return x.FlightDuration
+	}
+	return 0
+}
+
+func (x *Rocket) GetMaxSpeed() int64 {
+	if x != nil {
+		return x.MaxSpeed
+	}
+	return 0
+}
+
+func (x *Rocket) GetAverageSpeed() float64 {
+	if x != nil {
+		return x.AverageSpeed
+	}
+	return 0
+}
+
+func (x *Rocket) GetPayloads() []*Payload {
+	if x != nil {
+		return x.Payloads
+	}
+	return nil
+}
+
+func (x *Rocket) GetAnomalies() []*Anomaly {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+func (x *Rocket) GetEstimatedDistance() float64 {
+	if x != nil {
+		return x.EstimatedDistance
+	}
+	return 0
+}
+
+type Anomaly struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Detail        string                 `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+	MessageNumber int64                  `protobuf:"varint,3,opt,name=message_number,json=messageNumber,proto3" json:"message_number,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *Anomaly) Reset() {
+	*x = Anomaly{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Anomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Anomaly) ProtoMessage() {}
+
+func (x *Anomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Anomaly.ProtoReflect.Descriptor instead.
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Anomaly) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Anomaly) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *Anomaly) GetMessageNumber() int64 {
+	if x != nil {
+		return x.MessageNumber
+	}
+	return 0
+}
+
+func (x *Anomaly) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type Payload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	MessageNumber int64                  `protobuf:"varint,2,opt,name=message_number,json=messageNumber,proto3" json:"message_number,omitempty"`
+	DeployedAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deployed_at,json=deployedAt,proto3" json:"deployed_at,omitempty"`
+}
+
+func (x *Payload) Reset() {
+	*x = Payload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Payload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payload) ProtoMessage() {}
+
+func (x *Payload) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payload.ProtoReflect.Descriptor instead.
+func (*Payload) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Payload) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Payload) GetMessageNumber() int64 {
+	if x != nil {
+		return x.MessageNumber
+	}
+	return 0
+}
+
+func (x *Payload) GetDeployedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeployedAt
+	}
+	return nil
+}
+
+type StageSeparation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Stage         int32                  `protobuf:"varint,1,opt,name=stage,proto3" json:"stage,omitempty"`
+	MessageNumber int64                  `protobuf:"varint,2,opt,name=message_number,json=messageNumber,proto3" json:"message_number,omitempty"`
+	Time          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+}
+
+func (x *StageSeparation) Reset() {
+	*x = StageSeparation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StageSeparation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageSeparation) ProtoMessage() {}
+
+func (x *StageSeparation) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageSeparation.ProtoReflect.Descriptor instead.
+func (*StageSeparation) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *StageSeparation) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+func (x *StageSeparation) GetMessageNumber() int64 {
+	if x != nil {
+		return x.MessageNumber
+	}
+	return 0
+}
+
+func (x *StageSeparation) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+type Position struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  float64 `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude float64 `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	// Meters above sea level.
+	Altitude float64 `protobuf:"fixed64,3,opt,name=altitude,proto3" json:"altitude,omitempty"`
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Position) GetLatitude() float64 {
+	if x != nil {
+		return x.Latitude
+	}
+	return 0
+}
+
+func (x *Position) GetLongitude() float64 {
+	if x != nil {
+		return x.Longitude
+	}
+	return 0
+}
+
+func (x *Position) GetAltitude() float64 {
+	if x != nil {
+		return x.Altitude
+	}
+	return 0
+}
+
+type GetRocketRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetRocketRequest) Reset() {
+	*x = GetRocketRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRocketRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRocketRequest) ProtoMessage() {}
+
+func (x *GetRocketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRocketRequest.ProtoReflect.Descriptor instead.
+func (*GetRocketRequest) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetRocketRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetRocketResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rocket *Rocket `protobuf:"bytes,1,opt,name=rocket,proto3" json:"rocket,omitempty"`
+}
+
+func (x *GetRocketResponse) Reset() {
+	*x = GetRocketResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRocketResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRocketResponse) ProtoMessage() {}
+
+func (x *GetRocketResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRocketResponse.ProtoReflect.Descriptor instead.
+func (*GetRocketResponse) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetRocketResponse) GetRocket() *Rocket {
+	if x != nil {
+		return x.Rocket
+	}
+	return nil
+}
+
+type ListRocketsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Comma-separated sort fields, prefixed with '-' for descending or '+' for ascending order; speed sorts in
+	// descending order otherwise. Defaults to "id".
+	Sort    string `protobuf:"bytes,1,opt,name=sort,proto3" json:"sort,omitempty"`
+	Status  string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Mission string `protobuf:"bytes,3,opt,name=mission,proto3" json:"mission,omitempty"`
+	Type    string `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"`
+	// Case-insensitive search over ID, name, type and mission.
+	Query string `protobuf:"bytes,5,opt,name=query,proto3" json:"query,omitempty"`
+	// Filter expression, e.g. "speed>1000 AND status=ACTIVE".
+	Filter          string `protobuf:"bytes,6,opt,name=filter,proto3" json:"filter,omitempty"`
+	IncludeArchived bool   `protobuf:"varint,7,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	// Only rockets whose fuel level is below 20%.
+	LowFuel bool `protobuf:"varint,8,opt,name=low_fuel,json=lowFuel,proto3" json:"low_fuel,omitempty"`
+	// Label selectors of the form "key=value", all of which must match.
+	Labels []string `protobuf:"bytes,9,rep,name=labels,proto3" json:"labels,omitempty"`
+}
+
+func (x *ListRocketsRequest) Reset() {
+	*x = ListRocketsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRocketsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRocketsRequest) ProtoMessage() {}
+
+func (x *ListRocketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRocketsRequest.ProtoReflect.Descriptor instead.
+func (*ListRocketsRequest) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRocketsRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRocketsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListRocketsRequest) GetMission() string {
+	if x != nil {
+		return x.Mission
+	}
+	return ""
+}
+
+func (x *ListRocketsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ListRocketsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *ListRocketsRequest) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *ListRocketsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
+func (x *ListRocketsRequest) GetLowFuel() bool {
+	if x != nil {
+		return x.LowFuel
+	}
+	return false
+}
+
+func (x *ListRocketsRequest) GetLabels() []string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type ListRocketsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rockets []*Rocket `protobuf:"bytes,1,rep,name=rockets,proto3" json:"rockets,omitempty"`
+}
+
+func (x *ListRocketsResponse) Reset() {
+	*x = ListRocketsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRocketsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRocketsResponse) ProtoMessage() {}
+
+func (x *ListRocketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRocketsResponse.ProtoReflect.Descriptor instead.
+func (*ListRocketsResponse) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListRocketsResponse) GetRockets() []*Rocket {
+	if x != nil {
+		return x.Rockets
+	}
+	return nil
+}
+
+type MessageMetadata struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	MessageNumber int64                  `protobuf:"varint,2,opt,name=message_number,json=messageNumber,proto3" json:"message_number,omitempty"`
+	MessageTime   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=message_time,json=messageTime,proto3" json:"message_time,omitempty"`
+}
+
+func (x *MessageMetadata) Reset() {
+	*x = MessageMetadata{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MessageMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageMetadata) ProtoMessage() {}
+
+func (x *MessageMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageMetadata.ProtoReflect.Descriptor instead.
+func (*MessageMetadata) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MessageMetadata) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *MessageMetadata) GetMessageNumber() int64 {
+	if x != nil {
+		return x.MessageNumber
+	}
+	return 0
+}
+
+func (x *MessageMetadata) GetMessageTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MessageTime
+	}
+	return nil
+}
+
+type RocketLaunched struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type        string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	LaunchSpeed int64  `protobuf:"varint,2,opt,name=launch_speed,json=launchSpeed,proto3" json:"launch_speed,omitempty"`
+	Mission     string `protobuf:"bytes,3,opt,name=mission,proto3" json:"mission,omitempty"`
+}
+
+func (x *RocketLaunched) Reset() {
+	*x = RocketLaunched{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketLaunched) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketLaunched) ProtoMessage() {}
+
+func (x *RocketLaunched) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketLaunched.ProtoReflect.Descriptor instead.
+func (*RocketLaunched) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RocketLaunched) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *RocketLaunched) GetLaunchSpeed() int64 {
+	if x != nil {
+		return x.LaunchSpeed
+	}
+	return 0
+}
+
+func (x *RocketLaunched) GetMission() string {
+	if x != nil {
+		return x.Mission
+	}
+	return ""
+}
+
+type RocketSpeedChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	By int64 `protobuf:"varint,1,opt,name=by,proto3" json:"by,omitempty"`
+}
+
+func (x *RocketSpeedChanged) Reset() {
+	*x = RocketSpeedChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketSpeedChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketSpeedChanged) ProtoMessage() {}
+
+func (x *RocketSpeedChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketSpeedChanged.ProtoReflect.Descriptor instead.
+func (*RocketSpeedChanged) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RocketSpeedChanged) GetBy() int64 {
+	if x != nil {
+		return x.By
+	}
+	return 0
+}
+
+type RocketExploded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reason string `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *RocketExploded) Reset() {
+	*x = RocketExploded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketExploded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketExploded) ProtoMessage() {}
+
+func (x *RocketExploded) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketExploded.ProtoReflect.Descriptor instead.
+func (*RocketExploded) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RocketExploded) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// RocketLanded reports that a rocket touched down safely.
+type RocketLanded struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RocketLanded) Reset() {
+	*x = RocketLanded{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketLanded) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketLanded) ProtoMessage() {}
+
+func (x *RocketLanded) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketLanded.ProtoReflect.Descriptor instead.
+func (*RocketLanded) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{13}
+}
+
+// RocketDecommissioned reports that a landed rocket was retired from service.
+type RocketDecommissioned struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RocketDecommissioned) Reset() {
+	*x = RocketDecommissioned{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketDecommissioned) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketDecommissioned) ProtoMessage() {}
+
+func (x *RocketDecommissioned) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketDecommissioned.ProtoReflect.Descriptor instead.
+func (*RocketDecommissioned) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{14}
+}
+
+type RocketMissionChanged struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NewMission string `protobuf:"bytes,1,opt,name=new_mission,json=newMission,proto3" json:"new_mission,omitempty"`
+}
+
+func (x *RocketMissionChanged) Reset() {
+	*x = RocketMissionChanged{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketMissionChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketMissionChanged) ProtoMessage() {}
+
+func (x *RocketMissionChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketMissionChanged.ProtoReflect.Descriptor instead.
+func (*RocketMissionChanged) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RocketMissionChanged) GetNewMission() string {
+	if x != nil {
+		return x.NewMission
+	}
+	return ""
+}
+
+type RocketFuelUpdated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Remaining fuel in percent, from 0 to 100.
+	FuelLevel *float64 `protobuf:"fixed64,1,opt,name=fuel_level,json=fuelLevel,proto3,oneof" json:"fuel_level,omitempty"`
+}
+
+func (x *RocketFuelUpdated) Reset() {
+	*x = RocketFuelUpdated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketFuelUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketFuelUpdated) ProtoMessage() {}
+
+func (x *RocketFuelUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketFuelUpdated.ProtoReflect.Descriptor instead.
+func (*RocketFuelUpdated) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *RocketFuelUpdated) GetFuelLevel() float64 {
+	if x != nil && x.FuelLevel != nil {
+		return *x.FuelLevel
+	}
+	return 0
+}
+
+type RocketStageSeparated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Number of the separated stage; the rocket continues on the next one.
+	Stage int32 `protobuf:"varint,1,opt,name=stage,proto3" json:"stage,omitempty"`
+}
+
+func (x *RocketStageSeparated) Reset() {
+	*x = RocketStageSeparated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketStageSeparated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketStageSeparated) ProtoMessage() {}
+
+func (x *RocketStageSeparated) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketStageSeparated.ProtoReflect.Descriptor instead.
+func (*RocketStageSeparated) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RocketStageSeparated) GetStage() int32 {
+	if x != nil {
+		return x.Stage
+	}
+	return 0
+}
+
+type RocketPayloadDeployed struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RocketPayloadDeployed) Reset() {
+	*x = RocketPayloadDeployed{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketPayloadDeployed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketPayloadDeployed) ProtoMessage() {}
+
+func (x *RocketPayloadDeployed) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketPayloadDeployed.ProtoReflect.Descriptor instead.
+func (*RocketPayloadDeployed) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RocketPayloadDeployed) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type RocketPositionUpdated struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Latitude  *float64 `protobuf:"fixed64,1,opt,name=latitude,proto3,oneof" json:"latitude,omitempty"`
+	Longitude *float64 `protobuf:"fixed64,2,opt,name=longitude,proto3,oneof" json:"longitude,omitempty"`
+	Altitude  *float64 `protobuf:"fixed64,3,opt,name=altitude,proto3,oneof" json:"altitude,omitempty"`
+}
+
+func (x *RocketPositionUpdated) Reset() {
+	*x = RocketPositionUpdated{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RocketPositionUpdated) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RocketPositionUpdated) ProtoMessage() {}
+
+func (x *RocketPositionUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RocketPositionUpdated.ProtoReflect.Descriptor instead.
+func (*RocketPositionUpdated) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RocketPositionUpdated) GetLatitude() float64 {
+	if x != nil && x.Latitude != nil {
+		return *x.Latitude
+	}
+	return 0
+}
+
+func (x *RocketPositionUpdated) GetLongitude() float64 {
+	if x != nil && x.Longitude != nil {
+		return *x.Longitude
+	}
+	return 0
+}
+
+func (x *RocketPositionUpdated) GetAltitude() float64 {
+	if x != nil && x.Altitude != nil {
+		return *x.Altitude
+	}
+	return 0
+}
+
+// IngestTelemetryRequest carries a single rocket message; the payload determines the message type.
+type IngestTelemetryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Metadata *MessageMetadata `protobuf:"bytes,1,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// Types that are assignable to Payload:
+	//	*IngestTelemetryRequest_RocketLaunched
+	//	*IngestTelemetryRequest_RocketSpeedIncreased
+	//	*IngestTelemetryRequest_RocketSpeedDecreased
+	//	*IngestTelemetryRequest_RocketExploded
+	//	*IngestTelemetryRequest_RocketMissionChanged
+	//	*IngestTelemetryRequest_RocketFuelUpdated
+	//	*IngestTelemetryRequest_RocketPositionUpdated
+	//	*IngestTelemetryRequest_RocketStageSeparated
+	//	*IngestTelemetryRequest_RocketPayloadDeployed
+	//	*IngestTelemetryRequest_RocketLanded
+	//	*IngestTelemetryRequest_RocketDecommissioned
+	Payload isIngestTelemetryRequest_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *IngestTelemetryRequest) Reset() {
+	*x = IngestTelemetryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestTelemetryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestTelemetryRequest) ProtoMessage() {}
+
+func (x *IngestTelemetryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestTelemetryRequest.ProtoReflect.Descriptor instead.
+func (*IngestTelemetryRequest) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *IngestTelemetryRequest) GetMetadata() *MessageMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (m *IngestTelemetryRequest) GetPayload() isIngestTelemetryRequest_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketLaunched() *RocketLaunched {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketLaunched); ok {
+		return x.RocketLaunched
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketSpeedIncreased() *RocketSpeedChanged {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketSpeedIncreased); ok {
+		return x.RocketSpeedIncreased
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketSpeedDecreased() *RocketSpeedChanged {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketSpeedDecreased); ok {
+		return x.RocketSpeedDecreased
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketExploded() *RocketExploded {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketExploded); ok {
+		return x.RocketExploded
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketMissionChanged() *RocketMissionChanged {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketMissionChanged); ok {
+		return x.RocketMissionChanged
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketFuelUpdated() *RocketFuelUpdated {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketFuelUpdated); ok {
+		return x.RocketFuelUpdated
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketPositionUpdated() *RocketPositionUpdated {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketPositionUpdated); ok {
+		return x.RocketPositionUpdated
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketStageSeparated() *RocketStageSeparated {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketStageSeparated); ok {
+		return x.RocketStageSeparated
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketPayloadDeployed() *RocketPayloadDeployed {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketPayloadDeployed); ok {
+		return x.RocketPayloadDeployed
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketLanded() *RocketLanded {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketLanded); ok {
+		return x.RocketLanded
+	}
+	return nil
+}
+
+func (x *IngestTelemetryRequest) GetRocketDecommissioned() *RocketDecommissioned {
+	if x, ok := x.GetPayload().(*IngestTelemetryRequest_RocketDecommissioned); ok {
+		return x.RocketDecommissioned
+	}
+	return nil
+}
+
+type isIngestTelemetryRequest_Payload interface {
+	isIngestTelemetryRequest_Payload()
+}
+
+type IngestTelemetryRequest_RocketLaunched struct {
+	RocketLaunched *RocketLaunched `protobuf:"bytes,2,opt,name=rocket_launched,json=rocketLaunched,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketSpeedIncreased struct {
+	RocketSpeedIncreased *RocketSpeedChanged `protobuf:"bytes,3,opt,name=rocket_speed_increased,json=rocketSpeedIncreased,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketSpeedDecreased struct {
+	RocketSpeedDecreased *RocketSpeedChanged `protobuf:"bytes,4,opt,name=rocket_speed_decreased,json=rocketSpeedDecreased,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketExploded struct {
+	RocketExploded *RocketExploded `protobuf:"bytes,5,opt,name=rocket_exploded,json=rocketExploded,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketMissionChanged struct {
+	RocketMissionChanged *RocketMissionChanged `protobuf:"bytes,6,opt,name=rocket_mission_changed,json=rocketMissionChanged,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketFuelUpdated struct {
+	RocketFuelUpdated *RocketFuelUpdated `protobuf:"bytes,7,opt,name=rocket_fuel_updated,json=rocketFuelUpdated,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketPositionUpdated struct {
+	RocketPositionUpdated *RocketPositionUpdated `protobuf:"bytes,8,opt,name=rocket_position_updated,json=rocketPositionUpdated,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketStageSeparated struct {
+	RocketStageSeparated *RocketStageSeparated `protobuf:"bytes,9,opt,name=rocket_stage_separated,json=rocketStageSeparated,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketPayloadDeployed struct {
+	RocketPayloadDeployed *RocketPayloadDeployed `protobuf:"bytes,10,opt,name=rocket_payload_deployed,json=rocketPayloadDeployed,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketLanded struct {
+	RocketLanded *RocketLanded `protobuf:"bytes,11,opt,name=rocket_landed,json=rocketLanded,proto3,oneof"`
+}
+
+type IngestTelemetryRequest_RocketDecommissioned struct {
+	RocketDecommissioned *RocketDecommissioned `protobuf:"bytes,12,opt,name=rocket_decommissioned,json=rocketDecommissioned,proto3,oneof"`
+}
+
+func (*IngestTelemetryRequest_RocketLaunched) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketSpeedIncreased) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketSpeedDecreased) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketExploded) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketMissionChanged) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketFuelUpdated) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketPositionUpdated) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketStageSeparated) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketPayloadDeployed) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketLanded) isIngestTelemetryRequest_Payload() {}
+
+func (*IngestTelemetryRequest_RocketDecommissioned) isIngestTelemetryRequest_Payload() {}
+
+type RejectedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Position of the message in the stream, starting at 1.
+	Index int64  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *RejectedMessage) Reset() {
+	*x = RejectedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RejectedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectedMessage) ProtoMessage() {}
+
+func (x *RejectedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectedMessage.ProtoReflect.Descriptor instead.
+func (*RejectedMessage) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RejectedMessage) GetIndex() int64 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *RejectedMessage) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type IngestTelemetryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total    int64              `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Accepted int64              `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"`
+	Rejected int64              `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Errors   []*RejectedMessage `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+}
+
+func (x *IngestTelemetryResponse) Reset() {
+	*x = IngestTelemetryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rockets_v1_rockets_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IngestTelemetryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IngestTelemetryResponse) ProtoMessage() {}
+
+func (x *IngestTelemetryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rockets_v1_rockets_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestTelemetryResponse.ProtoReflect.Descriptor instead.
+func (*IngestTelemetryResponse) Descriptor() ([]byte, []int) {
+	return file_rockets_v1_rockets_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *IngestTelemetryResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *IngestTelemetryResponse) GetAccepted() int64 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *IngestTelemetryResponse) GetRejected() int64 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *IngestTelemetryResponse) GetErrors() []*RejectedMessage {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+var File_rockets_v1_rockets_proto protoreflect.FileDescriptor
+
+var file_rockets_v1_rockets_proto_rawDesc = []byte{
+	0x0a, 0x18, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x72, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xcc, 0x07, 0x0a, 0x06, 0x52, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x29,
+	0x0a, 0x10, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x73,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x13, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6c, 0x61, 0x73, 0x74, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0c, 0x6c, 0x61, 0x73,
+	0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6c, 0x61, 0x73,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x3b, 0x0a, 0x0b, 0x61, 0x72, 0x63, 0x68,
+	0x69, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x64, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x0a, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x6c, 0x65,
+	0x76, 0x65, 0x6c, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x09, 0x66, 0x75, 0x65,
+	0x6c, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x88, 0x01, 0x01, 0x12, 0x30, 0x0a, 0x08, 0x70, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x08, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0c, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65,
+	0x12, 0x33, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x67, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1b, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74,
+	0x61, 0x67, 0x65, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x5f, 0x74, 0x69, 0x6d, 0x65,
+	0x18, 0x10, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x0a, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x27,
+	0x0a, 0x0f, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x11, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x66, 0x6c, 0x69, 0x67, 0x68, 0x74, 0x44,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x5f, 0x73,
+	0x70, 0x65, 0x65, 0x64, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x53,
+	0x70, 0x65, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x76, 0x65, 0x72, 0x61, 0x67, 0x65, 0x5f,
+	0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x61, 0x76, 0x65,
+	0x72, 0x61, 0x67, 0x65, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12, 0x2f, 0x0a, 0x08, 0x70, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x73, 0x18, 0x14, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x72, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x52, 0x08, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x73, 0x12, 0x31, 0x0a, 0x09, 0x61, 0x6e,
+	0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6e, 0x6f, 0x6d, 0x61,
+	0x6c, 0x79, 0x52, 0x09, 0x61, 0x6e, 0x6f, 0x6d, 0x61, 0x6c, 0x69, 0x65, 0x73, 0x12, 0x2d, 0x0a,
+	0x12, 0x65, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61,
+	0x6e, 0x63, 0x65, 0x18, 0x16, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x65, 0x73, 0x74, 0x69, 0x6d,
+	0x61, 0x74, 0x65, 0x64, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x1a, 0x39, 0x0a, 0x0b,
+	0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
+	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x75, 0x65, 0x6c,
+	0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x8c, 0x01, 0x0a, 0x07, 0x41, 0x6e, 0x6f, 0x6d, 0x61,
+	0x6c, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x25,
+	0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52,
+	0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x81, 0x01, 0x0a, 0x07, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x0b,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x64,
+	0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x64, 0x41, 0x74, 0x22, 0x7e, 0x0a, 0x0f, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x22, 0x60, 0x0a, 0x08, 0x50, 0x6f, 0x73,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64,
+	0x65, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6c, 0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x22, 0x22, 0x0a, 0x10, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22,
+	0x3f, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x22, 0xfa, 0x01, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x6f, 0x72, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
+	0x29, 0x0a, 0x10, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x5f, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75,
+	0x64, 0x65, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x6f,
+	0x77, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x6c, 0x6f,
+	0x77, 0x46, 0x75, 0x65, 0x6c, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x22, 0x43, 0x0a,
+	0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x72, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x22, 0x91, 0x01, 0x0a, 0x0f, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65,
+	0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65,
+	0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
+	0x12, 0x25, 0x0a, 0x0e, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
+	0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x3d, 0x0a, 0x0c, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0b, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x61, 0x0a, 0x0e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x53, 0x70, 0x65, 0x65, 0x64, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x24, 0x0a, 0x12, 0x52, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12,
+	0x0e, 0x0a, 0x02, 0x62, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x62, 0x79, 0x22,
+	0x28, 0x0a, 0x0e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x64, 0x65,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x0e, 0x0a, 0x0c, 0x52, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x22, 0x16, 0x0a, 0x14, 0x52, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x65,
+	0x64, 0x22, 0x37, 0x0a, 0x14, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x65, 0x77,
+	0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x6e, 0x65, 0x77, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x46, 0x0a, 0x11, 0x52, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12,
+	0x22, 0x0a, 0x0a, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x09, 0x66, 0x75, 0x65, 0x6c, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x88, 0x01, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x22, 0x2c, 0x0a, 0x14, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x67,
+	0x65, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74,
+	0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61, 0x67, 0x65,
+	0x22, 0x2b, 0x0a, 0x15, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xa4, 0x01,
+	0x0a, 0x15, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x08, 0x6c, 0x61, 0x74, 0x69, 0x74,
+	0x75, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x08, 0x6c, 0x61, 0x74,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x21, 0x0a, 0x09, 0x6c, 0x6f, 0x6e, 0x67,
+	0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x09, 0x6c,
+	0x6f, 0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x88, 0x01, 0x01, 0x12, 0x1f, 0x0a, 0x08, 0x61,
+	0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02, 0x52,
+	0x08, 0x61, 0x6c, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x88, 0x01, 0x01, 0x42, 0x0b, 0x0a, 0x09,
+	0x5f, 0x6c, 0x61, 0x74, 0x69, 0x74, 0x75, 0x64, 0x65, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x6c, 0x6f,
+	0x6e, 0x67, 0x69, 0x74, 0x75, 0x64, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x5f, 0x61, 0x6c, 0x74, 0x69,
+	0x74, 0x75, 0x64, 0x65, 0x22, 0xf3, 0x07, 0x0a, 0x16, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x54,
+	0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x37, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x52, 0x08,
+	0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x45, 0x0a, 0x0f, 0x72, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x5f, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x48, 0x00, 0x52,
+	0x0e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x65, 0x64, 0x12,
+	0x56, 0x0a, 0x16, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f,
+	0x69, 0x6e, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1e, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48,
+	0x00, 0x52, 0x14, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x49, 0x6e,
+	0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x64, 0x12, 0x56, 0x0a, 0x16, 0x72, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x5f, 0x73, 0x70, 0x65, 0x65, 0x64, 0x5f, 0x64, 0x65, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x70, 0x65, 0x65, 0x64,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x14, 0x72, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x53, 0x70, 0x65, 0x65, 0x64, 0x44, 0x65, 0x63, 0x72, 0x65, 0x61, 0x73, 0x65, 0x64, 0x12,
+	0x45, 0x0a, 0x0f, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x65, 0x78, 0x70, 0x6c, 0x6f, 0x64,
+	0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x78, 0x70, 0x6c,
+	0x6f, 0x64, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x45, 0x78,
+	0x70, 0x6c, 0x6f, 0x64, 0x65, 0x64, 0x12, 0x58, 0x0a, 0x16, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x5f, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x48, 0x00, 0x52, 0x14, 0x72, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x4d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64,
+	0x12, 0x4f, 0x0a, 0x13, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x66, 0x75, 0x65, 0x6c, 0x5f,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x46, 0x75, 0x65, 0x6c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x11,
+	0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x46, 0x75, 0x65, 0x6c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x64, 0x12, 0x5b, 0x0a, 0x17, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x15, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50,
+	0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x12, 0x58,
+	0x0a, 0x16, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x73,
+	0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20,
+	0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x53, 0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x65, 0x64,
+	0x48, 0x00, 0x52, 0x14, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x74, 0x61, 0x67, 0x65, 0x53,
+	0x65, 0x70, 0x61, 0x72, 0x61, 0x74, 0x65, 0x64, 0x12, 0x5b, 0x0a, 0x17, 0x72, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x5f, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x5f, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x65, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x72, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x79,
+	0x6c, 0x6f, 0x61, 0x64, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x65, 0x64, 0x48, 0x00, 0x52, 0x15,
+	0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x44, 0x65, 0x70,
+	0x6c, 0x6f, 0x79, 0x65, 0x64, 0x12, 0x3f, 0x0a, 0x0d, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f,
+	0x6c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x72,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0c, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x4c, 0x61, 0x6e, 0x64, 0x65, 0x64, 0x12, 0x57, 0x0a, 0x15, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x5f, 0x64, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x48, 0x00, 0x52, 0x14, 0x72, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x44, 0x65, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x65, 0x64, 0x42,
+	0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3d, 0x0a, 0x0f, 0x52, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x9c, 0x01, 0x0a, 0x17, 0x49, 0x6e,
+	0x67, 0x65, 0x73, 0x74, 0x54, 0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61,
+	0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72, 0x65, 0x6a, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x32, 0x87, 0x02, 0x0a, 0x0d, 0x52, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x48, 0x0a, 0x09, 0x47, 0x65,
+	0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1c, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4e, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1f, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5c, 0x0a, 0x0f, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x54, 0x65,
+	0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x12, 0x22, 0x2e, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x54, 0x65, 0x6c, 0x65, 0x6d,
+	0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x72, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x6e, 0x67, 0x65, 0x73, 0x74, 0x54,
+	0x65, 0x6c, 0x65, 0x6d, 0x65, 0x74, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x28, 0x01, 0x42, 0x45, 0x5a, 0x43, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x61, 0x68, 0x65, 0x72, 0x6e, 0x61, 0x6e, 0x64, 0x65, 0x7a, 0x39, 0x2f, 0x72, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x67, 0x72,
+	0x70, 0x63, 0x61, 0x70, 0x69, 0x2f, 0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x76, 0x31, 0x3b,
+	0x72, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_rockets_v1_rockets_proto_rawDescOnce sync.Once
+	file_rockets_v1_rockets_proto_rawDescData = file_rockets_v1_rockets_proto_rawDesc
+)
+
+func file_rockets_v1_rockets_proto_rawDescGZIP() []byte {
+	file_rockets_v1_rockets_proto_rawDescOnce.Do(func() {
+		file_rockets_v1_rockets_proto_rawDescData = protoimpl.X.CompressGZIP(file_rockets_v1_rockets_proto_rawDescData)
+	})
+	return file_rockets_v1_rockets_proto_rawDescData
+}
+
+var file_rockets_v1_rockets_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_rockets_v1_rockets_proto_goTypes = []any{
+	(*Rocket)(nil),                  // 0: rockets.v1.Rocket
+	(*Anomaly)(nil),                 // 1: rockets.v1.Anomaly
+	(*Payload)(nil),                 // 2: rockets.v1.Payload
+	(*StageSeparation)(nil),         // 3: rockets.v1.StageSeparation
+	(*Position)(nil),                // 4: rockets.v1.Position
+	(*GetRocketRequest)(nil),        // 5: rockets.v1.GetRocketRequest
+	(*GetRocketResponse)(nil),       // 6: rockets.v1.GetRocketResponse
+	(*ListRocketsRequest)(nil),      // 7: rockets.v1.ListRocketsRequest
+	(*ListRocketsResponse)(nil),     // 8: rockets.v1.ListRocketsResponse
+	(*MessageMetadata)(nil),         // 9: rockets.v1.MessageMetadata
+	(*RocketLaunched)(nil),          // 10: rockets.v1.RocketLaunched
+	(*RocketSpeedChanged)(nil),      // 11: rockets.v1.RocketSpeedChanged
+	(*RocketExploded)(nil),          // 12: rockets.v1.RocketExploded
+	(*RocketLanded)(nil),            // 13: rockets.v1.RocketLanded
+	(*RocketDecommissioned)(nil),    // 14: rockets.v1.RocketDecommissioned
+	(*RocketMissionChanged)(nil),    // 15: rockets.v1.RocketMissionChanged
+	(*RocketFuelUpdated)(nil),       // 16: rockets.v1.RocketFuelUpdated
+	(*RocketStageSeparated)(nil),    // 17: rockets.v1.RocketStageSeparated
+	(*RocketPayloadDeployed)(nil),   // 18: rockets.v1.RocketPayloadDeployed
+	(*RocketPositionUpdated)(nil),   // 19: rockets.v1.RocketPositionUpdated
+	(*IngestTelemetryRequest)(nil),  // 20: rockets.v1.IngestTelemetryRequest
+	(*RejectedMessage)(nil),         // 21: rockets.v1.RejectedMessage
+	(*IngestTelemetryResponse)(nil), // 22: rockets.v1.IngestTelemetryResponse
+	nil,                             // 23: rockets.v1.Rocket.LabelsEntry
+	(*timestamppb.Timestamp)(nil),   // 24: google.protobuf.Timestamp
+}
+var file_rockets_v1_rockets_proto_depIdxs = []int32{
+	24, // 0: rockets.v1.Rocket.last_updated:type_name -> google.protobuf.Timestamp
+	24, // 1: rockets.v1.Rocket.archived_at:type_name -> google.protobuf.Timestamp
+	4,  // 2: rockets.v1.Rocket.position:type_name -> rockets.v1.Position
+	3,  // 3: rockets.v1.Rocket.stages:type_name -> rockets.v1.StageSeparation
+	23, // 4: rockets.v1.Rocket.labels:type_name -> rockets.v1.Rocket.LabelsEntry
+	24, // 5: rockets.v1.Rocket.launch_time:type_name -> google.protobuf.Timestamp
+	2,  // 6: rockets.v1.Rocket.payloads:type_name -> rockets.v1.Payload
+	1,  // 7: rockets.v1.Rocket.anomalies:type_name -> rockets.v1.Anomaly
+	24, // 8: rockets.v1.Anomaly.time:type_name -> google.protobuf.Timestamp
+	24, // 9: rockets.v1.Payload.deployed_at:type_name -> google.protobuf.Timestamp
+	24, // 10: rockets.v1.StageSeparation.time:type_name -> google.protobuf.Timestamp
+	0,  // 11: rockets.v1.GetRocketResponse.rocket:type_name -> rockets.v1.Rocket
+	0,  // 12: rockets.v1.ListRocketsResponse.rockets:type_name -> rockets.v1.Rocket
+	24, // 13: rockets.v1.MessageMetadata.message_time:type_name -> google.protobuf.Timestamp
+	9,  // 14: rockets.v1.IngestTelemetryRequest.metadata:type_name -> rockets.v1.MessageMetadata
+	10, // 15: rockets.v1.IngestTelemetryRequest.rocket_launched:type_name -> rockets.v1.RocketLaunched
+	11, // 16: rockets.v1.IngestTelemetryRequest.rocket_speed_increased:type_name -> rockets.v1.RocketSpeedChanged
+	11, // 17: rockets.v1.IngestTelemetryRequest.rocket_speed_decreased:type_name -> rockets.v1.RocketSpeedChanged
+	12, // 18: rockets.v1.IngestTelemetryRequest.rocket_exploded:type_name -> rockets.v1.RocketExploded
+	15, // 19: rockets.v1.IngestTelemetryRequest.rocket_mission_changed:type_name -> rockets.v1.RocketMissionChanged
+	16, // 20: rockets.v1.IngestTelemetryRequest.rocket_fuel_updated:type_name -> rockets.v1.RocketFuelUpdated
+	19, // 21: rockets.v1.IngestTelemetryRequest.rocket_position_updated:type_name -> rockets.v1.RocketPositionUpdated
+	17, // 22: rockets.v1.IngestTelemetryRequest.rocket_stage_separated:type_name -> rockets.v1.RocketStageSeparated
+	18, // 23: rockets.v1.IngestTelemetryRequest.rocket_payload_deployed:type_name -> rockets.v1.RocketPayloadDeployed
+	13, // 24: rockets.v1.IngestTelemetryRequest.rocket_landed:type_name -> rockets.v1.RocketLanded
+	14, // 25: rockets.v1.IngestTelemetryRequest.rocket_decommissioned:type_name -> rockets.v1.RocketDecommissioned
+	21, // 26: rockets.v1.IngestTelemetryResponse.errors:type_name -> rockets.v1.RejectedMessage
+	5,  // 27: rockets.v1.RocketService.GetRocket:input_type -> rockets.v1.GetRocketRequest
+	7,  // 28: rockets.v1.RocketService.ListRockets:input_type -> rockets.v1.ListRocketsRequest
+	20, // 29: rockets.v1.RocketService.IngestTelemetry:input_type -> rockets.v1.IngestTelemetryRequest
+	6,  // 30: rockets.v1.RocketService.GetRocket:output_type -> rockets.v1.GetRocketResponse
+	8,  // 31: rockets.v1.RocketService.ListRockets:output_type -> rockets.v1.ListRocketsResponse
+	22, // 32: rockets.v1.RocketService.IngestTelemetry:output_type -> rockets.v1.IngestTelemetryResponse
+	30, // [30:33] is the sub-list for method output_type
+	27, // [27:30] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
+}
+
+func init() { file_rockets_v1_rockets_proto_init() }
+func file_rockets_v1_rockets_proto_init() {
+	if File_rockets_v1_rockets_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rockets_v1_rockets_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Rocket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Anomaly); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*Payload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*StageSeparation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*Position); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRocketRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*GetRocketResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*ListRocketsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*ListRocketsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*MessageMetadata); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketLaunched); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketSpeedChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketExploded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketLanded); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketDecommissioned); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketMissionChanged); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketFuelUpdated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketStageSeparated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketPayloadDeployed); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*RocketPositionUpdated); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*IngestTelemetryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*RejectedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rockets_v1_rockets_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*IngestTelemetryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_rockets_v1_rockets_proto_msgTypes[0].OneofWrappers = []any{}
+	file_rockets_v1_rockets_proto_msgTypes[16].OneofWrappers = []any{}
+	file_rockets_v1_rockets_proto_msgTypes[19].OneofWrappers = []any{}
+	file_rockets_v1_rockets_proto_msgTypes[20].OneofWrappers = []any{
+		(*IngestTelemetryRequest_RocketLaunched)(nil),
+		(*IngestTelemetryRequest_RocketSpeedIncreased)(nil),
+		(*IngestTelemetryRequest_RocketSpeedDecreased)(nil),
+		(*IngestTelemetryRequest_RocketExploded)(nil),
+		(*IngestTelemetryRequest_RocketMissionChanged)(nil),
+		(*IngestTelemetryRequest_RocketFuelUpdated)(nil),
+		(*IngestTelemetryRequest_RocketPositionUpdated)(nil),
+		(*IngestTelemetryRequest_RocketStageSeparated)(nil),
+		(*IngestTelemetryRequest_RocketPayloadDeployed)(nil),
+		(*IngestTelemetryRequest_RocketLanded)(nil),
+		(*IngestTelemetryRequest_RocketDecommissioned)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rockets_v1_rockets_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rockets_v1_rockets_proto_goTypes,
+		DependencyIndexes: file_rockets_v1_rockets_proto_depIdxs,
+		MessageInfos:      file_rockets_v1_rockets_proto_msgTypes,
+	}.Build()
+	File_rockets_v1_rockets_proto = out.File
+	file_rockets_v1_rockets_proto_rawDesc = nil
+	file_rockets_v1_rockets_proto_goTypes = nil
+	file_rockets_v1_rockets_proto_depIdxs = nil
+}
@@ -0,0 +1,203 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rockets/v1/rockets.proto
+
+package rocketsv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	RocketService_GetRocket_FullMethodName       = "/rockets.v1.RocketService/GetRocket"
+	RocketService_ListRockets_FullMethodName     = "/rockets.v1.RocketService/ListRockets"
+	RocketService_IngestTelemetry_FullMethodName = "/rockets.v1.RocketService/IngestTelemetry"
+)
+
+// RocketServiceClient is the client API for RocketService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// RocketService exposes rocket state and telemetry ingestion over gRPC.
+// It is backed by the same services and processing pipeline as the REST API.
+type RocketServiceClient interface {
+	// GetRocket returns the current state of a rocket.
+	GetRocket(ctx context.Context, in *GetRocketRequest, opts ...grpc.CallOption) (*GetRocketResponse, error)
+	// ListRockets returns the rockets matching the request filters.
+	ListRockets(ctx context.Context, in *ListRocketsRequest, opts ...grpc.CallOption) (*ListRocketsResponse, error)
+	// IngestTelemetry accepts a stream of telemetry messages and reports a summary once the client closes the stream.
+	IngestTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestTelemetryRequest, IngestTelemetryResponse], error)
+}
+
+type rocketServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRocketServiceClient(cc grpc.ClientConnInterface) RocketServiceClient {
+	return &rocketServiceClient{cc}
+}
+
+func (c *rocketServiceClient) GetRocket(ctx context.Context, in *GetRocketRequest, opts ...grpc.CallOption) (*GetRocketResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRocketResponse)
+	err := c.cc.Invoke(ctx, RocketService_GetRocket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rocketServiceClient) ListRockets(ctx context.Context, in *ListRocketsRequest, opts ...grpc.CallOption) (*ListRocketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRocketsResponse)
+	err := c.cc.Invoke(ctx, RocketService_ListRockets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rocketServiceClient) IngestTelemetry(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[IngestTelemetryRequest, IngestTelemetryResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RocketService_ServiceDesc.Streams[0], RocketService_IngestTelemetry_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[IngestTelemetryRequest, IngestTelemetryResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RocketService_IngestTelemetryClient = grpc.ClientStreamingClient[IngestTelemetryRequest, IngestTelemetryResponse]
+
+// RocketServiceServer is the server API for RocketService service.
+// All implementations must embed UnimplementedRocketServiceServer
+// for forward compatibility.
+//
+// RocketService exposes rocket state and telemetry ingestion over gRPC.
+// It is backed by the same services and processing pipeline as the REST API.
+type RocketServiceServer interface {
+	// GetRocket returns the current state of a rocket.
+	GetRocket(context.Context, *GetRocketRequest) (*GetRocketResponse, error)
+	// ListRockets returns the rockets matching the request filters.
+	ListRockets(context.Context, *ListRocketsRequest) (*ListRocketsResponse, error)
+	// IngestTelemetry accepts a stream of telemetry messages and reports a summary once the client closes the stream.
+	IngestTelemetry(grpc.ClientStreamingServer[IngestTelemetryRequest, IngestTelemetryResponse]) error
+	mustEmbedUnimplementedRocketServiceServer()
+}
+
+// UnimplementedRocketServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRocketServiceServer struct{}
+
+func (UnimplementedRocketServiceServer) GetRocket(context.Context, *GetRocketRequest) (*GetRocketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRocket not implemented")
+}
+func (UnimplementedRocketServiceServer) ListRockets(context.Context, *ListRocketsRequest) (*ListRocketsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRockets not implemented")
+}
+func (UnimplementedRocketServiceServer) IngestTelemetry(grpc.ClientStreamingServer[IngestTelemetryRequest, IngestTelemetryResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method IngestTelemetry not implemented")
+}
+func (UnimplementedRocketServiceServer) mustEmbedUnimplementedRocketServiceServer() {}
+func (UnimplementedRocketServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeRocketServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RocketServiceServer will
+// result in compilation errors.
+type UnsafeRocketServiceServer interface {
+	mustEmbedUnimplementedRocketServiceServer()
+}
+
+func RegisterRocketServiceServer(s grpc.ServiceRegistrar, srv RocketServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRocketServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RocketService_ServiceDesc, srv)
+}
+
+func _RocketService_GetRocket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRocketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RocketServiceServer).GetRocket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RocketService_GetRocket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RocketServiceServer).GetRocket(ctx, req.(*GetRocketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RocketService_ListRockets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRocketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RocketServiceServer).ListRockets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RocketService_ListRockets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RocketServiceServer).ListRockets(ctx, req.(*ListRocketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RocketService_IngestTelemetry_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RocketServiceServer).IngestTelemetry(&grpc.GenericServerStream[IngestTelemetryRequest, IngestTelemetryResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RocketService_IngestTelemetryServer = grpc.ClientStreamingServer[IngestTelemetryRequest, IngestTelemetryResponse]
+
+// RocketService_ServiceDesc is the grpc.ServiceDesc for RocketService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RocketService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rockets.v1.RocketService",
+	HandlerType: (*RocketServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRocket",
+			Handler:    _RocketService_GetRocket_Handler,
+		},
+		{
+			MethodName: "ListRockets",
+			Handler:    _RocketService_ListRockets_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "IngestTelemetry",
+			Handler:       _RocketService_IngestTelemetry_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "rockets/v1/rockets.proto",
+}
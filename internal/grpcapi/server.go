@@ -0,0 +1,180 @@
+// Package grpcapi exposes the rocket services over gRPC, alongside the REST API
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/filter"
+	"github.com/ahernandez9/rockets/internal/grpcapi/rocketsv1"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/ahernandez9/rockets/internal/validation"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxIngestErrors caps the number of rejected messages detailed in an ingestion summary
+const maxIngestErrors = 100
+
+// rocketServer implements rocketsv1.RocketServiceServer on top of the rocket and message services
+type rocketServer struct {
+	rocketsv1.UnimplementedRocketServiceServer
+
+	messageService service.MessageService
+	rocketService  service.RocketService
+}
+
+// NewServer creates a gRPC server exposing the rocket service, guarding its calls with opts
+func NewServer(
+	messageService service.MessageService,
+	rocketService service.RocketService,
+	opts Options,
+	serverOpts ...grpc.ServerOption,
+) *grpc.Server {
+	guard := newGuard(opts)
+	serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(guard.unary), grpc.ChainStreamInterceptor(guard.stream))
+	server := grpc.NewServer(serverOpts...)
+	rocketsv1.RegisterRocketServiceServer(server, &rocketServer{
+		messageService: messageService,
+		rocketService:  rocketService,
+	})
+	return server
+}
+
+// Shutdown stops accepting calls and waits for the calls in flight until ctx is done, then cancels those left, such
+// as telemetry streams
+func Shutdown(ctx context.Context, server *grpc.Server) error {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		<-stopped
+		return ctx.Err()
+	}
+}
+
+// GetRocket returns the current state of a rocket
+func (s *rocketServer) GetRocket(ctx context.Context, req *rocketsv1.GetRocketRequest) (*rocketsv1.GetRocketResponse, error) {
+	if _, err := uuid.Parse(req.GetId()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "the rocket ID must be a valid UUID")
+	}
+
+	rocket, err := s.rocketService.GetRocket(ctx, req.GetId())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, status.Error(codes.NotFound, "no rocket exists with the provided ID")
+		}
+		return nil, status.Error(codes.Internal, "failed to retrieve rocket")
+	}
+
+	return &rocketsv1.GetRocketResponse{Rocket: toProtoRocket(rocket)}, nil
+}
+
+// ListRockets returns the rockets matching the request filters
+func (s *rocketServer) ListRockets(ctx context.Context, req *rocketsv1.ListRocketsRequest) (*rocketsv1.ListRocketsResponse, error) {
+	sortBy := req.GetSort()
+	if sortBy == "" {
+		sortBy = "id"
+	}
+
+	sortFields, err := service.ParseSort(sortBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if req.GetStatus() != "" {
+		if _, ok := models.ParseStatus(req.GetStatus()); !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unknown status %q", req.GetStatus())
+		}
+	}
+
+	predicates, err := filter.Parse(req.GetFilter())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	labels, err := filter.ParseLabels(req.GetLabels())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	rockets, err := s.rocketService.ListRockets(ctx, models.RocketFilter{
+		Status:          req.GetStatus(),
+		Mission:         req.GetMission(),
+		Type:            req.GetType(),
+		Query:           strings.TrimSpace(req.GetQuery()),
+		LowFuel:         req.GetLowFuel(),
+		Labels:          labels,
+		Predicates:      predicates,
+		IncludeArchived: req.GetIncludeArchived(),
+	}, sortFields)
+	if err != nil {
+		return nil, status.Error(codes.Internal, "failed to retrieve rockets")
+	}
+
+	resp := &rocketsv1.ListRocketsResponse{Rockets: make([]*rocketsv1.Rocket, 0, len(rockets))}
+	for _, rocket := range rockets {
+		resp.Rockets = append(resp.Rockets, toProtoRocket(rocket))
+	}
+
+	return resp, nil
+}
+
+// IngestTelemetry validates and publishes every message of the client stream, then reports a summary.
+// Invalid messages are reported and skipped without aborting the stream
+func (s *rocketServer) IngestTelemetry(stream grpc.ClientStreamingServer[rocketsv1.IngestTelemetryRequest, rocketsv1.IngestTelemetryResponse]) error {
+	resp := &rocketsv1.IngestTelemetryResponse{}
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return stream.SendAndClose(resp)
+		}
+		if err != nil {
+			return err
+		}
+
+		resp.Total++
+		if err := s.publish(stream.Context(), req); err != nil {
+			resp.Rejected++
+			if len(resp.Errors) < maxIngestErrors {
+				resp.Errors = append(resp.Errors, &rocketsv1.RejectedMessage{Index: resp.Total, Error: err.Error()})
+			}
+			continue
+		}
+		resp.Accepted++
+	}
+}
+
+// publish converts, validates and publishes a single streamed message
+func (s *rocketServer) publish(ctx context.Context, req *rocketsv1.IngestTelemetryRequest) error {
+	msg, err := fromProtoMessage(req)
+	if err != nil {
+		return err
+	}
+
+	if err := validation.ValidateMessage(msg); err != nil {
+		s.messageService.RecordRejected(msg, err)
+		return err
+	}
+
+	if err := s.messageService.PublishMessage(ctx, msg); err != nil {
+		return fmt.Errorf("failed to queue message: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package grpcapi
+
+import (
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/grpcapi/rocketsv1"
+	"github.com/ahernandez9/rockets/internal/service/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetRocketInvalidID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := newTestClient(t, nil, mocks.NewMockRocketService(ctrl), Options{})
+
+	_, err := client.GetRocket(t.Context(), &rocketsv1.GetRocketRequest{Id: "not-a-uuid"})
+
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, status.Convert(err).Message(), "valid UUID")
+}
+
+func TestListRocketsInvalidRequest(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             *rocketsv1.ListRocketsRequest
+		expectedMessage string
+	}{
+		{name: "unknown sort field", req: &rocketsv1.ListRocketsRequest{Sort: "altitude"}, expectedMessage: "altitude"},
+		{name: "unknown status", req: &rocketsv1.ListRocketsRequest{Status: "FLYING"}, expectedMessage: "FLYING"},
+		{name: "malformed filter", req: &rocketsv1.ListRocketsRequest{Filter: "speed>>1000"}, expectedMessage: "speed"},
+		{name: "malformed label", req: &rocketsv1.ListRocketsRequest{Labels: []string{"team"}}, expectedMessage: "team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			// The rockets are not listed, which the mock checks
+			client := newTestClient(t, nil, mocks.NewMockRocketService(ctrl), Options{})
+
+			_, err := client.ListRockets(t.Context(), tt.req)
+
+			assert.Equal(t, codes.InvalidArgument, status.Code(err))
+			assert.Contains(t, status.Convert(err).Message(), tt.expectedMessage)
+		})
+	}
+}
+
+func TestIngestTelemetrySummary(t *testing.T) {
+	invalid := func(number int64) *rocketsv1.IngestTelemetryRequest {
+		req := launch(number)
+		req.Metadata.Channel = "not-a-uuid"
+		return req
+	}
+
+	tests := []struct {
+		name             string
+		messages         []*rocketsv1.IngestTelemetryRequest
+		expectedAccepted int64
+		expectedRejected int64
+		expectedErrors   int
+		expectedIndex    int64 // Index of the first rejected message, counted from 1
+	}{
+		{
+			name:             "rejected message is reported by index",
+			messages:         []*rocketsv1.IngestTelemetryRequest{launch(1), invalid(2), launch(3)},
+			expectedAccepted: 2,
+			expectedRejected: 1,
+			expectedErrors:   1,
+			expectedIndex:    2,
+		},
+		{
+			name: "reported errors are capped",
+			messages: func() []*rocketsv1.IngestTelemetryRequest {
+				var messages []*rocketsv1.IngestTelemetryRequest
+				for i := range maxIngestErrors + 20 {
+					messages = append(messages, invalid(int64(i+1)))
+				}
+				return messages
+			}(),
+			expectedRejected: maxIngestErrors + 20,
+			expectedErrors:   maxIngestErrors,
+			expectedIndex:    1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			ms := mocks.NewMockMessageService(ctrl)
+			ms.EXPECT().PublishMessage(gomock.Any(), gomock.Any()).Return(nil).Times(int(tt.expectedAccepted))
+			ms.EXPECT().RecordRejected(gomock.Any(), gomock.Any()).Times(int(tt.expectedRejected))
+			client := newTestClient(t, ms, mocks.NewMockRocketService(ctrl), Options{})
+
+			stream, err := client.IngestTelemetry(t.Context())
+			require.NoError(t, err)
+			for _, msg := range tt.messages {
+				require.NoError(t, stream.Send(msg))
+			}
+			resp, err := stream.CloseAndRecv()
+			require.NoError(t, err)
+
+			assert.Equal(t, int64(len(tt.messages)), resp.GetTotal())
+			assert.Equal(t, tt.expectedAccepted, resp.GetAccepted())
+			assert.Equal(t, tt.expectedRejected, resp.GetRejected())
+			require.Len(t, resp.GetErrors(), tt.expectedErrors)
+			assert.Equal(t, tt.expectedIndex, resp.GetErrors()[0].GetIndex())
+			assert.Contains(t, resp.GetErrors()[0].GetError(), "channel")
+		})
+	}
+}
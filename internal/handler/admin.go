@@ -0,0 +1,475 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/audit"
+	"github.com/ahernandez9/rockets/internal/bufpool"
+	"github.com/ahernandez9/rockets/internal/feed"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/notify"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
+	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/ahernandez9/rockets/internal/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	// exportFlushEvery is the number of records written between flushes of a streamed export
+	exportFlushEvery = 100
+
+	// maxImportLineSize bounds the size of a single record of an imported dump
+	maxImportLineSize = 1 << 20
+
+	// ndjson is the media type of JSON Lines dumps and streamed results
+	ndjson = "application/x-ndjson"
+
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// ExportState godoc
+// @Summary Export all rockets
+// @Description Streams a JSON Lines dump of every rocket (archived included) in ID order, each optionally followed by
+// @Description its events. The dump can be re-imported and serves as a portable backup, or hands the state off to
+// @Description another instance. An interrupted export is resumed with from set to the last rocket received.
+// @Description Also served at /admin/export. Requires the admin token.
+// @Tags admin
+// @Produce application/x-ndjson
+// @Security AdminToken
+// @Param events query bool false "Include rocket events" default(false)
+// @Param from query string false "Resume at the rocket with this ID"
+// @Success 200 {object} models.BackupRecord "One record per line"
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Router /admin/state/export [get]
+func ExportState(bs service.BackupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		includeEvents, err := strconv.ParseBool(c.DefaultQuery("events", "false"))
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid events parameter",
+				"The events parameter must be a boolean (true or false)")
+			return
+		}
+		opts := service.ExportOptions{IncludeEvents: includeEvents, From: c.Query("from")}
+
+		c.Header("Content-Type", ndjson)
+		c.Header("Content-Disposition", `attachment; filename="rockets-export.jsonl"`)
+		c.Status(http.StatusOK)
+
+		encoder := json.NewEncoder(c.Writer)
+		written := 0
+
+		err = bs.Export(c.Request.Context(), opts, func(record *models.BackupRecord) error {
+			if err := encoder.Encode(record); err != nil {
+				return err
+			}
+
+			written++
+			if written%exportFlushEvery == 0 {
+				c.Writer.Flush()
+			}
+			return nil
+		})
+		if err != nil {
+			// Headers are already sent, the truncated dump is the only signal left to the client
+			slog.ErrorContext(c.Request.Context(), "Export interrupted", "records", written, "error", err)
+		}
+
+		c.Writer.Flush()
+	}
+}
+
+// ImportState godoc
+// @Summary Import rockets
+// @Description Upserts the records of a JSON Lines dump produced by the export endpoint, reporting a result per line.
+// @Description Invalid records are reported and skipped; the rest of the dump is still imported. Importing is
+// @Description idempotent, so an interrupted import is resumed by importing the dump again from the last rocket
+// @Description acknowledged. With Accept: application/x-ndjson, the result of each line is streamed as soon as it is
+// @Description imported instead of the summary. keepNewer skips rockets stored with later messages, to catch up with
+// @Description an instance still processing telemetry. Also served at /admin/import. Requires the admin token.
+// @Tags admin
+// @Accept application/x-ndjson
+// @Produce json,application/x-ndjson
+// @Security AdminToken
+// @Param dump body models.BackupRecord true "One record per line"
+// @Param keepNewer query bool false "Skip rockets stored with later messages" default(false)
+// @Success 200 {object} models.ImportResponse
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Router /admin/state/import [post]
+func ImportState(bs service.BackupService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keepNewer, err := strconv.ParseBool(c.DefaultQuery("keepNewer", "false"))
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid keepNewer parameter",
+				"The keepNewer parameter must be a boolean (true or false)")
+			return
+		}
+		opts := service.ImportOptions{KeepNewer: keepNewer}
+
+		scanner, release := bufpool.NewScanner(c.Request.Body, maxImportLineSize)
+		defer release()
+
+		// Streamed results tell how far an interrupted import went
+		var stream *json.Encoder
+		middleware.AddVary(c, "Accept")
+		if c.NegotiateFormat(binding.MIMEJSON, ndjson) == ndjson {
+			c.Header("Content-Type", ndjson)
+			c.Status(http.StatusOK)
+			stream = json.NewEncoder(c.Writer)
+		}
+
+		resp := models.ImportResponse{Results: []models.ImportResult{}}
+		line := 0
+
+		for scanner.Scan() {
+			line++
+			raw := bytes.TrimSpace(scanner.Bytes())
+			if len(raw) == 0 {
+				continue
+			}
+
+			result := importRecord(c, bs, raw, opts)
+			result.Line = line
+			if stream != nil {
+				if err := stream.Encode(result); err != nil {
+					slog.ErrorContext(c.Request.Context(), "Import interrupted", "line", line, "error", err)
+					return
+				}
+				c.Writer.Flush()
+				continue
+			}
+
+			resp.Total++
+			switch result.Status {
+			case models.ImportStatusImported:
+				resp.Imported++
+			case models.ImportStatusSkipped:
+				resp.Skipped++
+			default:
+				resp.Failed++
+			}
+			resp.Results = append(resp.Results, result)
+		}
+
+		if err := scanner.Err(); err != nil {
+			if stream != nil {
+				// Headers are already sent, the results stop at the last line imported
+				slog.ErrorContext(c.Request.Context(), "Import interrupted", "line", line, "error", err)
+				return
+			}
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid import body",
+				"The dump could not be read after line "+strconv.Itoa(line)+": "+err.Error())
+			return
+		}
+
+		if stream == nil {
+			c.JSON(http.StatusOK, resp)
+		}
+	}
+}
+
+// importRecord decodes, validates and imports a single line of a dump
+func importRecord(c *gin.Context, bs service.BackupService, raw []byte, opts service.ImportOptions) models.ImportResult {
+	var record models.BackupRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return models.ImportResult{Status: models.ImportStatusFailed, Error: "invalid JSON: " + err.Error()}
+	}
+
+	result := models.ImportResult{Kind: record.Kind}
+	switch {
+	case record.Rocket != nil:
+		result.ID = record.Rocket.ID
+	case record.Event != nil:
+		result.ID = record.Event.Channel
+	}
+
+	if err := validation.ValidateBackupRecord(&record); err != nil {
+		result.Status = models.ImportStatusFailed
+		result.Error = err.Error()
+		return result
+	}
+
+	imported, err := bs.Import(c.Request.Context(), &record, opts)
+	switch {
+	case err != nil:
+		result.Status = models.ImportStatusFailed
+		result.Error = err.Error()
+	case imported:
+		result.Status = models.ImportStatusImported
+	default:
+		result.Status = models.ImportStatusSkipped
+	}
+
+	return result
+}
+
+// ResetState godoc
+// @Summary Reset state
+// @Description Removes every rocket, archived ones included, and the whole event history. Meant for test environments.
+// @Description Requires the admin token.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} models.ResetResponse
+// @Failure 401 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /admin/reset [post]
+func ResetState(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rockets, events, err := rs.Reset(c.Request.Context())
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to reset state",
+				fmt.Sprintf("The reset stopped after removing %d rockets. Please try again.", rockets))
+			return
+		}
+
+		slog.InfoContext(c.Request.Context(), "Admin reset", "actor", c.GetString(middleware.ActorKey),
+			"rocketsDeleted", rockets, "eventsDeleted", events)
+
+		c.JSON(http.StatusOK, models.ResetResponse{
+			RocketsDeleted: rockets,
+			EventsDeleted:  events,
+		})
+	}
+}
+
+// PurgeRocket godoc
+// @Summary Purge rocket
+// @Description Removes a rocket together with its recorded history, unlike DELETE /rockets/{id} which keeps the events.
+// @Description Requires the admin token.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Rocket ID (UUID)"
+// @Success 200 {object} models.PurgeResponse
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /admin/rockets/{id}/purge [post]
+func PurgeRocket(rs service.RocketService, ms service.MessageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		events, err := rs.PurgeRocket(c.Request.Context(), id)
+		if err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+		ms.ForgetChannel(id)
+
+		c.JSON(http.StatusOK, models.PurgeResponse{
+			ID:            id,
+			EventsDeleted: events,
+		})
+	}
+}
+
+// EraseChannelData godoc
+// @Summary Erase channel data
+// @Description Removes everything stored about a channel, to honor data-removal requests: its rocket, events and
+// @Description position trail, its membership in fleets, its processing counters, the changes live streams resume
+// @Description from and its notification deliveries. Erasing a channel without data succeeds with an empty
+// @Description report. Requires the admin token.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Channel ID (UUID)"
+// @Success 200 {object} models.ErasureReport
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Router /admin/channels/{id}/data [delete]
+func EraseChannelData(
+	rs service.RocketService,
+	fs service.FleetService,
+	ms service.MessageService,
+	changes *feed.Feed,
+	notifications *notify.Dispatcher,
+) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		report, err := rs.EraseChannel(c.Request.Context(), id)
+		if err == nil {
+			report.FleetMembershipsRemoved, err = fs.RemoveMember(c.Request.Context(), id)
+		}
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to erase channel data",
+				"An error occurred while erasing the channel data. Please try again later.")
+			return
+		}
+		ms.ForgetChannel(id)
+		// Forgotten last, as erasing the rocket publishes its deletion
+		report.ChangesDeleted = changes.Forget(id)
+		report.DeliveriesDeleted = notifications.Forget(id)
+
+		// The erasure itself is kept in the logs, as evidence of the removal
+		slog.InfoContext(c.Request.Context(), "Erased channel data", "channel", id,
+			"actor", c.GetString(middleware.ActorKey), "rocketDeleted", report.RocketDeleted,
+			"eventsDeleted", report.EventsDeleted, "trackPointsDeleted", report.TrackPointsDeleted,
+			"fleetMembershipsRemoved", report.FleetMembershipsRemoved, "changesDeleted", report.ChangesDeleted,
+			"deliveriesDeleted", report.DeliveriesDeleted)
+
+		c.JSON(http.StatusOK, report)
+	}
+}
+
+// ListNotificationDeliveries godoc
+// @Summary List notification deliveries
+// @Description Lists the most recent deliveries of notifications (e.g. rocket explosions) to the configured sinks,
+// @Description most recent first, with their outcome and number of attempts. Requires the admin token.
+// @Tags admin
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Success 200 {object} models.DeliveryListResponse
+// @Failure 401 {object} models.Problem
+// @Router /admin/notifications [get]
+func ListNotificationDeliveries(notifications *notify.Dispatcher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		deliveries := notifications.Deliveries()
+
+		respond(c, http.StatusOK, models.DeliveryListResponse{
+			Count:      len(deliveries),
+			Deliveries: deliveries,
+		})
+	}
+}
+
+// ListAuditEntries godoc
+// @Summary List audit entries
+// @Description Lists the recorded operations that may have changed the service state (message posts, admin actions,
+// @Description manual corrections), most recent first, with the caller, the response status and a SHA-256 digest of
+// @Description the payload. Only the most recent entries are kept in memory; every entry is also logged.
+// @Description Requires the admin token.
+// @Tags admin
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param actor query string false "Only entries of this actor"
+// @Param since query string false "Only entries recorded at or after this time (RFC 3339)"
+// @Param limit query int false "Maximum number of entries" default(100) maximum(1000)
+// @Success 200 {object} models.AuditListResponse
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Router /admin/audit [get]
+func ListAuditEntries(log *audit.Log) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		query := audit.Query{Actor: c.Query("actor")}
+
+		if since := c.Query("since"); since != "" {
+			t, err := time.Parse(time.RFC3339, since)
+			if err != nil {
+				problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid since parameter",
+					"since must be an RFC 3339 time, e.g. 2022-02-02T19:39:05Z")
+				return
+			}
+			query.Since = t
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultAuditLimit)))
+		if err != nil || limit < 1 || limit > maxAuditLimit {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid limit parameter",
+				fmt.Sprintf("limit must be an integer between 1 and %d", maxAuditLimit))
+			return
+		}
+		query.Limit = limit
+
+		entries := log.Entries(query)
+
+		respond(c, http.StatusOK, models.AuditListResponse{
+			Count:   len(entries),
+			Entries: entries,
+		})
+	}
+}
+
+// ListKeyUsage godoc
+// @Summary List API key usage
+// @Description Reports the requests of every API caller since the service started: in the current minute and UTC
+// @Description day, which count against their rate limit and daily quota, in total, and denied for exceeding them.
+// @Description Requires the admin token.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} models.KeyUsageResponse
+// @Failure 401 {object} models.Problem
+// @Router /admin/keys/usage [get]
+func ListKeyUsage(quotas *ratelimit.Quotas) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		usage := quotas.Usage(time.Now())
+		c.JSON(http.StatusOK, models.KeyUsageResponse{Count: len(usage), Keys: usage})
+	}
+}
+
+// GetLogLevel godoc
+// @Summary Get log level
+// @Description Returns the minimum level of the service logs.
+// @Description Requires the admin token.
+// @Tags admin
+// @Produce json
+// @Security AdminToken
+// @Success 200 {object} models.LogLevel
+// @Failure 401 {object} models.Problem
+// @Router /admin/loglevel [get]
+func GetLogLevel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, models.LogLevel{Level: strings.ToLower(logging.Level().String())})
+	}
+}
+
+// PutLogLevel godoc
+// @Summary Change log level
+// @Description Changes the minimum level of the service logs at runtime, e.g. to enable debug logs during an incident
+// @Description without restarting and losing the in-memory state. The change is lost on restart.
+// @Description Requires the admin token.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body models.LogLevel true "New log level"
+// @Success 200 {object} models.LogLevel
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Router /admin/loglevel [put]
+func PutLogLevel() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.LogLevel
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be a JSON object with a 'level' field")
+			return
+		}
+
+		level, err := logging.ParseLevel(req.Level)
+		if err != nil {
+			problem.Respond(c, http.StatusUnprocessableEntity, models.ErrorCodeInvalidRequestBody, "Invalid log level",
+				err.Error())
+			return
+		}
+
+		logging.SetLevel(level)
+		slog.WarnContext(c.Request.Context(), "Log level changed", "level", level,
+			"actor", c.GetString(middleware.ActorKey))
+
+		c.JSON(http.StatusOK, models.LogLevel{Level: strings.ToLower(level.String())})
+	}
+}
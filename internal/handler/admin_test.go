@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/ahernandez9/rockets/internal/service/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestImportState(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	validRocket := `{"kind":"rocket","rocket":{"id":"193270a9-c9cf-404a-8f83-838e71d9ae67",` +
+		`"type":"Falcon-9","speed":500,"mission":"ARTEMIS","status":"active"}}`
+	validEvent := `{"kind":"event","event":{"channel":"193270a9-c9cf-404a-8f83-838e71d9ae67","type":"RocketLaunched"}}`
+
+	tests := []struct {
+		name           string
+		body           string
+		mockSetup      func(*mocks.MockBackupService)
+		expectedStatus int
+		expectedFile   string
+	}{
+		{
+			name: "mixed valid, duplicate and invalid records",
+			body: strings.Join([]string{
+				validRocket,
+				"",
+				validEvent,
+				`{"kind":"rocket","rocket":{"id":"not-a-uuid"}}`,
+				`{"kind":"satellite"}`,
+				`not json`,
+			}, "\n"),
+			mockSetup: func(m *mocks.MockBackupService) {
+				gomock.InOrder(
+					m.EXPECT().
+						Import(gomock.Any(), gomock.Cond(func(r *models.BackupRecord) bool {
+							return r.Kind == models.RecordKindRocket && r.Rocket.Status == models.StatusActive
+						}), service.ImportOptions{}).
+						Return(true, nil),
+					m.EXPECT().
+						Import(gomock.Any(), gomock.Cond(func(r *models.BackupRecord) bool {
+							return r.Kind == models.RecordKindEvent
+						}), service.ImportOptions{}).
+						Return(false, nil),
+				)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "import_mixed.json",
+		},
+		{
+			name:           "empty body",
+			body:           "",
+			mockSetup:      func(m *mocks.MockBackupService) {},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "import_empty.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockBackupService(ctrl)
+
+			tt.mockSetup(mockService)
+
+			router := gin.New()
+			router.POST("/admin/import", ImportState(mockService))
+
+			req, err := http.NewRequestWithContext(
+				context.Background(),
+				http.MethodPost,
+				"/admin/import",
+				strings.NewReader(tt.body),
+			)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+
+			expectedJSON, err := expectedFiles.ReadFile("testdata/admin/" + tt.expectedFile)
+			assert.NoError(t, err, "failed to read file: %s", tt.expectedFile)
+			assert.JSONEq(t, string(expectedJSON), w.Body.String(), "response body mismatch")
+		})
+	}
+}
+
+func TestImportStateStreamsResults(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	mockService := mocks.NewMockBackupService(ctrl)
+	mockService.EXPECT().Import(gomock.Any(), gomock.Any(), service.ImportOptions{KeepNewer: true}).Return(false, nil)
+
+	router := gin.New()
+	router.POST("/admin/state/import", ImportState(mockService))
+
+	body := `{"kind":"rocket","rocket":{"id":"193270a9-c9cf-404a-8f83-838e71d9ae67",` +
+		`"type":"Falcon-9","speed":500,"mission":"ARTEMIS","status":"active"}}` + "\nnot json\n"
+	req := httptest.NewRequest(http.MethodPost, "/admin/state/import?keepNewer=true", strings.NewReader(body))
+	req.Header.Set("Accept", "application/x-ndjson")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.JSONEq(t, `{"line":1,"kind":"rocket","id":"193270a9-c9cf-404a-8f83-838e71d9ae67","status":"skipped"}`, lines[0])
+		assert.Contains(t, lines[1], `"status":"failed"`)
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// rocketETag identifies a representation of a version of a rocket. The version changes with every stored change,
+// from telemetry or from an operator. Representations other than the full rocket in JSON are told apart by a hash of
+// their format, speed unit and fields
+func rocketETag(rocket *models.Rocket, view rocketView, format string) string {
+	if format == binding.MIMEJSON && view.unit == "" && len(view.fields) == 0 {
+		return fmt.Sprintf(`"%s-%d"`, rocket.ID, rocket.Version)
+	}
+
+	representation := fnv.New32a()
+	fmt.Fprintf(representation, "%s;%s;%s", format, view.unit, strings.Join(view.fields, ","))
+	return fmt.Sprintf(`"%s-%d-%08x"`, rocket.ID, rocket.Version, representation.Sum32())
+}
+
+// etagMatches reports whether an If-None-Match header matches the given ETag, using the weak comparison of RFC 9110
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// notModifiedSince sets the Last-Modified header and reports whether the request's If-Modified-Since
+// shows the client already has this version. If-Modified-Since is ignored when If-None-Match is present
+func notModifiedSince(c *gin.Context, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+	c.Header("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+
+	if c.GetHeader("If-None-Match") != "" {
+		return false
+	}
+
+	since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+
+	// HTTP dates have a one second resolution
+	return !lastModified.Truncate(time.Second).After(since)
+}
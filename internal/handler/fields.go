@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	rocketType = reflect.TypeOf(models.Rocket{})
+
+	// rocketFieldIndex maps the JSON name of every rocket field to its struct field index
+	rocketFieldIndex = jsonFieldIndex(rocketType)
+)
+
+// jsonFieldIndex indexes the exported fields of a struct type by their JSON name
+func jsonFieldIndex(t reflect.Type) map[string]int {
+	index := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			index[name] = i
+		}
+	}
+	return index
+}
+
+// rocketFields is a sparse fieldset, the subset of rocket fields requested with ?fields=
+type rocketFields []string
+
+// rocketFieldsParam parses the fields query parameter, responding with 400 when it names an unknown field.
+// An empty fieldset selects every field
+func rocketFieldsParam(c *gin.Context) (rocketFields, bool) {
+	param := c.Query("fields")
+	if param == "" {
+		return nil, true
+	}
+
+	var fields rocketFields
+	for _, name := range strings.Split(param, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := rocketFieldIndex[name]; !ok {
+			known := make([]string, 0, len(rocketFieldIndex))
+			for field := range rocketFieldIndex {
+				known = append(known, field)
+			}
+			slices.Sort(known)
+
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid fields parameter",
+				fmt.Sprintf("fields must be a comma-separated list of: %s", strings.Join(known, ", ")))
+			return nil, false
+		}
+		if !slices.Contains(fields, name) {
+			fields = append(fields, name)
+		}
+	}
+
+	return fields, true
+}
+
+// project returns the rocket restricted to the fieldset, or the rocket itself when every field is selected
+func (f rocketFields) project(rocket *models.Rocket) any {
+	if len(f) == 0 {
+		return rocket
+	}
+
+	value := reflect.ValueOf(rocket).Elem()
+	sparse := make(sparseRocket, len(f))
+	for _, name := range f {
+		sparse[name] = value.Field(rocketFieldIndex[name]).Interface()
+	}
+	return sparse
+}
+
+// projectAll applies the fieldset to a list of rockets
+func (f rocketFields) projectAll(rockets []*models.Rocket) any {
+	if len(f) == 0 {
+		return rockets
+	}
+
+	sparse := make([]any, 0, len(rockets))
+	for _, rocket := range rockets {
+		sparse = append(sparse, f.project(rocket))
+	}
+	return sparse
+}
+
+// sparseRocket holds the selected fields of a rocket, keyed by JSON name
+type sparseRocket map[string]any
+
+// MarshalXML encodes the selected fields as a rocket element, in the field order of models.Rocket
+func (r sparseRocket) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	slices.SortFunc(names, func(a, b string) int { return rocketFieldIndex[a] - rocketFieldIndex[b] })
+
+	start := xml.StartElement{Name: xml.Name{Local: "rocket"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, name := range names {
+		if err := encodeSparseField(e, name, r[name]); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// encodeSparseField encodes a selected field, wrapping list items as declared by "parent>item" xml tags
+func encodeSparseField(e *xml.Encoder, name string, value any) error {
+	tag, _, _ := strings.Cut(rocketType.Field(rocketFieldIndex[name]).Tag.Get("xml"), ",")
+	parent, item, nested := strings.Cut(tag, ">")
+	if !nested {
+		return e.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+	}
+
+	items := reflect.ValueOf(value)
+	if items.Len() == 0 {
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: parent}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for i := 0; i < items.Len(); i++ {
+		if err := e.EncodeElement(items.Index(i).Interface(), xml.StartElement{Name: xml.Name{Local: item}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
@@ -0,0 +1,233 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListFleets godoc
+// @Summary List fleets
+// @Description Lists every fleet with its rocket count, status breakdown and aggregate speed statistics
+// @Tags fleets
+// @Produce json,application/msgpack,xml
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Success 200 {object} models.FleetListResponse
+// @Failure 500 {object} models.Problem
+// @Router /fleets [get]
+func ListFleets(fs service.FleetService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		unit, ok := speedUnitParam(c)
+		if !ok {
+			return
+		}
+
+		fleets, err := fs.ListFleets(c.Request.Context())
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to retrieve fleets",
+				"An error occurred while fetching the fleets. Please try again later.")
+			return
+		}
+
+		for _, fleet := range fleets {
+			fleet.Speed = convertSpeedStats(fleet.Speed, unit)
+		}
+
+		respond(c, http.StatusOK, models.FleetListResponse{
+			Count:  len(fleets),
+			Fleets: fleets,
+		})
+	}
+}
+
+// GetFleet godoc
+// @Summary Get a fleet
+// @Description Retrieves a fleet with the rocket count, status breakdown and aggregate speed statistics of its non-archived rockets
+// @Tags fleets
+// @Produce json,application/msgpack,xml
+// @Param id path string true "Fleet ID"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Success 200 {object} models.Fleet
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /fleets/{id} [get]
+func GetFleet(fs service.FleetService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		unit, ok := speedUnitParam(c)
+		if !ok {
+			return
+		}
+
+		fleet, err := fs.GetFleet(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			respondFleetError(c, err)
+			return
+		}
+
+		fleet.Speed = convertSpeedStats(fleet.Speed, unit)
+		respond(c, http.StatusOK, fleet)
+	}
+}
+
+// ListFleetRockets godoc
+// @Summary List the rockets of a fleet
+// @Description Lists the non-archived rockets of a fleet, with the same sorting, sparse fieldsets and units as GET /rockets
+// @Tags fleets
+// @Produce json,application/msgpack,xml
+// @Param id path string true "Fleet ID"
+// @Param sort query string false "Comma-separated sort fields (id, name, type, speed, maxSpeed, averageSpeed, estimatedDistance, mission, status); prefix with - for descending or + (%2B) for ascending, speed defaults to descending" default(id)
+// @Param fields query string false "Comma-separated fields to return, e.g. id,speed,status"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Success 200 {object} models.FleetRocketsResponse
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /fleets/{id}/rockets [get]
+func ListFleetRockets(fs service.FleetService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sortBy, sortFields, ok := rocketSortParam(c)
+		if !ok {
+			return
+		}
+
+		view, ok := rocketViewParams(c)
+		if !ok {
+			return
+		}
+
+		id := c.Param("id")
+		rockets, err := fs.ListFleetRockets(c.Request.Context(), id, sortFields)
+		if err != nil {
+			respondFleetError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, models.FleetRocketsResponse{
+			Fleet:   id,
+			Count:   len(rockets),
+			Rockets: view.renderAll(rockets),
+			SortBy:  sortBy,
+		})
+	}
+}
+
+// PostFleet godoc
+// @Summary Create fleet
+// @Description Defines a fleet from a list of member rocket IDs, a label selector, or both. Requires the admin token.
+// @Description Members that have not launched yet are counted once they do.
+// @Tags fleets
+// @Accept json
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param request body models.FleetRequest true "Fleet definition"
+// @Success 201 {object} models.Fleet
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Router /fleets [post]
+func PostFleet(fs service.FleetService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, ok := fleetRequestBody(c)
+		if !ok {
+			return
+		}
+
+		fleet, err := fs.CreateFleet(c.Request.Context(), req)
+		if err != nil {
+			respondFleetError(c, err)
+			return
+		}
+
+		c.Header("Location", "/fleets/"+fleet.ID)
+		respond(c, http.StatusCreated, fleet)
+	}
+}
+
+// PutFleet godoc
+// @Summary Update fleet
+// @Description Replaces the name, members and selector of a fleet. Requires the admin token.
+// @Tags fleets
+// @Accept json
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Fleet ID"
+// @Param request body models.FleetRequest true "Fleet definition"
+// @Success 200 {object} models.Fleet
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Router /fleets/{id} [put]
+func PutFleet(fs service.FleetService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		req, ok := fleetRequestBody(c)
+		if !ok {
+			return
+		}
+
+		fleet, err := fs.UpdateFleet(c.Request.Context(), c.Param("id"), req)
+		if err != nil {
+			respondFleetError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, fleet)
+	}
+}
+
+// DeleteFleet godoc
+// @Summary Delete fleet
+// @Description Removes a fleet definition; its rockets are left untouched. Requires the admin token.
+// @Tags fleets
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Fleet ID"
+// @Success 204
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /fleets/{id} [delete]
+func DeleteFleet(fs service.FleetService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := fs.DeleteFleet(c.Request.Context(), c.Param("id")); err != nil {
+			respondFleetError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// fleetRequestBody binds and validates a fleet definition, responding with 400 or 422 when it is not acceptable
+func fleetRequestBody(c *gin.Context) (models.FleetRequest, bool) {
+	var req models.FleetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+			"The request body must be a JSON object with a 'name' and 'members' or 'selector'")
+		return req, false
+	}
+
+	if err := validateFleet(&req); err != nil {
+		problem.Respond(c, http.StatusUnprocessableEntity, models.ErrorCodeInvalidRequestBody, "Invalid fleet",
+			err.Error())
+		return req, false
+	}
+
+	return req, true
+}
+
+// respondFleetError maps errors from fleet operations to a response
+func respondFleetError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrFleetNotFound) {
+		problem.Respond(c, http.StatusNotFound, models.ErrorCodeFleetNotFound, "Fleet not found",
+			"No fleet exists with the provided ID.")
+		return
+	}
+
+	problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to process fleet",
+		"An error occurred while processing the fleet. Please try again later.")
+}
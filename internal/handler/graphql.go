@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/graphqlapi"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+)
+
+// GraphQL godoc
+// @Summary GraphQL query
+// @Description Executes a GraphQL query against the rockets schema (rocket, rockets, mission and missions queries).
+// @Description Query errors are reported in the errors field of a 200 response, as per GraphQL conventions
+// @Tags graphql
+// @Accept json
+// @Produce json
+// @Param request body models.GraphQLRequest true "GraphQL query"
+// @Success 200 {object} object
+// @Failure 400 {object} models.Problem
+// @Router /graphql [post]
+func GraphQL(schema graphql.Schema) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.GraphQLRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid GraphQL request",
+				"The request body must be a JSON object with a query field")
+			return
+		}
+
+		result := graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  req.Query,
+			OperationName:  req.OperationName,
+			VariableValues: req.Variables,
+			Context:        graphqlapi.WithRequestCache(c.Request.Context()),
+		})
+
+		c.JSON(http.StatusOK, result)
+	}
+}
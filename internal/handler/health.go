@@ -1,25 +1,68 @@
 package handler
 
 import (
+	"context"
 	"net/http"
+	"time"
 
+	"github.com/ahernandez9/rockets/internal/health"
 	"github.com/ahernandez9/rockets/internal/models"
 
 	"github.com/gin-gonic/gin"
 )
 
+// checkTimeout bounds the component checks of a probe, which must answer before the probe times out
+const checkTimeout = 2 * time.Second
+
 // Healthcheck godoc
 // @Summary Health check
-// @Description Returns the health status of the service
+// @Description Returns the health status of the service and of each component it depends on, with the latency of its
+// @Description check and its last error. The service is "degraded" while a component is down, but the check still
+// @Description succeeds: it tells that the process is alive, see /ready for whether it can take traffic.
 // @Tags health
 // @Produce json
 // @Success 200 {object} models.HealthResponse
 // @Router /health [get]
-func Healthcheck() gin.HandlerFunc {
+func Healthcheck(checker *health.Checker) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		c.JSON(http.StatusOK, models.HealthResponse{
-			Status:  "ok",
-			Service: "rockets",
-		})
+		ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+		defer cancel()
+
+		response := models.HealthResponse{
+			Status:     "ok",
+			Service:    "rockets",
+			Components: checker.Components(ctx),
+		}
+		for _, component := range response.Components {
+			if component.Status != health.StatusUp {
+				response.Status = "degraded"
+			}
+		}
+
+		c.JSON(http.StatusOK, response)
+	}
+}
+
+// Readiness godoc
+// @Summary Readiness check
+// @Description Tells whether the service can take traffic: the message processor is running and the components it
+// @Description depends on respond. Returns 503 otherwise, and while the service drains before shutting down.
+// @Description Unlike /health, which only tells that the process is alive, failing this check should not restart it.
+// @Tags health
+// @Produce json
+// @Success 200 {object} models.ReadinessResponse
+// @Failure 503 {object} models.ReadinessResponse
+// @Router /ready [get]
+func Readiness(checker *health.Checker) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, cancel := context.WithTimeout(c.Request.Context(), checkTimeout)
+		defer cancel()
+
+		if err := checker.Ready(ctx); err != nil {
+			c.JSON(http.StatusServiceUnavailable, models.ReadinessResponse{Status: "unavailable", Reason: err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusOK, models.ReadinessResponse{Status: "ready"})
 	}
 }
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"log/slog"
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// loginStateCookie ties the callback of a login to the browser that started it, against login CSRF
+	loginStateCookie = "rockets_login_state"
+
+	// loginStateMaxAge bounds the time an operator has to log in with the provider, in seconds
+	loginStateMaxAge = 600
+)
+
+// Login godoc
+// @Summary Log in
+// @Description Redirects operators to the login page of the OIDC provider, which redirects back to /auth/callback.
+// @Description Only available when OIDC login is configured.
+// @Tags auth
+// @Success 302
+// @Failure 500 {object} models.Problem
+// @Router /auth/login [get]
+func Login(provider *auth.OIDC) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state := make([]byte, 16)
+		if _, err := rand.Read(state); err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to start login",
+				"An error occurred while starting the login. Please try again later.")
+			return
+		}
+
+		c.SetSameSite(http.SameSiteLaxMode)
+		c.SetCookie(loginStateCookie, hex.EncodeToString(state), loginStateMaxAge, "/auth", "", c.Request.TLS != nil, true)
+		c.Redirect(http.StatusFound, provider.AuthCodeURL(hex.EncodeToString(state)))
+	}
+}
+
+// LoginCallback godoc
+// @Summary Complete login
+// @Description Exchanges the authorization code returned by the OIDC provider for an ID token, which operators send
+// @Description as a bearer token to administrative endpoints until it expires. Only operators granted the admin scope,
+// @Description by email or group, are let in.
+// @Tags auth
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "Login state"
+// @Success 200 {object} models.LoginResponse
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Router /auth/callback [get]
+func LoginCallback(provider *auth.OIDC) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state, err := c.Cookie(loginStateCookie)
+		if err != nil || subtle.ConstantTimeCompare([]byte(state), []byte(c.Query("state"))) != 1 {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid login state",
+				"The login was not started from this browser or took too long. Please log in again.")
+			return
+		}
+		c.SetCookie(loginStateCookie, "", -1, "/auth", "", c.Request.TLS != nil, true)
+
+		if reason := c.Query("error"); reason != "" {
+			problem.Respond(c, http.StatusUnauthorized, models.ErrorCodeUnauthorized, "Login failed",
+				"The provider refused the login: "+reason)
+			return
+		}
+
+		token, expiry, id, err := provider.Exchange(c.Request.Context(), c.Query("code"))
+		if err != nil {
+			slog.WarnContext(c.Request.Context(), "Login failed", "error", err)
+			problem.Respond(c, http.StatusUnauthorized, models.ErrorCodeUnauthorized, "Login failed",
+				"The authorization code could not be exchanged for a valid ID token. Please log in again.")
+			return
+		}
+		if !id.Allows(auth.ScopeAdmin) {
+			slog.WarnContext(c.Request.Context(), "Login denied", "actor", id.Name)
+			problem.Respond(c, http.StatusForbidden, models.ErrorCodeForbidden, "Forbidden",
+				"The account is not allowed to use administrative endpoints")
+			return
+		}
+
+		slog.InfoContext(c.Request.Context(), "Operator logged in", "actor", id.Name, "expiresAt", expiry)
+		c.JSON(http.StatusOK, models.LoginResponse{Token: token, ExpiresAt: expiry, Actor: id.Name})
+	}
+}
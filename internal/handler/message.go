@@ -1,64 +1,261 @@
 package handler
 
 import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
+	"unicode/utf8"
 
+	"github.com/ahernandez9/rockets/internal/bufpool"
+	"github.com/ahernandez9/rockets/internal/cluster"
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
 	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/pubsub"
 	"github.com/ahernandez9/rockets/internal/service"
+	"github.com/ahernandez9/rockets/internal/validation"
 
 	"github.com/gin-gonic/gin"
 )
 
+// MaxMessageSize bounds the body of a single submitted message, once inflated when it is compressed
+const MaxMessageSize = 1 << 20
+
+const (
+	// syncProcessingTimeout bounds how long a synchronous publish waits for the message to be processed
+	syncProcessingTimeout = 5 * time.Second
+
+	// maxStreamLineSize bounds the size of a single message of a streamed ingest
+	maxStreamLineSize = 1 << 20
+
+	// maxStreamErrors caps the number of rejected lines detailed in the summary of a streamed ingest
+	maxStreamErrors = 100
+
+	// maxStreamErrorLength bounds the text of the error of a rejected line, which may quote the line
+	maxStreamErrorLength = 256
+)
+
 // PostMessage godoc
 // @Summary Receive rocket telemetry message
-// @Description Accepts rocket telemetry messages from the test program and publishes them asynchronously
+// @Description Accepts rocket telemetry messages from the test program and publishes them asynchronously.
+// @Description With sync=true the request waits until the message is processed and returns the resulting rocket state.
+// @Description Malformed bodies are rejected with 400, well-formed messages failing validation with 422.
 // @Tags messages
-// @Accept json
-// @Produce json
+// @Accept json,application/msgpack
+// @Produce json,application/msgpack,xml
+// @Security APIKey
 // @Param message body models.RocketMessage true "Rocket message"
+// @Param sync query bool false "Wait for the message to be processed" default(false)
+// @Success 200 {object} models.Rocket
 // @Success 202 {object} map[string]string
-// @Failure 400 {object} models.ErrorResponse
-// @Failure 500 {object} models.ErrorResponse
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 413 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Failure 503 {object} models.Problem
+// @Failure 504 {object} models.Problem
 // @Router /messages [post]
 func PostMessage(ms service.MessageService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		var msg models.RocketMessage
 
-		if err := c.ShouldBindJSON(&msg); err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid request body",
-				Message: "The request body must be valid JSON matching the RocketMessage schema",
-			})
+		syncMode, err := strconv.ParseBool(c.DefaultQuery("sync", "false"))
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid sync parameter",
+				"The sync parameter must be a boolean (true or false)")
 			return
 		}
 
-		if err := validateMessageMetadata(msg.Metadata); err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid message metadata",
-				Message: err.Error(),
-			})
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, MaxMessageSize)
+		if err := bindBody(c, &msg); err != nil {
+			if tooLarge(err) {
+				problem.Respond(c, http.StatusRequestEntityTooLarge, models.ErrorCodePayloadTooLarge, "Payload too large",
+					fmt.Sprintf("Messages are limited to %d bytes, once inflated when compressed", MaxMessageSize))
+				return
+			}
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be valid JSON matching the RocketMessage schema")
 			return
 		}
 
-		if err := validateMessageContent(&msg); err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid message content",
-				Message: err.Error(),
-			})
+		if err := validation.ValidateMessageMetadata(msg.Metadata); err != nil {
+			ms.RecordRejected(&msg, err)
+			problem.Respond(c, http.StatusUnprocessableEntity, messageErrorCode(err), "Invalid message metadata", err.Error())
 			return
 		}
 
-		if err := ms.PublishMessage(&msg); err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "Failed to publish message",
-				Message: "The message could not be queued for processing. Please try again.",
-			})
+		if err := validation.ValidateMessageContent(&msg); err != nil {
+			ms.RecordRejected(&msg, err)
+			problem.Respond(c, http.StatusUnprocessableEntity, models.ErrorCodeInvalidMessage, "Invalid message content",
+				err.Error())
 			return
 		}
 
-		c.JSON(http.StatusAccepted, gin.H{
+		if syncMode {
+			publishMessageAndWait(c, ms, &msg)
+			return
+		}
+
+		if err := ms.PublishMessage(c.Request.Context(), &msg); err != nil {
+			respondPublishError(c, err)
+			return
+		}
+
+		respond(c, http.StatusAccepted, gin.H{
 			"status":  "ok",
 			"message": "Message queued for processing",
 		})
 	}
 }
+
+// publishMessageAndWait publishes the message and responds with the rocket state once it has been processed
+func publishMessageAndWait(c *gin.Context, ms service.MessageService, msg *models.RocketMessage) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), syncProcessingTimeout)
+	defer cancel()
+
+	rocket, err := ms.PublishMessageAndWait(ctx, msg)
+	switch {
+	case err == nil:
+		respond(c, http.StatusOK, rocket)
+	case errors.Is(err, service.ErrProcessingFailed):
+		problem.Respond(c, http.StatusConflict, models.ErrorCodeMessageRejected, "Message could not be applied",
+			err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		problem.Respond(c, http.StatusGatewayTimeout, models.ErrorCodeProcessingTimeout, "Message processing timed out",
+			"The message was queued but not processed in time. It may still be applied later.")
+	default:
+		respondPublishError(c, err)
+	}
+}
+
+// respondPublishError maps errors from publishing a message to a response
+func respondPublishError(c *gin.Context, err error) {
+	if errors.Is(err, pubsub.ErrQueueFull) {
+		problem.Respond(c, http.StatusServiceUnavailable, models.ErrorCodeQueueFull, "Message queue full",
+			"Too many messages are waiting to be processed. Please retry shortly.")
+		return
+	}
+	if errors.Is(err, cluster.ErrNotOwner) {
+		problem.Respond(c, http.StatusMisdirectedRequest, models.ErrorCodeChannelNotOwned, "Channel not owned",
+			err.Error())
+		return
+	}
+
+	problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to publish message",
+		"The message could not be queued for processing. Please try again.")
+}
+
+// streamLineErrorCode returns the error code of a rejected line of a streamed ingest
+func streamLineErrorCode(err error) models.ErrorCode {
+	if errors.Is(err, pubsub.ErrQueueFull) {
+		return models.ErrorCodeQueueFull
+	}
+	if errors.Is(err, cluster.ErrNotOwner) {
+		return models.ErrorCodeChannelNotOwned
+	}
+	return messageErrorCode(err)
+}
+
+// messageErrorCode returns the error code of a message validation error
+func messageErrorCode(err error) models.ErrorCode {
+	if errors.Is(err, validation.ErrInvalidMessageType) {
+		return models.ErrorCodeInvalidMessageType
+	}
+	return models.ErrorCodeInvalidMessage
+}
+
+// StreamMessages godoc
+// @Summary Stream rocket telemetry messages
+// @Description Accepts a newline-delimited JSON body where each line is a RocketMessage. Lines are validated
+// @Description and published as they are read, so producers can keep a single connection open.
+// @Description Invalid lines are reported and skipped without aborting the stream. The first 100 are detailed,
+// @Description the others only counted in omitted.
+// @Tags messages
+// @Accept application/x-ndjson
+// @Produce json
+// @Security APIKey
+// @Param messages body models.RocketMessage true "One RocketMessage per line"
+// @Success 200 {object} models.StreamIngestResponse
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Router /messages/stream [post]
+func StreamMessages(ms service.MessageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scanner, release := bufpool.NewScanner(c.Request.Body, maxStreamLineSize)
+		defer release()
+
+		resp := models.StreamIngestResponse{Errors: []models.StreamLineError{}}
+		line := 0
+
+		for scanner.Scan() {
+			line++
+			raw := bytes.TrimSpace(scanner.Bytes())
+			if len(raw) == 0 {
+				continue
+			}
+
+			resp.Total++
+			if err := publishStreamedMessage(c.Request.Context(), ms, raw); err != nil {
+				resp.Rejected++
+				if len(resp.Errors) == maxStreamErrors {
+					resp.Omitted++
+					continue
+				}
+				resp.Errors = append(resp.Errors, models.StreamLineError{
+					Line:      line,
+					ErrorCode: streamLineErrorCode(err),
+					Error:     truncate(err.Error(), maxStreamErrorLength),
+				})
+				continue
+			}
+			resp.Accepted++
+		}
+
+		if err := scanner.Err(); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid stream body",
+				fmt.Sprintf("The stream could not be read after line %d (%d messages accepted): %v", line, resp.Accepted, err))
+			return
+		}
+
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// truncate shortens s to at most n bytes, cutting at a rune boundary and marking the cut with an ellipsis
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := n - len("…")
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "…"
+}
+
+// publishStreamedMessage decodes, validates and publishes a single line of a streamed ingest
+func publishStreamedMessage(ctx context.Context, ms service.MessageService, raw []byte) error {
+	var msg models.RocketMessage
+	if err := jsoncodec.Unmarshal(raw, &msg); err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if err := validation.ValidateMessage(&msg); err != nil {
+		ms.RecordRejected(&msg, err)
+		return err
+	}
+
+	if err := ms.PublishMessage(ctx, &msg); err != nil {
+		return fmt.Errorf("failed to queue message: %w", err)
+	}
+
+	return nil
+}
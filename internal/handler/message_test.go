@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/service/mocks"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestStreamMessagesErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name             string
+		lines            []string
+		expectedRejected int
+		expectedErrors   int
+		expectedOmitted  int
+	}{
+		{
+			name:             "every rejected line detailed",
+			lines:            []string{"not json", "", "{"},
+			expectedRejected: 2,
+			expectedErrors:   2,
+		},
+		{
+			name:             "rejected lines beyond the cap counted",
+			lines:            slices.Repeat([]string{"not json"}, maxStreamErrors+20),
+			expectedRejected: maxStreamErrors + 20,
+			expectedErrors:   maxStreamErrors,
+			expectedOmitted:  20,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/messages/stream", StreamMessages(mocks.NewMockMessageService(gomock.NewController(t))))
+
+			body := strings.NewReader(strings.Join(tt.lines, "\n"))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/messages/stream", body))
+
+			require.Equal(t, http.StatusOK, w.Code)
+			var resp models.StreamIngestResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+			assert.Equal(t, tt.expectedRejected, resp.Rejected)
+			assert.Len(t, resp.Errors, tt.expectedErrors)
+			assert.Equal(t, tt.expectedOmitted, resp.Omitted)
+			assert.Equal(t, 1, resp.Errors[0].Line)
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "short", truncate("short", 10))
+
+	long := strings.Repeat("é", maxStreamErrorLength)
+	truncated := truncate(long, maxStreamErrorLength)
+	assert.LessOrEqual(t, len(truncated), maxStreamErrorLength)
+	assert.True(t, utf8.ValidString(truncated), "cut at a rune boundary")
+	assert.True(t, strings.HasSuffix(truncated, "…"))
+}
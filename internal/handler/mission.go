@@ -0,0 +1,129 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListMissions godoc
+// @Summary List missions
+// @Description Lists every mission with its rocket count, status breakdown and aggregate speed statistics
+// @Tags missions
+// @Produce json,application/msgpack,xml
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Success 200 {object} models.MissionListResponse
+// @Failure 500 {object} models.Problem
+// @Router /missions [get]
+func ListMissions(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		unit, ok := speedUnitParam(c)
+		if !ok {
+			return
+		}
+
+		missions, err := rs.ListMissions(c.Request.Context())
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to retrieve missions",
+				"An error occurred while fetching the missions. Please try again later.")
+			return
+		}
+
+		for _, mission := range missions {
+			mission.Speed = convertSpeedStats(mission.Speed, unit)
+		}
+
+		respond(c, http.StatusOK, models.MissionListResponse{
+			Count:    len(missions),
+			Missions: missions,
+		})
+	}
+}
+
+// GetMission godoc
+// @Summary Get a mission
+// @Description Retrieves a mission by its exact name with its rocket count, status breakdown and aggregate speed statistics
+// @Tags missions
+// @Produce json,application/msgpack,xml
+// @Param name path string true "Mission name"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Success 200 {object} models.Mission
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /missions/{name} [get]
+func GetMission(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		unit, ok := speedUnitParam(c)
+		if !ok {
+			return
+		}
+
+		mission, err := rs.GetMission(c.Request.Context(), c.Param("name"))
+		if err != nil {
+			respondMissionError(c, err)
+			return
+		}
+
+		mission.Speed = convertSpeedStats(mission.Speed, unit)
+		respond(c, http.StatusOK, mission)
+	}
+}
+
+// ListMissionRockets godoc
+// @Summary List the rockets of a mission
+// @Description Lists the non-archived rockets assigned to a mission, with the same sorting, sparse fieldsets and units as GET /rockets
+// @Tags missions
+// @Produce json,application/msgpack,xml
+// @Param name path string true "Mission name"
+// @Param sort query string false "Comma-separated sort fields (id, name, type, speed, maxSpeed, averageSpeed, estimatedDistance, mission, status); prefix with - for descending or + (%2B) for ascending, speed defaults to descending" default(id)
+// @Param fields query string false "Comma-separated fields to return, e.g. id,speed,status"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Success 200 {object} models.MissionRocketsResponse
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /missions/{name}/rockets [get]
+func ListMissionRockets(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		sortBy, sortFields, ok := rocketSortParam(c)
+		if !ok {
+			return
+		}
+
+		view, ok := rocketViewParams(c)
+		if !ok {
+			return
+		}
+
+		name := c.Param("name")
+		rockets, err := rs.ListMissionRockets(c.Request.Context(), name, sortFields)
+		if err != nil {
+			respondMissionError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, models.MissionRocketsResponse{
+			Mission: name,
+			Count:   len(rockets),
+			Rockets: view.renderAll(rockets),
+			SortBy:  sortBy,
+		})
+	}
+}
+
+// respondMissionError maps errors from mission lookups to a response
+func respondMissionError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrMissionNotFound) {
+		problem.Respond(c, http.StatusNotFound, models.ErrorCodeMissionNotFound, "Mission not found",
+			"No rocket is assigned to a mission with the provided name.")
+		return
+	}
+
+	problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to retrieve mission",
+		"An error occurred while fetching the mission. Please try again later.")
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/ahernandez9/rockets/internal/bufpool"
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/gin-gonic/gin/render"
+	"github.com/ugorji/go/codec"
+)
+
+// negotiatedFormats are the response formats offered through the Accept header, JSON first as the default
+var negotiatedFormats = []string{
+	binding.MIMEJSON,
+	binding.MIMEMSGPACK2,
+	binding.MIMEMSGPACK,
+	binding.MIMEXML,
+	binding.MIMEXML2,
+}
+
+// msgpackHandle decodes MessagePack request bodies. Maps decode with string keys, like JSON objects do,
+// so that untyped message content can be converted to JSON
+var msgpackHandle = func() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{}
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	h.RawToString = true
+	return h
+}()
+
+// negotiatedFormat returns the response format negotiated from the Accept header, falling back to JSON.
+// The response is marked as varying with the Accept header
+func negotiatedFormat(c *gin.Context) string {
+	middleware.AddVary(c, "Accept")
+	if format := c.NegotiateFormat(negotiatedFormats...); format != "" {
+		return format
+	}
+	return binding.MIMEJSON
+}
+
+// respond writes obj in the format negotiated from the Accept header, falling back to JSON
+func respond(c *gin.Context, status int, obj any) {
+	switch negotiatedFormat(c) {
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		c.Render(status, render.MsgPack{Data: obj})
+	case binding.MIMEXML, binding.MIMEXML2:
+		c.XML(status, obj)
+	default:
+		c.JSON(status, obj)
+	}
+}
+
+// bindBody decodes the request body according to its Content-Type: MessagePack or, by default, JSON
+func bindBody(c *gin.Context, obj any) error {
+	switch c.ContentType() {
+	case binding.MIMEMSGPACK, binding.MIMEMSGPACK2:
+		if msg, ok := obj.(*models.RocketMessage); ok {
+			return bindMsgpackMessage(c, msg)
+		}
+		if err := codec.NewDecoder(c.Request.Body, msgpackHandle).Decode(obj); err != nil {
+			return err
+		}
+		return binding.Validator.ValidateStruct(obj)
+	default:
+		return bindJSON(c, obj)
+	}
+}
+
+// bindJSON decodes and validates a JSON request body as ShouldBindJSON does, but reads it into a pooled buffer rather
+// than through a decoder allocated for the request. The decoded values copy what they keep of the buffer
+func bindJSON(c *gin.Context, obj any) error {
+	buf := bufpool.Get()
+	defer bufpool.Put(buf)
+
+	if _, err := buf.ReadFrom(c.Request.Body); err != nil {
+		return err
+	}
+	if err := jsoncodec.Unmarshal(buf.Bytes(), obj); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// bindMsgpackMessage decodes a message submitted in MessagePack, converting its content to JSON as if it had been
+// submitted in JSON
+func bindMsgpackMessage(c *gin.Context, msg *models.RocketMessage) error {
+	var body struct {
+		Metadata models.MessageMetadata `json:"metadata"`
+		Message  any                    `json:"message"`
+	}
+	if err := codec.NewDecoder(c.Request.Body, msgpackHandle).Decode(&body); err != nil {
+		return err
+	}
+	content, err := jsoncodec.Marshal(body.Message)
+	if err != nil {
+		return err
+	}
+	msg.Metadata, msg.Message = body.Metadata, content
+	return nil
+}
+
+// tooLarge reports whether decoding the request body failed because the body exceeds the size limit of its route
+func tooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
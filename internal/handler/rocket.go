@@ -1,9 +1,18 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/ahernandez9/rockets/internal/filter"
+	"github.com/ahernandez9/rockets/internal/middleware"
 	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/repository"
 	"github.com/ahernandez9/rockets/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -12,82 +21,696 @@ import (
 
 // GetRocket godoc
 // @Summary Get rocket by ID
-// @Description Retrieves the current state of a specific rocket
+// @Description Retrieves the current state of a specific rocket.
+// @Description Responses carry an ETag and a Last-Modified date; send them back in If-None-Match or
+// @Description If-Modified-Since to get a 304 while the rocket is unchanged
 // @Tags rockets
-// @Produce json
+// @Produce json,application/msgpack,xml
 // @Param id path string true "Rocket ID (UUID)"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,speed,status"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Param If-None-Match header string false "ETag of a previously retrieved version"
+// @Param If-Modified-Since header string false "Date of a previously retrieved version (HTTP date)"
 // @Success 200 {object} models.Rocket
-// @Failure 400 {object} models.ErrorResponse
-// @Failure 404 {object} models.ErrorResponse
+// @Success 304 "Not modified"
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
 // @Router /rockets/{id} [get]
 func GetRocket(rs service.RocketService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		id := c.Param("id")
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
 
-		if _, err := uuid.Parse(id); err != nil {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid rocket ID",
-				Message: "The rocket ID must be a valid UUID (e.g., 193270a9-c9cf-404a-8f83-838e71d9ae67)",
-			})
+		view, ok := rocketViewParams(c)
+		if !ok {
 			return
 		}
 
 		rocket, err := rs.GetRocket(c.Request.Context(), id)
 		if err != nil {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{
-				Error:   "Rocket not found",
-				Message: "No rocket exists with the provided ID. It may not have been launched yet.",
-			})
+			problem.Respond(c, http.StatusNotFound, models.ErrorCodeRocketNotFound, "Rocket not found",
+				"No rocket exists with the provided ID. It may not have been launched yet.")
+			return
+		}
+
+		respondRocket(c, view, rocket)
+	}
+}
+
+// GetRocketByName godoc
+// @Summary Get rocket by name
+// @Description Retrieves the current state of the rocket with the given display name, ignoring case.
+// @Description Supports the same views and conditional requests as retrieving a rocket by ID
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Param name path string true "Rocket display name"
+// @Param fields query string false "Comma-separated fields to return, e.g. id,speed,status"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Param If-None-Match header string false "ETag of a previously retrieved version"
+// @Param If-Modified-Since header string false "Date of a previously retrieved version (HTTP date)"
+// @Success 200 {object} models.Rocket
+// @Success 304 "Not modified"
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/by-name/{name} [get]
+func GetRocketByName(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		view, ok := rocketViewParams(c)
+		if !ok {
+			return
+		}
+
+		rocket, err := rs.GetRocketByName(c.Request.Context(), c.Param("name"))
+		if err != nil {
+			problem.Respond(c, http.StatusNotFound, models.ErrorCodeRocketNotFound, "Rocket not found",
+				"No rocket has the provided name.")
 			return
 		}
 
-		c.JSON(http.StatusOK, rocket)
+		respondRocket(c, view, rocket)
+	}
+}
+
+// respondRocket writes a single rocket, or a 304 when the client already holds its current version
+func respondRocket(c *gin.Context, view rocketView, rocket *models.Rocket) {
+	etag := rocketETag(rocket, view, negotiatedFormat(c))
+	c.Header("ETag", etag)
+	if notModifiedSince(c, rocket.ModifiedAt) || etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
 	}
+
+	respond(c, http.StatusOK, view.render(rocket))
 }
 
 // ListRockets godoc
 // @Summary List all rockets
-// @Description Retrieves a list of all rockets in the system with optional sorting
+// @Description Retrieves a list of all rockets in the system with optional filtering and multi-field sorting.
+// @Description Filters are combinable and case-insensitive.
 // @Tags rockets
-// @Produce json
-// @Param sort query string false "Sort by field (type, speed, mission, status)" default(id)
+// @Produce json,application/msgpack,xml
+// @Param sort query string false "Comma-separated sort fields (id, name, type, speed, maxSpeed, averageSpeed, estimatedDistance, mission, status); prefix with - for descending or + (%2B) for ascending, speed defaults to descending" default(id)
+// @Param status query string false "Filter by status (ACTIVE, EXPLODED, LANDED, DECOMMISSIONED)"
+// @Param mission query string false "Filter by mission"
+// @Param type query string false "Filter by rocket type"
+// @Param q query string false "Case-insensitive search over ID, name, type and mission"
+// @Param filter query string false "Filter expression, e.g. speed>1000 AND status=ACTIVE"
+// @Param lowFuel query bool false "Only rockets whose fuel level is below 20%" default(false)
+// @Param label query []string false "Label selector key=value, repeatable" collectionFormat(multi)
+// @Param includeArchived query bool false "Include archived rockets" default(false)
+// @Param fields query string false "Comma-separated fields to return, e.g. id,speed,status"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Param If-Modified-Since header string false "Returns 304 when no rocket was changed or deleted since this HTTP date"
 // @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} models.ErrorResponse
+// @Success 304 "Not modified"
+// @Failure 400 {object} models.Problem
 // @Router /rockets [get]
 func ListRockets(rs service.RocketService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		sortBy := c.DefaultQuery("sort", "id")
+		sortBy, sortFields, ok := rocketSortParam(c)
+		if !ok {
+			return
+		}
+
+		includeArchived, err := strconv.ParseBool(c.DefaultQuery("includeArchived", "false"))
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid includeArchived parameter",
+				"The includeArchived parameter must be a boolean (true or false)")
+			return
+		}
+
+		lowFuel, err := strconv.ParseBool(c.DefaultQuery("lowFuel", "false"))
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid lowFuel parameter",
+				"The lowFuel parameter must be a boolean (true or false)")
+			return
+		}
+
+		view, ok := rocketViewParams(c)
+		if !ok {
+			return
+		}
 
-		validSortFields := map[string]bool{
-			"id":      true,
-			"type":    true,
-			"speed":   true,
-			"mission": true,
-			"status":  true,
+		rocketFilter := models.RocketFilter{
+			Status:  c.Query("status"),
+			Mission: c.Query("mission"),
+			Type:    c.Query("type"),
+			Query:   strings.TrimSpace(c.Query("q")),
+			LowFuel: lowFuel,
+
+			IncludeArchived: includeArchived,
+		}
+
+		if rocketFilter.Status != "" && !isValidStatus(rocketFilter.Status) {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid status filter",
+				"Status filter must be one of: ACTIVE, EXPLODED, LANDED, DECOMMISSIONED")
+			return
+		}
+
+		predicates, err := filter.Parse(c.Query("filter"))
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidFilter, "Invalid filter expression",
+				err.Error())
+			return
 		}
+		rocketFilter.Predicates = predicates
 
-		if !validSortFields[sortBy] {
-			c.JSON(http.StatusBadRequest, models.ErrorResponse{
-				Error:   "Invalid sort parameter",
-				Message: "Sort parameter must be one of: id, type, speed, mission, status",
-			})
+		labels, err := filter.ParseLabels(c.QueryArray("label"))
+		if err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidFilter, "Invalid label selector",
+				err.Error())
 			return
 		}
+		rocketFilter.Labels = labels
+
+		// Read before listing, so that a change made meanwhile is not hidden behind a later date
+		lastModified := rs.LastModified(c.Request.Context())
 
 		var rockets []*models.Rocket
-		var err error
-		if rockets, err = rs.ListRockets(c.Request.Context(), sortBy); err != nil {
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{
-				Error:   "Failed to retrieve rockets",
-				Message: "An error occurred while fetching the list of rockets. Please try again later.",
-			})
+		if rockets, err = rs.ListRockets(c.Request.Context(), rocketFilter, sortFields); err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to retrieve rockets",
+				"An error occurred while fetching the list of rockets. Please try again later.")
+			return
+		}
+
+		if notModifiedSince(c, lastModified) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+
+		respond(c, http.StatusOK, models.RocketListResponse{
+			Count:   len(rockets),
+			Rockets: view.renderAll(rockets),
+			SortBy:  sortBy,
+		})
+	}
+}
+
+// rocketSortParam parses the sort query parameter, responding with 400 when it names an unknown field.
+// It returns the specification as given, defaulting to "id", along with the parsed sort keys
+func rocketSortParam(c *gin.Context) (string, []models.SortField, bool) {
+	sortBy := c.DefaultQuery("sort", "id")
+
+	sortFields, err := service.ParseSort(sortBy)
+	if err != nil {
+		problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid sort parameter",
+			fmt.Sprintf("Sort parameter must be a comma-separated list of fields (%s), "+
+				"each optionally prefixed with '-' for descending or '+' for ascending order", strings.Join(service.SortableFields(), ", ")))
+		return "", nil, false
+	}
+
+	return sortBy, sortFields, true
+}
+
+// isValidStatus reports whether the value names a known rocket status (case-insensitive)
+func isValidStatus(status string) bool {
+	_, ok := models.ParseStatus(status)
+	return ok
+}
+
+// maxBatchGetIDs caps the number of rockets fetched in a single batch request
+const maxBatchGetIDs = 100
+
+// BatchGetRockets godoc
+// @Summary Get several rockets by ID
+// @Description Retrieves multiple rockets in one call, reporting found/not-found per requested ID
+// @Tags rockets
+// @Accept json
+// @Produce json,application/msgpack,xml
+// @Param request body models.BatchGetRequest true "Rocket IDs"
+// @Success 200 {object} models.BatchGetResponse
+// @Failure 400 {object} models.Problem
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Router /rockets/batch-get [post]
+func BatchGetRockets(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.BatchGetRequest
+
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be a JSON object with an 'ids' array")
+			return
+		}
+
+		if len(req.IDs) > maxBatchGetIDs {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeBatchTooLarge, "Too many IDs",
+				fmt.Sprintf("At most %d rocket IDs can be requested at once, got %d", maxBatchGetIDs, len(req.IDs)))
+			return
+		}
+
+		unit, ok := speedUnitParam(c)
+		if !ok {
+			return
+		}
+
+		validIDs := make([]string, 0, len(req.IDs))
+		for _, id := range req.IDs {
+			if _, err := uuid.Parse(id); err == nil {
+				validIDs = append(validIDs, id)
+			}
+		}
+
+		rockets := rs.GetRockets(c.Request.Context(), validIDs)
+
+		resp := models.BatchGetResponse{
+			Count:   len(req.IDs),
+			Results: make([]models.BatchGetResult, 0, len(req.IDs)),
+		}
+		for _, id := range req.IDs {
+			result := models.BatchGetResult{ID: id}
+
+			if _, err := uuid.Parse(id); err != nil {
+				result.Error = "invalid UUID"
+			} else if rocket, ok := rockets[id]; ok {
+				result.Found = true
+				result.Rocket = convertRocket(rocket, unit)
+				resp.Found++
+			}
+
+			resp.Results = append(resp.Results, result)
+		}
+
+		respond(c, http.StatusOK, resp)
+	}
+}
+
+// DeleteRocket godoc
+// @Summary Delete rocket
+// @Description Permanently removes a rocket, and the processing counters of its channel. Requires the admin token.
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Rocket ID (UUID)"
+// @Success 204
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/{id} [delete]
+func DeleteRocket(rs service.RocketService, ms service.MessageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		if err := rs.DeleteRocket(c.Request.Context(), id); err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+		ms.ForgetChannel(id)
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ArchiveRocket godoc
+// @Summary Archive rocket
+// @Description Marks a rocket as archived, hiding it from default listings without deleting it. Requires the admin token.
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Rocket ID (UUID)"
+// @Success 200 {object} models.Rocket
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/{id}/archive [post]
+func ArchiveRocket(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		rocket, err := rs.ArchiveRocket(c.Request.Context(), id)
+		if err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, rocket)
+	}
+}
+
+// UnarchiveRocket godoc
+// @Summary Unarchive rocket
+// @Description Restores an archived rocket to default listings. Requires the admin token.
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Rocket ID (UUID)"
+// @Success 200 {object} models.Rocket
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/{id}/unarchive [post]
+func UnarchiveRocket(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		rocket, err := rs.UnarchiveRocket(c.Request.Context(), id)
+		if err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, rocket)
+	}
+}
+
+// SummarizeRockets godoc
+// @Summary Summarize the fleet
+// @Description Returns rocket counts, status breakdown and speed statistics per rocket type, mission or status
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Param groupBy query string false "Grouping field (mission, status, type)" default(type)
+// @Success 200 {object} models.SummaryResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Router /rockets/summary [get]
+func SummarizeRockets(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		groupBy := c.DefaultQuery("groupBy", "type")
+
+		if !slices.Contains(service.GroupableFields(), groupBy) {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid groupBy parameter",
+				fmt.Sprintf("groupBy must be one of: %s", strings.Join(service.GroupableFields(), ", ")))
+			return
+		}
+
+		unit, ok := speedUnitParam(c)
+		if !ok {
+			return
+		}
+
+		groups, err := rs.Summarize(c.Request.Context(), groupBy)
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to summarize rockets",
+				"An error occurred while aggregating rockets. Please try again later.")
+			return
+		}
+
+		for _, group := range groups {
+			group.Speed = convertSpeedStats(group.Speed, unit)
+		}
+
+		respond(c, http.StatusOK, models.SummaryResponse{
+			GroupBy: groupBy,
+			Count:   len(groups),
+			Groups:  groups,
+		})
+	}
+}
+
+const (
+	defaultTopLimit = 10
+	maxTopLimit     = 100
+)
+
+// TopRockets godoc
+// @Summary Top-N active rockets
+// @Description Returns the active rockets with the highest value of the ranking field, best first
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Param by query string false "Ranking field (speed, maxSpeed, averageSpeed)" default(speed)
+// @Param limit query int false "Number of rockets to return (1-100)" default(10)
+// @Param fields query string false "Comma-separated fields to return, e.g. id,speed,status"
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Success 200 {object} models.TopRocketsResponse
+// @Failure 400 {object} models.Problem
+// @Failure 500 {object} models.Problem
+// @Router /rockets/top [get]
+func TopRockets(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		by := c.DefaultQuery("by", "speed")
+
+		if !slices.Contains(service.RankableFields(), by) {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid by parameter",
+				fmt.Sprintf("by must be one of: %s", strings.Join(service.RankableFields(), ", ")))
+			return
+		}
+
+		limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultTopLimit)))
+		if err != nil || limit < 1 || limit > maxTopLimit {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid limit parameter",
+				fmt.Sprintf("limit must be an integer between 1 and %d", maxTopLimit))
+			return
+		}
+
+		view, ok := rocketViewParams(c)
+		if !ok {
+			return
+		}
+
+		rockets, err := rs.TopRockets(c.Request.Context(), by, limit)
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to retrieve rockets",
+				"An error occurred while ranking rockets. Please try again later.")
+			return
+		}
+
+		respond(c, http.StatusOK, models.TopRocketsResponse{
+			By:      by,
+			Limit:   limit,
+			Count:   len(rockets),
+			Rockets: view.renderAll(rockets),
+		})
+	}
+}
+
+// PatchRocket godoc
+// @Summary Correct rocket fields
+// @Description Manually overwrites mission, speed or status when telemetry was wrong. Requires the admin token.
+// @Description The change is recorded as a ManualCorrection event in the rocket history.
+// @Tags rockets
+// @Accept json
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Rocket ID (UUID)"
+// @Param correction body models.RocketCorrection true "Fields to correct"
+// @Success 200 {object} models.Rocket
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Router /rockets/{id} [patch]
+func PatchRocket(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		var correction models.RocketCorrection
+		if err := c.ShouldBindJSON(&correction); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be valid JSON matching the RocketCorrection schema")
+			return
+		}
+
+		if err := validateCorrection(&correction); err != nil {
+			problem.Respond(c, http.StatusUnprocessableEntity, models.ErrorCodeInvalidRequestBody, "Invalid correction",
+				err.Error())
+			return
+		}
+
+		rocket, err := rs.CorrectRocket(c.Request.Context(), id, correction, c.GetString(middleware.ActorKey))
+		if err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, rocket)
+	}
+}
+
+// GetRocketEvents godoc
+// @Summary Get rocket history
+// @Description Retrieves the applied telemetry messages and manual corrections of a rocket, oldest first
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Param id path string true "Rocket ID (UUID)"
+// @Success 200 {object} models.RocketEventsResponse
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/{id}/events [get]
+func GetRocketEvents(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		events, err := rs.GetRocketEvents(c.Request.Context(), id)
+		if err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, models.RocketEventsResponse{
+			Count:  len(events),
+			Events: events,
+		})
+	}
+}
+
+// PutRocketLabels godoc
+// @Summary Replace rocket labels
+// @Description Replaces the labels of a rocket with the given key/value pairs; an empty object removes every label.
+// @Description Requires the admin token. Changes are recorded as a LabelsUpdated event in the rocket history.
+// @Tags rockets
+// @Accept json
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Rocket ID (UUID)"
+// @Param labels body object true "Labels, as a JSON object of strings"
+// @Success 200 {object} models.Rocket
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Router /rockets/{id}/labels [put]
+func PutRocketLabels(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		var labels models.Labels
+		if err := c.ShouldBindJSON(&labels); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be a JSON object of string labels")
+			return
+		}
+
+		if err := validateLabels(labels); err != nil {
+			problem.Respond(c, http.StatusUnprocessableEntity, models.ErrorCodeInvalidRequestBody, "Invalid labels",
+				err.Error())
+			return
+		}
+
+		rocket, err := rs.SetRocketLabels(c.Request.Context(), id, labels, c.GetString(middleware.ActorKey))
+		if err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, rocket)
+	}
+}
+
+// PutRocketName godoc
+// @Summary Rename rocket
+// @Description Assigns a display name to a rocket. Names are unique across rockets, ignoring case.
+// @Description Requires the admin token. Renames are recorded as a Renamed event in the rocket history.
+// @Tags rockets
+// @Accept json
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Rocket ID (UUID)"
+// @Param request body models.RocketNameRequest true "New name"
+// @Success 200 {object} models.Rocket
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Failure 409 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Router /rockets/{id}/name [put]
+func PutRocketName(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		var req models.RocketNameRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be a JSON object with a 'name' field")
+			return
+		}
+
+		if err := validateRocketName(req.Name); err != nil {
+			problem.Respond(c, http.StatusUnprocessableEntity, models.ErrorCodeInvalidRequestBody, "Invalid name",
+				err.Error())
+			return
+		}
+
+		rocket, err := rs.RenameRocket(c.Request.Context(), id, req.Name, c.GetString(middleware.ActorKey))
+		if errors.Is(err, repository.ErrNameTaken) {
+			problem.Respond(c, http.StatusConflict, models.ErrorCodeRocketNameTaken, "Name taken",
+				fmt.Sprintf("Another rocket is already named %q.", req.Name))
+			return
+		}
+		if err != nil {
+			respondRocketUpdateError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, rocket)
+	}
+}
+
+// GetRocketTrack godoc
+// @Summary Get rocket track
+// @Description Retrieves the most recent reported positions of a rocket, oldest first, e.g. to draw its trail on a map.
+// @Description Only a bounded number of positions is kept per rocket.
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Param id path string true "Rocket ID (UUID)"
+// @Success 200 {object} models.TrackResponse
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/{id}/track [get]
+func GetRocketTrack(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		points, err := rs.GetRocketTrack(c.Request.Context(), id)
+		if err != nil {
+			respondRocketUpdateError(c, err)
 			return
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"count":   len(rockets),
-			"rockets": rockets,
-			"sortBy":  sortBy,
+		respond(c, http.StatusOK, models.TrackResponse{
+			ID:     id,
+			Count:  len(points),
+			Points: points,
 		})
 	}
 }
+
+// rocketIDParam extracts the rocket ID path parameter, responding with 400 when it is not a valid UUID
+func rocketIDParam(c *gin.Context) (string, bool) {
+	id := c.Param("id")
+
+	if _, err := uuid.Parse(id); err != nil {
+		problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRocketID, "Invalid rocket ID",
+			"The rocket ID must be a valid UUID (e.g., 193270a9-c9cf-404a-8f83-838e71d9ae67)")
+		return "", false
+	}
+
+	return id, true
+}
+
+// respondRocketUpdateError maps errors from rocket mutations to a response
+func respondRocketUpdateError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrNotFound) {
+		problem.Respond(c, http.StatusNotFound, models.ErrorCodeRocketNotFound, "Rocket not found",
+			"No rocket exists with the provided ID.")
+		return
+	}
+
+	problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to update rocket",
+		"An error occurred while updating the rocket. Please try again later.")
+}
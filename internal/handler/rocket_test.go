@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
 	"github.com/ahernandez9/rockets/internal/service/mocks"
 
 	"github.com/gin-gonic/gin"
@@ -16,7 +18,7 @@ import (
 	"go.uber.org/mock/gomock"
 )
 
-//go:embed testdata/rocket/*.json
+//go:embed testdata/rocket/*.json testdata/admin/*.json
 var expectedFiles embed.FS
 
 func TestGetRocket(t *testing.T) {
@@ -36,6 +38,7 @@ func TestGetRocket(t *testing.T) {
 	tests := []struct {
 		name           string
 		rocketID       string
+		headers        map[string]string
 		mockSetup      func(*mocks.MockRocketService)
 		expectedStatus int
 		expectedFile   string
@@ -76,7 +79,7 @@ func TestGetRocket(t *testing.T) {
 			rocketID:       "12345-abcde-67890",
 			mockSetup:      func(m *mocks.MockRocketService) {},
 			expectedStatus: http.StatusBadRequest,
-			expectedFile:   "invalid_uuid.json",
+			expectedFile:   "malformed_uuid.json",
 		},
 		{
 			name:     "exploded rocket status",
@@ -98,6 +101,60 @@ func TestGetRocket(t *testing.T) {
 			expectedStatus: http.StatusOK,
 			expectedFile:   "exploded_rocket.json",
 		},
+		{
+			name:     "unchanged since ETag",
+			rocketID: validUUID,
+			headers:  map[string]string{"If-None-Match": `W/"other", "193270a9-c9cf-404a-8f83-838e71d9ae67-0"`},
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					GetRocket(gomock.Any(), validUUID).
+					Return(expectedRocket, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:     "unchanged since ETag of another representation",
+			rocketID: validUUID,
+			headers: map[string]string{
+				"If-None-Match": `"193270a9-c9cf-404a-8f83-838e71d9ae67-0"`,
+				"Accept":        "application/xml",
+			},
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					GetRocket(gomock.Any(), validUUID).
+					Return(expectedRocket, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:     "unchanged since date",
+			rocketID: validUUID,
+			headers:  map[string]string{"If-Modified-Since": "Wed, 02 Feb 2022 18:39:05 GMT"},
+			mockSetup: func(m *mocks.MockRocketService) {
+				updated := *expectedRocket
+				updated.ModifiedAt = time.Date(2022, 2, 2, 19, 39, 5, 863370000, time.FixedZone("CET", 3600))
+				m.EXPECT().
+					GetRocket(gomock.Any(), validUUID).
+					Return(&updated, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:     "changed since ETag",
+			rocketID: validUUID,
+			headers:  map[string]string{"If-None-Match": `"193270a9-c9cf-404a-8f83-838e71d9ae67-1"`},
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					GetRocket(gomock.Any(), validUUID).
+					Return(expectedRocket, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "successful_retrieval.json",
+		},
 	}
 
 	for _, tt := range tests {
@@ -121,6 +178,213 @@ func TestGetRocket(t *testing.T) {
 			if err != nil {
 				t.Fatalf("failed to create request: %v", err)
 			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code, "unexpected status code")
+			if w.Code >= http.StatusBadRequest {
+				assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"), "unexpected content type")
+			}
+
+			if tt.expectedFile != "" {
+				expectedJSON, err := expectedFiles.ReadFile("testdata/rocket/" + tt.expectedFile)
+				assert.NoError(t, err, fmt.Sprintf("failed to read file: %s", tt.expectedFile))
+
+				actualJSON := w.Body.String()
+				assert.JSONEq(t, string(expectedJSON), actualJSON, "response body mismatch")
+			}
+		})
+	}
+}
+
+func TestListRockets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	rockets := []*models.Rocket{
+		{
+			ID:      "193270a9-c9cf-404a-8f83-838e71d9ae67",
+			Type:    "Falcon-9",
+			Speed:   5000,
+			Mission: "ARTEMIS",
+			Status:  models.StatusActive,
+		},
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		headers        map[string]string
+		mockSetup      func(*mocks.MockRocketService)
+		expectedStatus int
+		expectedFile   string
+	}{
+		{
+			name:  "filters are passed to the service",
+			query: "?status=active&mission=artemis&type=falcon-9",
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), models.RocketFilter{
+						Status:  "active",
+						Mission: "artemis",
+						Type:    "falcon-9",
+					}, []models.SortField{{Field: "id"}}).
+					Return(rockets, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_filtered.json",
+		},
+		{
+			name:  "terminal status filter",
+			query: "?status=DECOMMISSIONED",
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), models.RocketFilter{Status: "DECOMMISSIONED"}, []models.SortField{{Field: "id"}}).
+					Return(rockets, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_filtered.json",
+		},
+		{
+			name:  "low fuel filter",
+			query: "?lowFuel=true",
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), models.RocketFilter{LowFuel: true}, []models.SortField{{Field: "id"}}).
+					Return(rockets, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_filtered.json",
+		},
+		{
+			name:  "label selectors",
+			query: "?label=team=alpha&label=env=staging",
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), models.RocketFilter{
+						Labels: models.Labels{"team": "alpha", "env": "staging"},
+					}, []models.SortField{{Field: "id"}}).
+					Return(rockets, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_filtered.json",
+		},
+		{
+			name:  "multi-field sort",
+			query: "?sort=status,-speed",
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), models.RocketFilter{}, []models.SortField{
+						{Field: "status"},
+						{Field: "speed", Descending: true},
+					}).
+					Return(rockets, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_multi_sort.json",
+		},
+		{
+			name:  "sparse fieldset",
+			query: "?fields=id,speed,status",
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), models.RocketFilter{}, []models.SortField{{Field: "id"}}).
+					Return(rockets, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_sparse.json",
+		},
+		{
+			name:           "unknown field in fieldset",
+			query:          "?fields=id,altitude",
+			mockSetup:      func(m *mocks.MockRocketService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedFile:   "invalid_fields.json",
+		},
+		{
+			name:  "speed sorts descending unless prefixed",
+			query: "?sort=speed,%2Bstatus",
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().
+					ListRockets(gomock.Any(), models.RocketFilter{}, []models.SortField{
+						{Field: "speed", Descending: true},
+						{Field: "status"},
+					}).
+					Return(rockets, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_default_direction_sort.json",
+		},
+		{
+			name:           "unknown sort field",
+			query:          "?sort=status,-altitude",
+			mockSetup:      func(m *mocks.MockRocketService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedFile:   "invalid_sort.json",
+		},
+		{
+			name:           "unknown status filter",
+			query:          "?status=orbiting",
+			mockSetup:      func(m *mocks.MockRocketService) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedFile:   "invalid_status_filter.json",
+		},
+		{
+			name:    "unchanged since date",
+			headers: map[string]string{"If-Modified-Since": "Wed, 02 Feb 2022 18:39:05 GMT"},
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().LastModified(gomock.Any()).Return(time.Date(2022, 2, 2, 18, 39, 5, 0, time.UTC))
+				m.EXPECT().ListRockets(gomock.Any(), gomock.Any(), gomock.Any()).Return(rockets, nil)
+			},
+			expectedStatus: http.StatusNotModified,
+		},
+		{
+			name:    "rocket changed or deleted since date",
+			headers: map[string]string{"If-Modified-Since": "Wed, 02 Feb 2022 18:39:05 GMT"},
+			mockSetup: func(m *mocks.MockRocketService) {
+				m.EXPECT().LastModified(gomock.Any()).Return(time.Date(2022, 2, 2, 18, 39, 6, 0, time.UTC))
+				m.EXPECT().ListRockets(gomock.Any(), gomock.Any(), gomock.Any()).Return(rockets, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedFile:   "list_filtered.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockService := mocks.NewMockRocketService(ctrl)
+
+			tt.mockSetup(mockService)
+			mockService.EXPECT().LastModified(gomock.Any()).AnyTimes()
+
+			router := gin.New()
+			router.GET("/rockets", ListRockets(mockService))
+
+			req, err := http.NewRequestWithContext(
+				context.Background(),
+				http.MethodGet,
+				"/rockets"+tt.query,
+				http.NoBody,
+			)
+			if err != nil {
+				t.Fatalf("failed to create request: %v", err)
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
 
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
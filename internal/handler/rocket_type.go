@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListRocketTypes godoc
+// @Summary List rocket types
+// @Description Lists the rocket types observed in telemetry with the count, status breakdown and missions of their current rockets.
+// @Description Types stay listed once observed, even when no rocket of that type is left.
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Success 200 {object} models.RocketTypeListResponse
+// @Failure 500 {object} models.Problem
+// @Router /rocket-types [get]
+func ListRocketTypes(rs service.RocketService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		types, err := rs.ListRocketTypes(c.Request.Context())
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to retrieve rocket types",
+				"An error occurred while fetching the rocket types. Please try again later.")
+			return
+		}
+
+		respond(c, http.StatusOK, models.RocketTypeListResponse{
+			Count: len(types),
+			Types: types,
+		})
+	}
+}
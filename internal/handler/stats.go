@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetProcessingStats godoc
+// @Summary Get processing lag
+// @Description Reports the delay between the time of messages and their processing, as p50/p99/max over the most
+// @Description recent messages, to detect when processing falls behind real time. The full distribution is exported
+// @Description as the rockets_processing_lag_seconds histogram at /metrics.
+// @Tags stats
+// @Produce json,application/msgpack,xml
+// @Success 200 {object} models.ProcessingStats
+// @Router /stats/processing [get]
+func GetProcessingStats(ms service.MessageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		respond(c, http.StatusOK, ms.ProcessingStats())
+	}
+}
+
+// GetIngestionStats godoc
+// @Summary Get ingestion rates
+// @Description Reports the load of submitted messages: rates in messages per second over the last 1, 5 and 15
+// @Description minutes, and messages accepted for processing or rejected since the service started, overall and by
+// @Description message type. Messages of unknown types are grouped as "unknown".
+// @Tags stats
+// @Produce json,application/msgpack,xml
+// @Success 200 {object} models.IngestionStats
+// @Router /stats/ingestion [get]
+func GetIngestionStats(ms service.MessageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		respond(c, http.StatusOK, ms.IngestionStats())
+	}
+}
+
+// GetRocketMetrics godoc
+// @Summary Get channel processing metrics
+// @Description Reports how the messages of a rocket's channel were processed since the service started: messages
+// @Description received, latest applied message number, gaps in the numbering, lag of the latest message and messages
+// @Description dropped by reason. Meant to debug a single misbehaving producer.
+// @Tags stats
+// @Produce json,application/msgpack,xml
+// @Param id path string true "Rocket ID (UUID)"
+// @Success 200 {object} models.ChannelStats
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/{id}/metrics [get]
+func GetRocketMetrics(ms service.MessageService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		stats, found := ms.ChannelStats(id)
+		if !found {
+			problem.Respond(c, http.StatusNotFound, models.ErrorCodeRocketNotFound, "Channel not found",
+				"No message was submitted for this channel since the service started.")
+			return
+		}
+
+		respond(c, http.StatusOK, stats)
+	}
+}
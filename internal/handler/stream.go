@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/bufpool"
+	"github.com/ahernandez9/rockets/internal/feed"
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// sseHeartbeatInterval is how often a comment is sent on idle streams to keep proxies from closing them
+const sseHeartbeatInterval = 15 * time.Second
+
+// StreamRockets godoc
+// @Summary Stream rocket changes
+// @Description Server-Sent Events stream of rocket state changes. Each event carries an ID; reconnecting clients
+// @Description send it back in the Last-Event-ID header (or lastEventId query parameter) to resume where they left off.
+// @Description When stream tokens are configured, a token from POST /stream-tokens is required and only the changes
+// @Description it grants are streamed; the stream ends when the token expires.
+// @Tags rockets
+// @Produce text/event-stream
+// @Param Last-Event-ID header string false "ID of the last event received"
+// @Param lastEventId query string false "ID of the last event received, for clients that cannot set headers"
+// @Param token query string false "Stream token, also accepted as a bearer token"
+// @Success 200 {object} models.RocketChange "rocket.updated and rocket.deleted events"
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Router /rockets/stream [get]
+func StreamRockets(changes *feed.Feed, tokens *auth.StreamTokens) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed := func(*models.RocketChange) bool { return true }
+		var expiry <-chan time.Time
+		if tokens != nil {
+			grant, ok := streamGrant(c, tokens)
+			if !ok {
+				return
+			}
+			allowed = func(change *models.RocketChange) bool {
+				mission := ""
+				if change.Rocket != nil {
+					mission = change.Rocket.Mission
+				}
+				return grant.Allows(change.RocketID, mission)
+			}
+			timer := time.NewTimer(time.Until(grant.ExpiresAt))
+			defer timer.Stop()
+			expiry = timer.C
+		}
+
+		lastEventID := c.GetHeader("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = c.Query("lastEventId")
+		}
+
+		var lastID uint64
+		if lastEventID != "" {
+			var err error
+			if lastID, err = strconv.ParseUint(lastEventID, 10, 64); err != nil {
+				problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid Last-Event-ID",
+					"The last event ID must be a non-negative integer")
+				return
+			}
+		}
+
+		sub, backlog := changes.Subscribe(lastID)
+		defer sub.Close()
+
+		c.Header("Content-Type", "text/event-stream")
+		c.Header("Cache-Control", "no-cache")
+		c.Header("Connection", "keep-alive")
+		c.Header("X-Accel-Buffering", "no")
+		c.Status(http.StatusOK)
+
+		for i := range backlog {
+			if !allowed(&backlog[i]) {
+				continue
+			}
+			if err := writeChangeEvent(c.Writer, &backlog[i]); err != nil {
+				return
+			}
+		}
+		c.Writer.Flush()
+
+		heartbeat := time.NewTicker(sseHeartbeatInterval)
+		defer heartbeat.Stop()
+
+		for {
+			select {
+			case change, ok := <-sub.C:
+				if !ok {
+					// Dropped for being too slow; the client reconnects and resumes from its last event ID
+					return
+				}
+				if !allowed(&change) {
+					continue
+				}
+				if err := writeChangeEvent(c.Writer, &change); err != nil {
+					return
+				}
+			case <-heartbeat.C:
+				if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+					return
+				}
+			case <-expiry:
+				// The client requests a new token and resumes from its last event ID
+				return
+			case <-c.Request.Context().Done():
+				return
+			}
+			c.Writer.Flush()
+		}
+	}
+}
+
+// streamGrant verifies the stream token of a request, from the token query parameter or a bearer token
+func streamGrant(c *gin.Context, tokens *auth.StreamTokens) (auth.StreamGrant, bool) {
+	token := c.Query("token")
+	if bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); token == "" && found {
+		token = bearer
+	}
+	if token == "" {
+		problem.Respond(c, http.StatusUnauthorized, models.ErrorCodeUnauthorized, "Stream token required",
+			"A stream token from POST /stream-tokens must be provided in the token query parameter")
+		return auth.StreamGrant{}, false
+	}
+
+	grant, err := tokens.Verify(token, time.Now())
+	if err != nil {
+		problem.Respond(c, http.StatusUnauthorized, models.ErrorCodeUnauthorized, "Invalid stream token",
+			"The stream token is invalid or expired, request a new one")
+		return auth.StreamGrant{}, false
+	}
+	return grant, true
+}
+
+// writeChangeEvent writes a change as a Server-Sent Event
+func writeChangeEvent(w io.Writer, change *models.RocketChange) error {
+	buf := bufpool.Get()
+	defer bufpool.Put(buf)
+
+	buf.WriteString("id: ")
+	buf.Write(strconv.AppendUint(buf.AvailableBuffer(), change.ID, 10))
+	buf.WriteString("\nevent: rocket.")
+	buf.WriteString(change.Kind)
+	buf.WriteString("\ndata: ")
+	if err := jsoncodec.MarshalTo(buf, change); err != nil {
+		return err
+	}
+	buf.WriteString("\n\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,102 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/middleware"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// maxStreamTokenScopes bounds the channels and missions of a stream token, keeping tokens short enough for URLs
+const maxStreamTokenScopes = 50
+
+// PostStreamToken godoc
+// @Summary Issue a stream token
+// @Description Issues a short-lived token subscribing to the changes of the given channels and of the rockets of the
+// @Description given missions, for browser dashboards that should not hold API keys. The token is passed in the token
+// @Description query parameter of /rockets/stream. Requires an API key with the stream scope.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body models.StreamTokenRequest true "Channels and missions to subscribe to"
+// @Success 201 {object} models.StreamTokenResponse
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 403 {object} models.Problem
+// @Security APIKey
+// @Router /stream-tokens [post]
+func PostStreamToken(tokens *auth.StreamTokens) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.StreamTokenRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be a JSON object with 'channels' and/or 'missions'")
+			return
+		}
+		if err := validateStreamTokenRequest(req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid stream token request",
+				err.Error())
+			return
+		}
+
+		var ttl time.Duration
+		if req.TTL != "" {
+			var err error
+			if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl <= 0 {
+				problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid stream token request",
+					fmt.Sprintf("'ttl' must be a positive duration such as 15m, up to %s", tokens.MaxTTL()))
+				return
+			}
+		}
+
+		grant := auth.StreamGrant{
+			Subject:  c.GetString(middleware.ActorKey),
+			Channels: req.Channels,
+			Missions: req.Missions,
+		}
+		token, grant, err := tokens.Issue(grant, ttl, time.Now())
+		if err != nil {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to issue stream token",
+				"An error occurred while issuing the token. Please try again later.")
+			return
+		}
+
+		slog.InfoContext(c.Request.Context(), "Stream token issued", "channels", len(grant.Channels),
+			"missions", len(grant.Missions), "expiresAt", grant.ExpiresAt)
+		c.JSON(http.StatusCreated, models.StreamTokenResponse{
+			Token:     token,
+			ExpiresAt: grant.ExpiresAt,
+			Channels:  grant.Channels,
+			Missions:  grant.Missions,
+		})
+	}
+}
+
+func validateStreamTokenRequest(req models.StreamTokenRequest) error {
+	if len(req.Channels) == 0 && len(req.Missions) == 0 {
+		return fmt.Errorf("at least one channel or mission is required")
+	}
+	if len(req.Channels)+len(req.Missions) > maxStreamTokenScopes {
+		return fmt.Errorf("a token covers at most %d channels and missions", maxStreamTokenScopes)
+	}
+	for _, id := range req.Channels {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("'channels' must be rocket UUIDs, got: %s", id)
+		}
+	}
+	for _, mission := range req.Missions {
+		if strings.TrimSpace(mission) == "" {
+			return fmt.Errorf("'missions' must not contain empty names")
+		}
+	}
+	return nil
+}
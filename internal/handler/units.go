@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"math"
+	"net/http"
+	"slices"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// speedUnitParam parses the units query parameter, responding with 400 when it is not a known unit.
+// An empty unit leaves speeds in the stored unit, without annotation
+func speedUnitParam(c *gin.Context) (models.SpeedUnit, bool) {
+	param := c.Query("units")
+	if param == "" {
+		return "", true
+	}
+
+	unit, ok := models.ParseSpeedUnit(param)
+	if !ok {
+		problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid units parameter",
+			"units must be one of: kmh, mph, ms")
+		return "", false
+	}
+
+	return unit, true
+}
+
+// convertRocket returns a copy of the rocket with its speed in the given unit, or the rocket itself without unit
+func convertRocket(rocket *models.Rocket, unit models.SpeedUnit) *models.Rocket {
+	if unit == "" || rocket == nil {
+		return rocket
+	}
+
+	converted := *rocket
+	converted.Speed = int(math.Round(unit.FromStored(float64(rocket.Speed))))
+	converted.MaxSpeed = int(math.Round(unit.FromStored(float64(rocket.MaxSpeed))))
+	converted.AverageSpeed = unit.FromStored(rocket.AverageSpeed)
+	converted.SpeedUnit = unit
+	return &converted
+}
+
+// convertSpeedStats returns the statistics in the given unit
+func convertSpeedStats(stats models.SpeedStats, unit models.SpeedUnit) models.SpeedStats {
+	if unit == "" {
+		return stats
+	}
+
+	return models.SpeedStats{
+		Min:     int(math.Round(unit.FromStored(float64(stats.Min)))),
+		Max:     int(math.Round(unit.FromStored(float64(stats.Max)))),
+		Average: unit.FromStored(stats.Average),
+		Total:   int(math.Round(unit.FromStored(float64(stats.Total)))),
+		Unit:    unit,
+	}
+}
+
+// rocketView describes how rockets are represented in responses: the unit of their speed and the selected fields
+type rocketView struct {
+	unit   models.SpeedUnit
+	fields rocketFields
+}
+
+// rocketViewParams parses the units and fields query parameters, responding with 400 when either is invalid
+func rocketViewParams(c *gin.Context) (rocketView, bool) {
+	unit, ok := speedUnitParam(c)
+	if !ok {
+		return rocketView{}, false
+	}
+
+	fields, ok := rocketFieldsParam(c)
+	if !ok {
+		return rocketView{}, false
+	}
+
+	// Converted speeds always carry their unit
+	if unit != "" && slices.Contains(fields, "speed") && !slices.Contains(fields, "speedUnit") {
+		fields = append(fields, "speedUnit")
+	}
+
+	return rocketView{unit: unit, fields: fields}, true
+}
+
+// render returns the representation of a rocket
+func (v rocketView) render(rocket *models.Rocket) any {
+	return v.fields.project(convertRocket(rocket, v.unit))
+}
+
+// renderAll returns the representation of a list of rockets
+func (v rocketView) renderAll(rockets []*models.Rocket) any {
+	if v.unit == "" {
+		return v.fields.projectAll(rockets)
+	}
+
+	converted := make([]*models.Rocket, 0, len(rockets))
+	for _, rocket := range rockets {
+		converted = append(converted, convertRocket(rocket, v.unit))
+	}
+	return v.fields.projectAll(converted)
+}
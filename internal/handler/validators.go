@@ -1,111 +1,139 @@
 package handler
 
 import (
-	"encoding/json"
 	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/ahernandez9/rockets/internal/models"
 
 	"github.com/google/uuid"
 )
 
-// validateMessageMetadata validates the metadata fields
-func validateMessageMetadata(metadata models.MessageMetadata) error {
-	if _, err := uuid.Parse(metadata.Channel); err != nil {
-		return fmt.Errorf("channel must be a valid UUID, got: %s", metadata.Channel)
+// validateCorrection validates a manual correction request
+func validateCorrection(correction *models.RocketCorrection) error {
+	if correction.Mission == nil && correction.Speed == nil && correction.Status == nil {
+		return fmt.Errorf("at least one of 'mission', 'speed' or 'status' must be provided")
 	}
 
-	if metadata.MessageNumber <= 0 {
-		return fmt.Errorf("messageNumber must be positive, got: %d", metadata.MessageNumber)
+	if correction.Mission != nil && *correction.Mission == "" {
+		return fmt.Errorf("'mission' cannot be empty")
 	}
 
-	if metadata.MessageTime.IsZero() {
-		return fmt.Errorf("messageTime is required and cannot be zero")
+	if correction.Speed != nil && *correction.Speed < 0 {
+		return fmt.Errorf("'speed' must be non-negative")
 	}
 
-	if metadata.MessageType == "" {
-		return fmt.Errorf("messageType is required and cannot be empty")
+	if correction.Status != nil {
+		status, ok := models.ParseStatus(string(*correction.Status))
+		if !ok {
+			return fmt.Errorf("'status' must be one of: ACTIVE, EXPLODED, LANDED, DECOMMISSIONED")
+		}
+		correction.Status = &status
 	}
 
-	validTypes := map[string]bool{
-		"RocketLaunched":       true,
-		"RocketSpeedIncreased": true,
-		"RocketSpeedDecreased": true,
-		"RocketExploded":       true,
-		"RocketMissionChanged": true,
-	}
+	return nil
+}
+
+const (
+	// maxLabels caps the number of labels of a rocket
+	maxLabels = 32
+
+	// maxLabelValueLength caps the length of a label value
+	maxLabelValueLength = 255
+
+	// maxRocketNameLength caps the length of a rocket display name
+	maxRocketNameLength = 64
+
+	// maxFleetMembers caps the number of rockets listed as members of a fleet
+	maxFleetMembers = 1000
+
+	// maxWebhookSecretLength caps the length of a webhook secret
+	maxWebhookSecretLength = 256
+)
 
-	if !validTypes[metadata.MessageType] {
-		return fmt.Errorf("messageType must be one of: RocketLaunched, RocketSpeedIncreased, "+
-			"RocketSpeedDecreased, RocketExploded, RocketMissionChanged, got: %s", metadata.MessageType)
+// validateRocketName validates the display name an operator wants to give a rocket
+func validateRocketName(name string) error {
+	if n := utf8.RuneCountInString(name); n == 0 || n > maxRocketNameLength {
+		return fmt.Errorf("'name' must be 1-%d characters, got: %d", maxRocketNameLength, n)
+	}
+	if strings.TrimSpace(name) != name {
+		return fmt.Errorf("'name' cannot start or end with whitespace")
+	}
+	if strings.IndexFunc(name, func(r rune) bool { return !unicode.IsPrint(r) }) >= 0 {
+		return fmt.Errorf("'name' cannot contain control characters")
 	}
 
 	return nil
 }
 
-// validateMessageContent validates the message content based on type
-func validateMessageContent(msg *models.RocketMessage) error {
-	if msg.Message == nil {
-		return fmt.Errorf("message content is required")
-	}
+// labelKeyPattern restricts label keys to short identifiers such as "team" or "campaign/phase"
+var labelKeyPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]{0,62}$`)
 
-	msgBytes, err := json.Marshal(msg.Message)
-	if err != nil {
-		return fmt.Errorf("invalid message format: %w", err)
+// validateLabels validates the labels an operator wants to attach to a rocket
+func validateLabels(labels models.Labels) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("a rocket can carry at most %d labels, got: %d", maxLabels, len(labels))
 	}
 
-	switch msg.Metadata.MessageType {
-	case "RocketLaunched":
-		var launchMsg models.RocketLaunchedMessage
-		if err := json.Unmarshal(msgBytes, &launchMsg); err != nil {
-			return fmt.Errorf("invalid RocketLaunched message: %w", err)
-		}
-		if launchMsg.Type == "" {
-			return fmt.Errorf("RocketLaunched message: 'type' field is required")
-		}
-		if launchMsg.LaunchSpeed < 0 {
-			return fmt.Errorf("RocketLaunched message: 'launchSpeed' must be non-negative")
+	for key, value := range labels {
+		if !labelKeyPattern.MatchString(key) {
+			return fmt.Errorf("label key %q must be 1-63 letters, digits, '.', '_', '/' or '-', starting with a letter or digit", key)
 		}
-		if launchMsg.Mission == "" {
-			return fmt.Errorf("RocketLaunched message: 'mission' field is required")
+		if len(value) > maxLabelValueLength {
+			return fmt.Errorf("label %q: value must be at most %d characters", key, maxLabelValueLength)
 		}
+	}
 
-	case "RocketSpeedIncreased":
-		var speedMsg models.RocketSpeedChangedMessage
-		if err := json.Unmarshal(msgBytes, &speedMsg); err != nil {
-			return fmt.Errorf("invalid RocketSpeedIncreased message: %w", err)
-		}
-		if speedMsg.By <= 0 {
-			return fmt.Errorf("RocketSpeedIncreased message: 'by' must be positive")
-		}
+	return nil
+}
 
-	case "RocketSpeedDecreased":
-		var speedMsg models.RocketSpeedChangedMessage
-		if err := json.Unmarshal(msgBytes, &speedMsg); err != nil {
-			return fmt.Errorf("invalid RocketSpeedDecreased message: %w", err)
-		}
-		if speedMsg.By <= 0 {
-			return fmt.Errorf("RocketSpeedDecreased message: 'by' must be positive (will be subtracted)")
-		}
+// validateFleet validates a fleet definition
+func validateFleet(req *models.FleetRequest) error {
+	if n := utf8.RuneCountInString(strings.TrimSpace(req.Name)); n == 0 || n > maxRocketNameLength {
+		return fmt.Errorf("'name' must be 1-%d characters", maxRocketNameLength)
+	}
 
-	case "RocketExploded":
-		var explodedMsg models.RocketExplodedMessage
-		if err := json.Unmarshal(msgBytes, &explodedMsg); err != nil {
-			return fmt.Errorf("invalid RocketExploded message: %w", err)
-		}
-		if explodedMsg.Reason == "" {
-			return fmt.Errorf("RocketExploded message: 'reason' field is required")
-		}
+	if len(req.Members) == 0 && len(req.Selector) == 0 {
+		return fmt.Errorf("at least one of 'members' or 'selector' must be provided")
+	}
 
-	case "RocketMissionChanged":
-		var missionMsg models.RocketMissionChangedMessage
-		if err := json.Unmarshal(msgBytes, &missionMsg); err != nil {
-			return fmt.Errorf("invalid RocketMissionChanged message: %w", err)
+	if len(req.Members) > maxFleetMembers {
+		return fmt.Errorf("a fleet can list at most %d members, got: %d", maxFleetMembers, len(req.Members))
+	}
+	for _, id := range req.Members {
+		if _, err := uuid.Parse(id); err != nil {
+			return fmt.Errorf("'members' must be rocket UUIDs, got: %s", id)
 		}
-		if missionMsg.NewMission == "" {
-			return fmt.Errorf("RocketMissionChanged message: 'newMission' field is required")
+	}
+
+	if err := validateLabels(req.Selector); err != nil {
+		return fmt.Errorf("selector: %w", err)
+	}
+
+	return nil
+}
+
+// validateWebhook validates a webhook registration
+func validateWebhook(req *models.WebhookRequest) error {
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return fmt.Errorf("'url' must be an absolute http or https URL, got: %s", req.URL)
+	}
+
+	for _, event := range req.Events {
+		if !slices.Contains(models.WebhookEvents, event) {
+			return fmt.Errorf("'events' must be among: %s, got: %s", strings.Join(models.WebhookEvents, ", "), event)
 		}
 	}
 
+	if len(req.Secret) > maxWebhookSecretLength {
+		return fmt.Errorf("'secret' must be at most %d characters", maxWebhookSecretLength)
+	}
+
 	return nil
 }
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/feed"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultWaitTimeout = 30 * time.Second
+	maxWaitTimeout     = 60 * time.Second
+)
+
+// WaitForRocket godoc
+// @Summary Long-poll a rocket for updates
+// @Description Returns the rocket as soon as it has applied a message newer than sinceMessageNumber: immediately
+// @Description when it already has, otherwise once an update arrives. Returns 204 when the timeout elapses first.
+// @Description Rockets that are not launched yet are waited for as well
+// @Tags rockets
+// @Produce json,application/msgpack,xml
+// @Param id path string true "Rocket ID (UUID)"
+// @Param sinceMessageNumber query int false "Last message number known to the client" default(0)
+// @Param units query string false "Speed unit (kmh, mph, ms); speeds are stored in kmh"
+// @Param timeout query string false "Maximum time to wait, as a Go duration (at most 60s)" default(30s)
+// @Success 200 {object} models.Rocket
+// @Success 204 "No update before the timeout"
+// @Failure 400 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /rockets/{id}/wait [get]
+func WaitForRocket(rs service.RocketService, changes *feed.Feed) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := rocketIDParam(c)
+		if !ok {
+			return
+		}
+
+		since, err := strconv.ParseInt(c.DefaultQuery("sinceMessageNumber", "0"), 10, 64)
+		if err != nil || since < 0 {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid sinceMessageNumber parameter",
+				"sinceMessageNumber must be a non-negative integer")
+			return
+		}
+
+		timeout, err := time.ParseDuration(c.DefaultQuery("timeout", defaultWaitTimeout.String()))
+		if err != nil || timeout <= 0 || timeout > maxWaitTimeout {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidParameter, "Invalid timeout parameter",
+				fmt.Sprintf("timeout must be a positive duration of at most %s (e.g. 30s)", maxWaitTimeout))
+			return
+		}
+
+		unit, ok := speedUnitParam(c)
+		if !ok {
+			return
+		}
+
+		// Subscribe before reading the current state so that no update can slip in between
+		sub, _ := changes.Subscribe(0)
+		defer sub.Close()
+
+		rocket, err := rs.GetRocket(c.Request.Context(), id)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to retrieve rocket",
+				"An error occurred while fetching the rocket. Please try again later.")
+			return
+		}
+		if rocket != nil && rocket.LastMessageNumber > since {
+			respond(c, http.StatusOK, convertRocket(rocket, unit))
+			return
+		}
+
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+
+		for {
+			select {
+			case change, ok := <-sub.C:
+				if !ok {
+					// Dropped for being too slow, let the client poll again
+					c.Status(http.StatusNoContent)
+					return
+				}
+				if change.RocketID != id {
+					continue
+				}
+				if change.Kind == models.ChangeDeleted {
+					problem.Respond(c, http.StatusNotFound, models.ErrorCodeRocketNotFound, "Rocket not found",
+						"The rocket was deleted while waiting for updates.")
+					return
+				}
+				if change.Rocket.LastMessageNumber > since {
+					respond(c, http.StatusOK, convertRocket(change.Rocket, unit))
+					return
+				}
+			case <-timer.C:
+				c.Status(http.StatusNoContent)
+				return
+			case <-c.Request.Context().Done():
+				return
+			}
+		}
+	}
+}
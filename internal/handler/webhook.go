@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PostWebhook godoc
+// @Summary Register webhook
+// @Description Subscribes an endpoint to rocket state changes. Each change is POSTed as JSON with its event in the
+// @Description X-Rockets-Event header and an HMAC-SHA256 of the body, keyed with the webhook secret, in the
+// @Description X-Rockets-Signature header ("sha256=<hex>"). A secret is generated when none is given; it is only
+// @Description returned in this response. Requires the admin token.
+// @Tags webhooks
+// @Accept json
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param request body models.WebhookRequest true "Webhook registration; events among rocket.updated and rocket.deleted, all by default"
+// @Success 201 {object} models.Webhook
+// @Failure 400 {object} models.Problem
+// @Failure 401 {object} models.Problem
+// @Failure 422 {object} models.Problem
+// @Router /webhooks [post]
+func PostWebhook(ws service.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req models.WebhookRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			problem.Respond(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body must be a JSON object with a 'url' field")
+			return
+		}
+
+		if err := validateWebhook(&req); err != nil {
+			problem.Respond(c, http.StatusUnprocessableEntity, models.ErrorCodeInvalidRequestBody, "Invalid webhook",
+				err.Error())
+			return
+		}
+
+		webhook, err := ws.CreateWebhook(c.Request.Context(), req)
+		if err != nil {
+			respondWebhookError(c, err)
+			return
+		}
+
+		c.Header("Location", "/webhooks/"+webhook.ID)
+		respond(c, http.StatusCreated, webhook)
+	}
+}
+
+// ListWebhooks godoc
+// @Summary List webhooks
+// @Description Lists the registered webhooks, oldest first, without their secrets. Requires the admin token.
+// @Tags webhooks
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Success 200 {object} models.WebhookListResponse
+// @Failure 401 {object} models.Problem
+// @Router /webhooks [get]
+func ListWebhooks(ws service.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhooks, err := ws.ListWebhooks(c.Request.Context())
+		if err != nil {
+			respondWebhookError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, models.WebhookListResponse{
+			Count:    len(webhooks),
+			Webhooks: webhooks,
+		})
+	}
+}
+
+// GetWebhook godoc
+// @Summary Get webhook
+// @Description Retrieves a registered webhook, without its secret. Requires the admin token.
+// @Tags webhooks
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Webhook ID"
+// @Success 200 {object} models.Webhook
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /webhooks/{id} [get]
+func GetWebhook(ws service.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		webhook, err := ws.GetWebhook(c.Request.Context(), c.Param("id"))
+		if err != nil {
+			respondWebhookError(c, err)
+			return
+		}
+
+		respond(c, http.StatusOK, webhook)
+	}
+}
+
+// DeleteWebhook godoc
+// @Summary Unregister webhook
+// @Description Stops deliveries to a webhook. Requires the admin token.
+// @Tags webhooks
+// @Produce json,application/msgpack,xml
+// @Security AdminToken
+// @Param id path string true "Webhook ID"
+// @Success 204
+// @Failure 401 {object} models.Problem
+// @Failure 404 {object} models.Problem
+// @Router /webhooks/{id} [delete]
+func DeleteWebhook(ws service.WebhookService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := ws.DeleteWebhook(c.Request.Context(), c.Param("id")); err != nil {
+			respondWebhookError(c, err)
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// respondWebhookError maps errors from webhook operations to a response
+func respondWebhookError(c *gin.Context, err error) {
+	if errors.Is(err, repository.ErrWebhookNotFound) {
+		problem.Respond(c, http.StatusNotFound, models.ErrorCodeWebhookNotFound, "Webhook not found",
+			"No webhook exists with the provided ID.")
+		return
+	}
+
+	problem.Respond(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Failed to process webhook",
+		"An error occurred while processing the webhook. Please try again later.")
+}
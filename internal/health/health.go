@@ -0,0 +1,108 @@
+// Package health tells whether the service and its dependencies work, for monitoring and the probes of orchestrators
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// ErrDraining is reported once the service is shutting down, so that traffic moves to other instances first
+var ErrDraining = errors.New("shutting down")
+
+// Component statuses
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check tells whether a component is usable, returning why it isn't otherwise
+type Check func(ctx context.Context) error
+
+type component struct {
+	name  string
+	check Check
+
+	mu          sync.Mutex
+	lastError   string
+	lastErrorAt time.Time
+}
+
+// run checks the component, remembering the failure so that it is still reported once the component recovers
+func (c *component) run(ctx context.Context) models.ComponentHealth {
+	start := time.Now()
+	err := c.check(ctx)
+	latency := time.Since(start)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		c.lastError, c.lastErrorAt = err.Error(), start
+	}
+
+	result := models.ComponentHealth{
+		Name:      c.name,
+		Status:    StatusUp,
+		LatencyMs: float64(latency.Microseconds()) / 1000,
+		LastError: c.lastError,
+	}
+	if err != nil {
+		result.Status = StatusDown
+	}
+	if !c.lastErrorAt.IsZero() {
+		lastErrorAt := c.lastErrorAt
+		result.LastErrorAt = &lastErrorAt
+	}
+	return result
+}
+
+// Checker runs the checks of the components the service needs to work. Components are added during setup;
+// running the checks is safe for concurrent use
+type Checker struct {
+	components []*component
+	draining   atomic.Bool
+}
+
+// NewChecker creates a checker without components, ready until it drains
+func NewChecker() *Checker {
+	return &Checker{}
+}
+
+// Add registers the check of a component
+func (c *Checker) Add(name string, check Check) {
+	c.components = append(c.components, &component{name: name, check: check})
+}
+
+// Drain marks the service as shutting down; it is no longer ready from then on
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Ready checks the components in the order they were added, returning the first failure prefixed by the component
+// name. The service can take traffic when it returns nil
+func (c *Checker) Ready(ctx context.Context) error {
+	if c.draining.Load() {
+		return ErrDraining
+	}
+	for _, comp := range c.components {
+		if result := comp.run(ctx); result.Status != StatusUp {
+			return fmt.Errorf("%s: %s", comp.name, result.LastError)
+		}
+	}
+	return nil
+}
+
+// Components checks every component, in the order they were added
+func (c *Checker) Components(ctx context.Context) []models.ComponentHealth {
+	results := make([]models.ComponentHealth, len(c.components))
+	for i, comp := range c.components {
+		results[i] = comp.run(ctx)
+	}
+	return results
+}
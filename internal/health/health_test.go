@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker(t *testing.T) {
+	ctx := context.Background()
+
+	var pubsubDown bool
+	c := NewChecker()
+	c.Add("repository", func(context.Context) error { return nil })
+	c.Add("pubsub", func(context.Context) error {
+		if pubsubDown {
+			return errors.New("message queue closed")
+		}
+		return nil
+	})
+
+	assert.NoError(t, c.Ready(ctx))
+
+	pubsubDown = true
+	assert.EqualError(t, c.Ready(ctx), "pubsub: message queue closed")
+
+	components := c.Components(ctx)
+	require.Len(t, components, 2)
+	assert.Equal(t, "repository", components[0].Name)
+	assert.Equal(t, StatusUp, components[0].Status)
+	assert.Empty(t, components[0].LastError)
+	assert.Equal(t, StatusDown, components[1].Status)
+
+	// The last error is still reported once the component recovers
+	pubsubDown = false
+	components = c.Components(ctx)
+	assert.Equal(t, StatusUp, components[1].Status)
+	assert.Equal(t, "message queue closed", components[1].LastError)
+	assert.NotNil(t, components[1].LastErrorAt)
+
+	c.Drain()
+	assert.ErrorIs(t, c.Ready(ctx), ErrDraining)
+}
@@ -0,0 +1,27 @@
+// Package jsoncodec encodes and decodes the JSON of the hot paths: the messages ingested, validated, queued and
+// processed, and the changes streamed to clients. The library is chosen at build time with the tags Gin uses for the
+// requests it binds and the responses it renders, so that a single tag switches both:
+//
+//	go build ./cmd/server                  # encoding/json
+//	go build -tags jsoniter ./cmd/server   # github.com/json-iterator/go
+//	go build -tags "sonic avx" ./cmd/server # github.com/bytedance/sonic, on amd64 only
+//
+// Every library is configured to behave as encoding/json does, so that the service answers the same whatever the
+// build. The benchmarks of the package compare the library built in with encoding/json.
+package jsoncodec
+
+import "bytes"
+
+// encoder encodes values to a stream, as json.Encoder does
+type encoder interface {
+	Encode(v any) error
+}
+
+// MarshalTo appends the JSON encoding of v to buf, as Marshal returns it, without copying it out of the library
+func MarshalTo(buf *bytes.Buffer, v any) error {
+	if err := newEncoder(buf).Encode(v); err != nil {
+		return err
+	}
+	buf.Truncate(buf.Len() - 1) // Encode ends the value with a newline
+	return nil
+}
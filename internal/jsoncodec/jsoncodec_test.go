@@ -0,0 +1,99 @@
+package jsoncodec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var messageJSON = []byte(`{"metadata":{"channel":"193270a9-c9cf-404a-8f83-838e71d9ae67","messageNumber":1,` +
+	`"messageTime":"2022-02-02T19:39:05.86337+01:00","messageType":"RocketLaunched"},` +
+	`"message":{"type":"Falcon-9","launchSpeed":500,"mission":"ARTEMIS"}}`)
+
+// fleet returns rockets as listed by GET /rockets
+func fleet(n int) []*models.Rocket {
+	launched := time.Date(2022, 2, 2, 19, 39, 1, 0, time.UTC)
+	rockets := make([]*models.Rocket, n)
+	for i := range rockets {
+		fuel := 87.5
+		rockets[i] = &models.Rocket{
+			ID:                fmt.Sprintf("193270a9-c9cf-404a-8f83-%012d", i),
+			Type:              "Falcon-9",
+			Speed:             3500,
+			MaxSpeed:          4200,
+			AverageSpeed:      2950.5,
+			Mission:           "ARTEMIS",
+			FuelLevel:         &fuel,
+			Position:          &models.Position{Latitude: 28.5721, Longitude: -80.648, Altitude: 12000},
+			Labels:            models.Labels{"team": "blue"},
+			Status:            models.StatusActive,
+			LastMessageNumber: 42,
+			LaunchTime:        &launched,
+			LastUpdated:       launched.Add(4 * time.Minute),
+		}
+	}
+	return rockets
+}
+
+func TestCodecMatchesEncodingJSON(t *testing.T) {
+	var got, want models.RocketMessage
+	require.NoError(t, Unmarshal(messageJSON, &got))
+	require.NoError(t, json.Unmarshal(messageJSON, &want))
+	assert.Equal(t, want.Metadata, got.Metadata)
+	assert.JSONEq(t, string(want.Message), string(got.Message))
+
+	rockets := fleet(3)
+	encoded, err := Marshal(rockets)
+	require.NoError(t, err)
+	expected, err := json.Marshal(rockets)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(encoded), "%s encodes as encoding/json does", Name)
+
+	var buf bytes.Buffer
+	buf.WriteString("data: ")
+	require.NoError(t, MarshalTo(&buf, rockets))
+	assert.Equal(t, "data: "+string(encoded), buf.String(), "MarshalTo appends what Marshal returns")
+
+	assert.Error(t, Unmarshal([]byte(`{"metadata":`), &got))
+}
+
+// BenchmarkUnmarshalMessage decodes an ingested message, e.g. go test -bench . -benchmem -tags jsoniter
+func BenchmarkUnmarshalMessage(b *testing.B) {
+	codecs := map[string]func([]byte, any) error{"encoding/json": json.Unmarshal}
+	codecs[Name] = Unmarshal
+	for name, unmarshal := range codecs {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				var msg models.RocketMessage
+				if err := unmarshal(messageJSON, &msg); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkMarshalRockets encodes a page of rockets, as listed by GET /rockets
+func BenchmarkMarshalRockets(b *testing.B) {
+	rockets := fleet(100)
+	codecs := map[string]func(any) ([]byte, error){"encoding/json": json.Marshal}
+	codecs[Name] = Marshal
+	for name, marshal := range codecs {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := marshal(rockets); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
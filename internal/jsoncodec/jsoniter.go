@@ -0,0 +1,22 @@
+//go:build jsoniter
+
+package jsoncodec
+
+import (
+	"io"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Name names the library built in
+const Name = "jsoniter"
+
+var (
+	api = jsoniter.ConfigCompatibleWithStandardLibrary
+	// Marshal returns the JSON encoding of v, as json.Marshal does
+	Marshal = api.Marshal
+	// Unmarshal decodes data into v, as json.Unmarshal does
+	Unmarshal = api.Unmarshal
+
+	newEncoder = func(w io.Writer) encoder { return api.NewEncoder(w) }
+)
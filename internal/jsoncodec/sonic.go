@@ -0,0 +1,22 @@
+//go:build !jsoniter && sonic && avx && (linux || windows || darwin) && amd64
+
+package jsoncodec
+
+import (
+	"io"
+
+	"github.com/bytedance/sonic"
+)
+
+// Name names the library built in
+const Name = "sonic"
+
+var (
+	api = sonic.ConfigStd
+	// Marshal returns the JSON encoding of v, as json.Marshal does
+	Marshal = api.Marshal
+	// Unmarshal decodes data into v, as json.Unmarshal does
+	Unmarshal = api.Unmarshal
+
+	newEncoder = func(w io.Writer) encoder { return api.NewEncoder(w) }
+)
@@ -0,0 +1,20 @@
+//go:build !jsoniter && !(sonic && avx && (linux || windows || darwin) && amd64)
+
+package jsoncodec
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Name names the library built in
+const Name = "encoding/json"
+
+var (
+	// Marshal returns the JSON encoding of v, as json.Marshal does
+	Marshal = json.Marshal
+	// Unmarshal decodes data into v, as json.Unmarshal does
+	Unmarshal = json.Unmarshal
+
+	newEncoder = func(w io.Writer) encoder { return json.NewEncoder(w) }
+)
@@ -0,0 +1,33 @@
+// Package leader elects the instance applying state when several instances consume the same broker and write to the
+// same repository, so that the messages of a rocket are never applied by two instances at once, out of order.
+package leader
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrLost is reported once an elected instance lost its leadership, e.g. because it could not renew its lease in time
+var ErrLost = errors.New("leadership lost")
+
+// Elector campaigns for the leadership of a partition of the messages
+type Elector interface {
+	// Campaign blocks until the instance is elected or ctx is done. The returned channel is closed when the
+	// leadership is lost; it is kept until ctx is done
+	Campaign(ctx context.Context) (lost <-chan struct{}, err error)
+	// Resign gives the leadership up, so that another instance takes over without waiting for it to expire
+	Resign(ctx context.Context) error
+}
+
+// Single is the elector of an instance running alone, always the leader
+type Single struct{}
+
+// Campaign returns at once and never loses the leadership
+func (Single) Campaign(context.Context) (<-chan struct{}, error) {
+	return nil, nil
+}
+
+// Resign does nothing
+func (Single) Resign(context.Context) error {
+	return nil
+}
@@ -0,0 +1,138 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisOptions configures an election held in Redis
+type RedisOptions struct {
+	Addr     string        // Address of the Redis server, e.g. redis:6379
+	Password string        // Password of the Redis server, if any
+	Key      string        // Key of the lease, one per partition elected separately
+	ID       string        // Identity of the instance holding the lease
+	TTL      time.Duration // Lease duration, how long a failed leader holds the others back
+}
+
+// renew extends the lease only if the instance still holds it
+var renew = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// release deletes the lease only if the instance still holds it
+var release = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Redis elects the instance holding a lease stored in Redis, renewed while the instance runs
+type Redis struct {
+	client  *redis.Client
+	opts    RedisOptions
+	elected atomic.Bool
+}
+
+// NewRedis creates an elector holding its lease in Redis
+func NewRedis(opts RedisOptions) (*Redis, error) {
+	if opts.Addr == "" || opts.Key == "" || opts.ID == "" {
+		return nil, fmt.Errorf("the Redis address, lease key and instance identity are required")
+	}
+	if opts.TTL < time.Second {
+		return nil, fmt.Errorf("the lease duration must be at least a second, got %s", opts.TTL)
+	}
+	return &Redis{
+		client: redis.NewClient(&redis.Options{Addr: opts.Addr, Password: opts.Password}),
+		opts:   opts,
+	}, nil
+}
+
+// interval is how often the lease is claimed and renewed, leaving time for retries before it expires
+func (r *Redis) interval() time.Duration {
+	return r.opts.TTL / 3
+}
+
+// Campaign claims the lease until it gets it, then renews it until ctx is done or it could not be renewed before
+// it expires
+func (r *Redis) Campaign(ctx context.Context) (<-chan struct{}, error) {
+	ticker := time.NewTicker(r.interval())
+	defer ticker.Stop()
+	for {
+		ok, err := r.client.SetNX(ctx, r.opts.Key, r.opts.ID, r.opts.TTL).Result()
+		if err != nil && ctx.Err() == nil {
+			slog.Warn("Failed to claim the leadership", "key", r.opts.Key, "error", err)
+		}
+		if ok {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	slog.Info("Elected leader", "key", r.opts.Key, "id", r.opts.ID)
+	r.elected.Store(true)
+	lost := make(chan struct{})
+	go r.hold(ctx, lost)
+	return lost, nil
+}
+
+// hold renews the lease, closing lost once it is held by another instance or may have expired
+func (r *Redis) hold(ctx context.Context, lost chan<- struct{}) {
+	defer close(lost)
+
+	ticker := time.NewTicker(r.interval())
+	defer ticker.Stop()
+	renewed := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		held, err := renew.Run(ctx, r.client, []string{r.opts.Key}, r.opts.ID, r.opts.TTL.Milliseconds()).Int()
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			slog.Warn("Failed to renew the leadership", "key", r.opts.Key, "error", err)
+			// Another instance may claim the lease once it expires, give it up before the next renewal is late
+			if time.Since(renewed)+r.interval() >= r.opts.TTL {
+				slog.Error("Leadership lost", "key", r.opts.Key, "error", ErrLost)
+				return
+			}
+		case held == 0:
+			slog.Error("Leadership lost", "key", r.opts.Key, "error", ErrLost)
+			return
+		default:
+			renewed = time.Now()
+		}
+	}
+}
+
+// Resign releases the lease if the instance still holds it
+func (r *Redis) Resign(ctx context.Context) error {
+	if !r.elected.Load() {
+		return nil
+	}
+	if err := release.Run(ctx, r.client, []string{r.opts.Key}, r.opts.ID).Err(); err != nil && !errors.Is(err, redis.Nil) {
+		return fmt.Errorf("releasing the leadership: %w", err)
+	}
+	return nil
+}
+
+// Close closes the connections to Redis
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
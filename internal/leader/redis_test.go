@@ -0,0 +1,58 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestElector(t *testing.T, addr, id string) *Redis {
+	elector, err := NewRedis(RedisOptions{Addr: addr, Key: "rockets:leader", ID: id, TTL: 3 * time.Second})
+	require.NoError(t, err)
+	t.Cleanup(func() { elector.Close() })
+	return elector
+}
+
+func TestRedisElectsOneInstance(t *testing.T) {
+	server := miniredis.RunT(t)
+	first, second := newTestElector(t, server.Addr(), "first"), newTestElector(t, server.Addr(), "second")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, err := first.Campaign(ctx)
+	require.NoError(t, err)
+
+	waiting, stopWaiting := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer stopWaiting()
+	_, err = second.Campaign(waiting)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the lease is held by the first instance")
+
+	require.NoError(t, first.Resign(context.Background()))
+	_, err = second.Campaign(ctx)
+	require.NoError(t, err)
+	holder, err := server.Get("rockets:leader")
+	require.NoError(t, err)
+	assert.Equal(t, "second", holder)
+}
+
+func TestRedisReportsLostLeadership(t *testing.T) {
+	server := miniredis.RunT(t)
+	elector := newTestElector(t, server.Addr(), "first")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	lost, err := elector.Campaign(ctx)
+	require.NoError(t, err)
+
+	// Another instance took over, e.g. after the lease expired during a pause
+	require.NoError(t, server.Set("rockets:leader", "second"))
+	select {
+	case <-lost:
+	case <-time.After(3 * time.Second):
+		t.Fatal("leadership loss not reported")
+	}
+}
@@ -0,0 +1,51 @@
+// Package lock serializes the processing of the messages of a channel across consumers sharing a repository, so that
+// updates of the same rocket applied by different instances never interleave.
+package lock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/pubsub"
+)
+
+// Locker acquires exclusive locks by key
+type Locker interface {
+	// Lock blocks until the lock of key is acquired or ctx is done. It returns a context derived from ctx, canceled
+	// if the lock is lost before it is released, and the function releasing it
+	Lock(ctx context.Context, key string) (held context.Context, unlock func(), err error)
+}
+
+// None acquires every lock at once, for consumers that don't share their repository
+type None struct{}
+
+// Lock returns at once
+func (None) Lock(ctx context.Context, _ string) (context.Context, func(), error) {
+	return ctx, func() {}, nil
+}
+
+// lockedPubSub handles every message under the lock of its channel
+type lockedPubSub struct {
+	pubsub.Interface
+	locker Locker
+}
+
+// WrapPubSub returns a pub/sub whose subscribers handle each message while holding the lock of its channel, with a
+// context canceled if the lock is lost meanwhile. Messages whose lock is not acquired are not handled, and left to the
+// pub/sub to redeliver or drop
+func WrapPubSub(ps pubsub.Interface, locker Locker) pubsub.Interface {
+	return &lockedPubSub{Interface: ps, locker: locker}
+}
+
+// Subscribe handles messages under the lock of their channel
+func (p *lockedPubSub) Subscribe(ctx context.Context, handler pubsub.MessageHandler) error {
+	return p.Interface.Subscribe(ctx, func(ctx context.Context, msg *models.RocketMessage) error {
+		held, unlock, err := p.locker.Lock(ctx, msg.Metadata.Channel)
+		if err != nil {
+			return fmt.Errorf("locking channel %s: %w", msg.Metadata.Channel, err)
+		}
+		defer unlock()
+		return handler(held, msg)
+	})
+}
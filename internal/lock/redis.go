@@ -0,0 +1,146 @@
+package lock
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// retryInterval is how often a lock held by another consumer is tried again
+const retryInterval = 20 * time.Millisecond
+
+// RedisOptions configures locks held in Redis
+type RedisOptions struct {
+	Addr     string        // Address of the Redis server, e.g. redis:6379
+	Password string        // Password of the Redis server, if any
+	Prefix   string        // Prefix of the keys of the locks
+	TTL      time.Duration // Lease of a lock, renewed while held and released by then if its holder failed
+	Wait     time.Duration // Time waited for a lock held by another consumer before giving up
+}
+
+// renew extends the lease of a lock only if it is still held with the token of its holder
+var renew = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// release deletes a lock only if it is still held with the token of its holder
+var release = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Redis holds locks in Redis, as keys expiring after their lease
+type Redis struct {
+	client *redis.Client
+	opts   RedisOptions
+}
+
+// NewRedis creates a locker holding its locks in Redis
+func NewRedis(opts RedisOptions) (*Redis, error) {
+	if opts.Addr == "" {
+		return nil, fmt.Errorf("the Redis address is required")
+	}
+	if opts.TTL <= 0 || opts.Wait <= 0 {
+		return nil, fmt.Errorf("the lease and wait of locks must be positive")
+	}
+	return &Redis{
+		client: redis.NewClient(&redis.Options{Addr: opts.Addr, Password: opts.Password}),
+		opts:   opts,
+	}, nil
+}
+
+// Lock acquires the lock of key, waiting up to the configured wait while another consumer holds it. The lease is
+// renewed until the lock is released, however long the holder keeps it
+func (r *Redis) Lock(ctx context.Context, key string) (context.Context, func(), error) {
+	key = r.opts.Prefix + key
+	token := uuid.NewString()
+	if err := r.acquire(ctx, key, token); err != nil {
+		return nil, nil, err
+	}
+
+	held, lose := context.WithCancel(ctx)
+	renewed := make(chan struct{})
+	go func() {
+		defer close(renewed)
+		r.hold(held, key, token)
+		lose()
+	}()
+
+	return held, func() {
+		lose()
+		<-renewed
+		// Released even when the processing was canceled, rather than held until the lease expires
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := release.Run(ctx, r.client, []string{key}, token).Err(); err != nil {
+			slog.Warn("Failed to release lock", "key", key, "error", err)
+		}
+	}, nil
+}
+
+// acquire sets the lock of key to token once no other consumer holds it, waiting up to the configured wait
+func (r *Redis) acquire(ctx context.Context, key, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.opts.Wait)
+	defer cancel()
+
+	for {
+		ok, err := r.client.SetNX(ctx, key, token, r.opts.TTL).Result()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// hold renews the lease of the lock of key every third of its duration until ctx is done, and returns early once the
+// lock is held by another consumer or may have expired
+func (r *Redis) hold(ctx context.Context, key, token string) {
+	interval := r.opts.TTL / 3
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	renewed := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		held, err := renew.Run(ctx, r.client, []string{key}, token, r.opts.TTL.Milliseconds()).Int()
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			slog.Warn("Failed to renew lock", "key", key, "error", err)
+			// Another consumer may take the lock once it expires, give it up before the next renewal is late
+			if time.Since(renewed)+interval >= r.opts.TTL {
+				slog.Error("Lock lost", "key", key, "error", err)
+				return
+			}
+		case held == 0:
+			slog.Error("Lock lost", "key", key)
+			return
+		default:
+			renewed = time.Now()
+		}
+	}
+}
+
+// Close closes the connections to Redis
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
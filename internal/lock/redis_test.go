@@ -0,0 +1,75 @@
+package lock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLocksExclusively(t *testing.T) {
+	server := miniredis.RunT(t)
+	locker, err := NewRedis(RedisOptions{Addr: server.Addr(), Prefix: "rockets:lock:", TTL: 10 * time.Second,
+		Wait: 200 * time.Millisecond})
+	require.NoError(t, err)
+	defer locker.Close()
+
+	_, unlock, err := locker.Lock(context.Background(), "193270a9")
+	require.NoError(t, err)
+	assert.True(t, server.Exists("rockets:lock:193270a9"))
+
+	_, _, err = locker.Lock(context.Background(), "193270a9")
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "the lock is held")
+	_, unlockOther, err := locker.Lock(context.Background(), "5ac3f2d1")
+	require.NoError(t, err, "locks of other channels are independent")
+	unlockOther()
+
+	unlock()
+	_, unlock, err = locker.Lock(context.Background(), "193270a9")
+	require.NoError(t, err)
+	unlock()
+}
+
+func TestRedisLockExpires(t *testing.T) {
+	server := miniredis.RunT(t)
+	locker, err := NewRedis(RedisOptions{Addr: server.Addr(), TTL: time.Second, Wait: 200 * time.Millisecond})
+	require.NoError(t, err)
+	defer locker.Close()
+
+	_, crashed, err := locker.Lock(context.Background(), "193270a9")
+	require.NoError(t, err)
+
+	server.FastForward(time.Second)
+	_, unlock, err := locker.Lock(context.Background(), "193270a9")
+	require.NoError(t, err, "the lease of a failed holder expired")
+
+	crashed()
+	assert.True(t, server.Exists("193270a9"), "a holder whose lease expired can't release the next holder's lock")
+	unlock()
+	assert.False(t, server.Exists("193270a9"))
+}
+
+func TestRedisLockRenewed(t *testing.T) {
+	server := miniredis.RunT(t)
+	locker, err := NewRedis(RedisOptions{Addr: server.Addr(), TTL: 300 * time.Millisecond, Wait: 200 * time.Millisecond})
+	require.NoError(t, err)
+	defer locker.Close()
+
+	held, unlock, err := locker.Lock(context.Background(), "193270a9")
+	require.NoError(t, err)
+	defer unlock()
+
+	// The holder processes for longer than the lease, which is renewed meanwhile
+	server.FastForward(250 * time.Millisecond)
+	assert.Eventually(t, func() bool { return server.TTL("193270a9") > 100*time.Millisecond }, time.Second,
+		10*time.Millisecond)
+	assert.NoError(t, held.Err())
+
+	// The lease expired after all, and another consumer took the lock
+	server.Set("193270a9", "other")
+	assert.Eventually(t, func() bool { return held.Err() != nil }, time.Second, 10*time.Millisecond,
+		"the holder learns it lost the lock")
+}
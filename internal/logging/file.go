@@ -0,0 +1,127 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat suffixes rotated files, sorting them in rotation order
+const backupTimeFormat = "20060102T150405.000000000"
+
+// FileOptions configures a log file
+type FileOptions struct {
+	Path       string
+	MaxSize    int64         // Bytes written before the file is rotated, 0 for no limit
+	MaxAge     time.Duration // Time after which the file is rotated, 0 for no limit
+	MaxBackups int           // Rotated files kept, oldest first removed, 0 to keep them all
+}
+
+// File is a log file rotated by size and age, for deployments without a log collector. Rotated files are renamed
+// with the time of the rotation, e.g. rockets.log.20220202T193905.863370000. Safe for concurrent use
+type File struct {
+	opts FileOptions
+
+	mu     sync.Mutex
+	file   *os.File
+	size   int64
+	opened time.Time
+}
+
+// OpenFile opens the log file at opts.Path, appending to it when it exists. Call Close to release it
+func OpenFile(opts FileOptions) (*File, error) {
+	f := &File{opts: opts}
+	if err := f.open(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Write appends p to the file, rotating it first when it is full or too old
+func (f *File) Write(p []byte) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.full(len(p)) || f.expired() {
+		if err := f.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := f.file.Write(p)
+	f.size += int64(n)
+	return n, err
+}
+
+// Close closes the file
+func (f *File) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.file.Close()
+}
+
+// full tells if writing n more bytes would exceed the maximum size. A write is never split, and an empty file
+// accepts any write
+func (f *File) full(n int) bool {
+	return f.opts.MaxSize > 0 && f.size > 0 && f.size+int64(n) > f.opts.MaxSize
+}
+
+func (f *File) expired() bool {
+	return f.opts.MaxAge > 0 && time.Since(f.opened) > f.opts.MaxAge
+}
+
+func (f *File) open() error {
+	if err := os.MkdirAll(filepath.Dir(f.opts.Path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.opts.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to open log file: %w", err), file.Close())
+	}
+
+	f.file, f.size, f.opened = file, info.Size(), time.Now()
+	return nil
+}
+
+// rotate renames the current file with the time of the rotation, starts a new one and removes the extra backups
+func (f *File) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	backup := f.opts.Path + "." + time.Now().UTC().Format(backupTimeFormat)
+	if err := os.Rename(f.opts.Path, backup); err != nil {
+		return fmt.Errorf("failed to rotate log file: %w", err)
+	}
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	f.removeBackups()
+	return nil
+}
+
+// removeBackups deletes the oldest rotated files beyond the number to keep. Failures are ignored, as they only cost
+// disk space and there is no better place to report them than the log being written
+func (f *File) removeBackups() {
+	if f.opts.MaxBackups <= 0 {
+		return
+	}
+
+	backups, err := filepath.Glob(f.opts.Path + ".*")
+	if err != nil || len(backups) <= f.opts.MaxBackups {
+		return
+	}
+	slices.Sort(backups)
+	for _, backup := range backups[:len(backups)-f.opts.MaxBackups] {
+		_ = os.Remove(backup)
+	}
+}
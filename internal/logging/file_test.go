@@ -0,0 +1,35 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "rockets.log")
+
+	f, err := OpenFile(FileOptions{Path: path, MaxSize: 10, MaxBackups: 2})
+	require.NoError(t, err)
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		_, err := f.Write([]byte(line))
+		require.NoError(t, err)
+	}
+
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "fourth\n", string(current))
+
+	// The oldest backup, holding "first", was removed
+	backups, err := filepath.Glob(path + ".*")
+	require.NoError(t, err)
+	require.Len(t, backups, 2)
+	newest, err := os.ReadFile(backups[1])
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(newest))
+}
@@ -0,0 +1,160 @@
+// Package logging configures the structured logger of the service and carries contextual log fields in contexts
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// Formats of the log output
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+var (
+	// level is the minimum level of the default logger, which can be changed at runtime
+	level = new(slog.LevelVar)
+	// configured is the level set by Setup, restored when debug logging is toggled off
+	configured = new(slog.LevelVar)
+)
+
+// Setup installs the default slog logger, writing records of at least the given level ("debug", "info", "warn" or
+// "error") to w in the given format. The standard log package is redirected to it as well
+func Setup(w io.Writer, format, minLevel string) error {
+	lvl, err := ParseLevel(minLevel)
+	if err != nil {
+		return err
+	}
+	configured.Set(lvl)
+	level.Set(lvl)
+
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	switch format {
+	case FormatText:
+		handler = slog.NewTextHandler(w, opts)
+	case FormatJSON:
+		handler = slog.NewJSONHandler(w, opts)
+	default:
+		return fmt.Errorf("invalid log format %q: must be %s or %s", format, FormatText, FormatJSON)
+	}
+
+	slog.SetDefault(slog.New(contextHandler{handler}))
+	return nil
+}
+
+// ParseLevel parses a level name, ignoring case
+func ParseLevel(name string) (slog.Level, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(name)); err != nil {
+		return lvl, fmt.Errorf("invalid log level %q: must be debug, info, warn or error", name)
+	}
+	return lvl, nil
+}
+
+// Level returns the current minimum level of the default logger
+func Level() slog.Level {
+	return level.Level()
+}
+
+// SetLevel changes the minimum level of the default logger, e.g. to enable debug logs during an incident
+func SetLevel(lvl slog.Level) {
+	level.Set(lvl)
+}
+
+// Configure changes the level set by Setup, as on a configuration reload, and applies it
+func Configure(lvl slog.Level) {
+	configured.Set(lvl)
+	level.Set(lvl)
+}
+
+// ToggleDebug switches the default logger to debug level, or back to the level set by Setup when it already logs
+// debug records, and returns the new level
+func ToggleDebug() slog.Level {
+	next := slog.LevelDebug
+	if level.Level() <= slog.LevelDebug {
+		next = configured.Level()
+	}
+	level.Set(next)
+	return next
+}
+
+// Stack returns the stack of the calling goroutine as "function (file:line)" frames, innermost first, so that stack
+// traces are logged as a list instead of a multi-line string. skip is the number of callers to leave out
+func Stack(skip int) []string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip+2, pcs)
+
+	var stack []string
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+		if !more {
+			return stack
+		}
+	}
+}
+
+type (
+	attrsKey     struct{}
+	requestIDKey struct{}
+)
+
+// With returns a copy of ctx whose log records carry the given fields, as alternating keys and values like
+// slog.Logger.With. Fields are only added to records logged with the *Context functions of slog
+func With(ctx context.Context, args ...any) context.Context {
+	// Parse the arguments the same way slog does, so that slog.Attr values are accepted too
+	record := slog.NewRecord(time.Time{}, 0, "", 0)
+	record.Add(args...)
+
+	attrs := slices.Clip(attrsFrom(ctx))
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	return context.WithValue(ctx, attrsKey{}, attrs)
+}
+
+// WithRequestID returns a copy of ctx carrying the ID of the request being served, added to its log records as requestId
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return With(context.WithValue(ctx, requestIDKey{}, id), "requestId", id)
+}
+
+// RequestID returns the ID of the request being served, or an empty string outside requests
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func attrsFrom(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsKey{}).([]slog.Attr)
+	return attrs
+}
+
+// contextHandler adds the fields carried by the context of a record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if attrs := attrsFrom(ctx); len(attrs) > 0 {
+		record = record.Clone()
+		record.AddAttrs(attrs...)
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
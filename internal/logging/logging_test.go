@@ -0,0 +1,54 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWith(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(contextHandler{slog.NewJSONHandler(&buf, nil)})
+
+	ctx := With(context.Background(), "channel", "193270a9-c9cf-404a-8f83-838e71d9ae67")
+	ctx = With(ctx, slog.Int64("messageNumber", 7))
+	logger.InfoContext(ctx, "Rocket launched", "speed", 500)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "Rocket launched", record["msg"])
+	assert.Equal(t, "193270a9-c9cf-404a-8f83-838e71d9ae67", record["channel"])
+	assert.EqualValues(t, 7, record["messageNumber"])
+	assert.EqualValues(t, 500, record["speed"])
+}
+
+func TestSetup(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	assert.Error(t, Setup(&bytes.Buffer{}, "xml", "info"))
+	assert.Error(t, Setup(&bytes.Buffer{}, FormatJSON, "verbose"))
+
+	var buf bytes.Buffer
+	require.NoError(t, Setup(&buf, FormatText, "warn"))
+	slog.Info("hidden")
+	slog.Warn("shown")
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "msg=shown")
+}
+
+func TestToggleDebug(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	require.NoError(t, Setup(&bytes.Buffer{}, FormatText, "warn"))
+	assert.Equal(t, slog.LevelDebug, ToggleDebug())
+	assert.Equal(t, slog.LevelWarn, ToggleDebug())
+
+	SetLevel(slog.LevelError)
+	assert.Equal(t, slog.LevelError, Level())
+	assert.Equal(t, slog.LevelDebug, ToggleDebug())
+}
@@ -0,0 +1,60 @@
+// Package metrics records operational metrics of the service behind a backend-agnostic interface
+package metrics
+
+import "time"
+
+// Reasons a message is dropped or ignored instead of being applied
+const (
+	ReasonQueueFull   = "queue_full"   // Rejected by a full processing queue
+	ReasonDuplicate   = "duplicate"    // Same number as the latest applied message of its channel
+	ReasonOutOfOrder  = "out_of_order" // Older than the latest applied message of its channel
+	ReasonUnknownType = "unknown_type" // Unknown message type
+	ReasonInvalid     = "invalid"      // Failed validation for another reason
+)
+
+// Sources of recovered panics
+const (
+	SourceHTTP       = "http"       // A request handler
+	SourceProcessing = "processing" // The message processor
+)
+
+// Reasons a request is denied before reaching its handler
+const (
+	DeniedAddress   = "address"    // Client address outside the allowlist
+	DeniedRateLimit = "rate_limit" // Caller over its requests per minute
+	DeniedQuota     = "quota"      // Caller over its daily quota
+	DeniedGroupRate = "group_rate" // Route group over its requests per second
+	DeniedInFlight  = "in_flight"  // Route group over its requests in flight
+)
+
+// Recorder records application metrics. Implementations must be safe for concurrent use
+type Recorder interface {
+	// AnomalyDetected counts an implausible telemetry transition of the given kind
+	AnomalyDetected(kind string)
+	// RocketReaped counts a stale rocket removed by the retention policy, by action and rocket status
+	RocketReaped(action, status string)
+	// MessageProcessed observes the delay between the time of a message and its processing
+	MessageProcessed(lag time.Duration)
+	// MessageDropped counts a message that was dropped or ignored, for one of the Reason values. Channels are left
+	// out, as clients can create them at will: the counters of a channel are at GET /rockets/{id}/metrics
+	MessageDropped(reason string)
+	// PanicRecovered counts a panic recovered from one of the Source values
+	PanicRecovered(source string)
+	// RequestDenied counts a request to a route denied for one of the Denied values
+	RequestDenied(route, reason string)
+}
+
+// Nop is a Recorder discarding every metric, for tests and deployments without metrics
+type Nop struct{}
+
+func (Nop) AnomalyDetected(string) {}
+
+func (Nop) RocketReaped(string, string) {}
+
+func (Nop) MessageProcessed(time.Duration) {}
+
+func (Nop) MessageDropped(string) {}
+
+func (Nop) PanicRecovered(string) {}
+
+func (Nop) RequestDenied(string, string) {}
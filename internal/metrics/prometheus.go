@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name
+const namespace = "rockets"
+
+// Prometheus is a Recorder exposing metrics in the Prometheus text format.
+// It uses its own registry, so that only the metrics of this service and of the Go runtime are exported
+type Prometheus struct {
+	registry  *prometheus.Registry
+	anomalies *prometheus.CounterVec
+	reaped    *prometheus.CounterVec
+	lag       prometheus.Histogram
+	dropped   *prometheus.CounterVec
+	panics    *prometheus.CounterVec
+	denied    *prometheus.CounterVec
+}
+
+// NewPrometheus creates a Prometheus recorder with the Go runtime and process collectors registered
+func NewPrometheus() *Prometheus {
+	p := &Prometheus{
+		registry: prometheus.NewRegistry(),
+		anomalies: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "anomalies_total",
+			Help:      "Implausible telemetry transitions detected, by kind.",
+		}, []string{"kind"}),
+		reaped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reaped_total",
+			Help:      "Stale rockets archived or deleted by the retention policy, by action and rocket status.",
+		}, []string{"action", "status"}),
+		lag: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "processing_lag_seconds",
+			Help:      "Delay between the time of a message and its processing.",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 4, 12), // 1ms to about 70 minutes
+		}),
+		dropped: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "messages_dropped_total",
+			Help:      "Messages dropped or ignored instead of being applied, by reason.",
+		}, []string{"reason"}),
+		panics: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "panics_total",
+			Help:      "Panics recovered from request handlers and message processing, by source.",
+		}, []string{"source"}),
+		denied: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_denied_total",
+			Help:      "Requests denied before reaching their handler, by route and reason.",
+		}, []string{"route", "reason"}),
+	}
+
+	p.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		p.anomalies,
+		p.reaped,
+		p.lag,
+		p.dropped,
+		p.panics,
+		p.denied,
+	)
+
+	return p
+}
+
+// Gatherer returns the registry of the recorder, e.g. to export its metrics by other means than scraping
+func (p *Prometheus) Gatherer() prometheus.Gatherer {
+	return p.registry
+}
+
+// Handler serves the registered metrics for scraping
+func (p *Prometheus) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+func (p *Prometheus) AnomalyDetected(kind string) {
+	p.anomalies.WithLabelValues(kind).Inc()
+}
+
+func (p *Prometheus) RocketReaped(action, status string) {
+	p.reaped.WithLabelValues(action, status).Inc()
+}
+
+func (p *Prometheus) MessageProcessed(lag time.Duration) {
+	p.lag.Observe(lag.Seconds())
+}
+
+func (p *Prometheus) MessageDropped(reason string) {
+	p.dropped.WithLabelValues(reason).Inc()
+}
+
+func (p *Prometheus) PanicRecovered(source string) {
+	p.panics.WithLabelValues(source).Inc()
+}
+
+func (p *Prometheus) RequestDenied(route, reason string) {
+	p.denied.WithLabelValues(route, reason).Inc()
+}
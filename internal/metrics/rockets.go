@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// rocketCollector exports the speed and status of individual rockets, read from a source on each scrape.
+// Only the most recently updated rockets are exported, to keep the number of series bounded
+type rocketCollector struct {
+	source func() []*models.Rocket
+	limit  int
+
+	speed   *prometheus.Desc
+	status  *prometheus.Desc
+	omitted *prometheus.Desc
+}
+
+// ExportRockets exports per-rocket speed and status gauges for up to limit of the rockets listed by source,
+// most recently updated first. Source is called on every scrape and must be safe for concurrent use
+func (p *Prometheus) ExportRockets(source func() []*models.Rocket, limit int) {
+	p.registry.MustRegister(&rocketCollector{
+		source: source,
+		limit:  limit,
+		speed: prometheus.NewDesc(prometheus.BuildFQName(namespace, "rocket", "speed"),
+			"Current speed of a rocket, in km/h.", []string{"id", "type", "mission"}, nil),
+		status: prometheus.NewDesc(prometheus.BuildFQName(namespace, "rocket", "status"),
+			"Current status of a rocket, always 1.", []string{"id", "status"}, nil),
+		omitted: prometheus.NewDesc(prometheus.BuildFQName(namespace, "rocket", "gauges_omitted"),
+			"Rockets left out of the per-rocket gauges by the cardinality limit.", nil, nil),
+	})
+}
+
+func (c *rocketCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.speed
+	ch <- c.status
+	ch <- c.omitted
+}
+
+func (c *rocketCollector) Collect(ch chan<- prometheus.Metric) {
+	rockets := c.source()
+	slices.SortFunc(rockets, func(a, b *models.Rocket) int {
+		return cmp.Or(b.LastUpdated.Compare(a.LastUpdated), cmp.Compare(a.ID, b.ID))
+	})
+
+	omitted := max(len(rockets)-c.limit, 0)
+	rockets = rockets[:len(rockets)-omitted]
+
+	for _, rocket := range rockets {
+		ch <- prometheus.MustNewConstMetric(c.speed, prometheus.GaugeValue, float64(rocket.Speed),
+			rocket.ID, rocket.Type, rocket.Mission)
+		ch <- prometheus.MustNewConstMetric(c.status, prometheus.GaugeValue, 1, rocket.ID, string(rocket.Status))
+	}
+	ch <- prometheus.MustNewConstMetric(c.omitted, prometheus.GaugeValue, float64(omitted))
+}
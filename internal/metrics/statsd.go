@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// StatsDOptions configures a StatsD recorder
+type StatsDOptions struct {
+	Addr   string // host:port of the agent, usually localhost:8125
+	Prefix string // Prepended to every metric name, e.g. "rockets."
+	Tags   bool   // Send labels as DogStatsD tags; plain StatsD has no tags, so labels are appended to the name instead
+}
+
+// StatsD is a Recorder sending metrics to a StatsD or DogStatsD agent over UDP, for deployments without Prometheus.
+// Metrics are sent as they are recorded and lost if the agent is unreachable, as usual with StatsD
+type StatsD struct {
+	conn net.Conn
+	opts StatsDOptions
+}
+
+// label is a metric dimension, sent as a tag or appended to the metric name
+type label struct {
+	name, value string
+}
+
+// NewStatsD creates a recorder sending to the agent at opts.Addr. Call Close to release the socket
+func NewStatsD(opts StatsDOptions) (*StatsD, error) {
+	conn, err := net.Dial("udp", opts.Addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach StatsD agent at %s: %w", opts.Addr, err)
+	}
+	return &StatsD{conn: conn, opts: opts}, nil
+}
+
+// Close releases the socket of the recorder
+func (s *StatsD) Close() error {
+	return s.conn.Close()
+}
+
+func (s *StatsD) AnomalyDetected(kind string) {
+	s.send("anomalies", "1|c", label{"kind", kind})
+}
+
+func (s *StatsD) RocketReaped(action, status string) {
+	s.send("rockets_reaped", "1|c", label{"action", action}, label{"status", status})
+}
+
+func (s *StatsD) MessageProcessed(lag time.Duration) {
+	s.send("processing_lag", strconv.FormatInt(lag.Milliseconds(), 10)+"|ms")
+}
+
+func (s *StatsD) MessageDropped(reason string) {
+	s.send("messages_dropped", "1|c", label{"reason", reason})
+}
+
+func (s *StatsD) PanicRecovered(source string) {
+	s.send("panics", "1|c", label{"source", source})
+}
+
+func (s *StatsD) RequestDenied(route, reason string) {
+	s.send("requests_denied", "1|c", label{"reason", reason}, label{"route", route})
+}
+
+// send writes a single metric line, e.g. "rockets.anomalies:1|c|#kind:SPEED_JUMP"
+func (s *StatsD) send(name, value string, labels ...label) {
+	var line strings.Builder
+	line.WriteString(s.opts.Prefix)
+	line.WriteString(name)
+
+	if !s.opts.Tags {
+		for _, l := range labels {
+			line.WriteString(".")
+			line.WriteString(sanitize(l.value))
+		}
+	}
+
+	line.WriteString(":")
+	line.WriteString(value)
+
+	if s.opts.Tags && len(labels) > 0 {
+		line.WriteString("|#")
+		for i, l := range labels {
+			if i > 0 {
+				line.WriteString(",")
+			}
+			line.WriteString(l.name + ":" + sanitize(l.value))
+		}
+	}
+
+	// Best-effort, like the protocol: a missing agent must not slow down or fail processing
+	_, _ = s.conn.Write([]byte(line.String()))
+}
+
+// sanitize replaces the characters the StatsD line format reserves
+func sanitize(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ':', '|', '@', '#', ',', '\n':
+			return '_'
+		}
+		return r
+	}, value)
+}
@@ -0,0 +1,45 @@
+package metrics
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsD(t *testing.T) {
+	agent, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer agent.Close()
+
+	receive := func() string {
+		buf := make([]byte, 512)
+		require.NoError(t, agent.SetReadDeadline(time.Now().Add(time.Second)))
+		n, _, err := agent.ReadFrom(buf)
+		require.NoError(t, err)
+		return string(buf[:n])
+	}
+
+	t.Run("DogStatsD tags", func(t *testing.T) {
+		s, err := NewStatsD(StatsDOptions{Addr: agent.LocalAddr().String(), Prefix: "rockets.", Tags: true})
+		require.NoError(t, err)
+		defer s.Close()
+
+		s.MessageDropped(ReasonDuplicate)
+		assert.Equal(t, "rockets.messages_dropped:1|c|#reason:duplicate", receive())
+
+		s.MessageProcessed(1500 * time.Millisecond)
+		assert.Equal(t, "rockets.processing_lag:1500|ms", receive())
+	})
+
+	t.Run("plain StatsD", func(t *testing.T) {
+		s, err := NewStatsD(StatsDOptions{Addr: agent.LocalAddr().String(), Prefix: "rockets."})
+		require.NoError(t, err)
+		defer s.Close()
+
+		s.RocketReaped("archive", "EXPLODED")
+		assert.Equal(t, "rockets.rockets_reaped.archive.EXPLODED:1|c", receive())
+	})
+}
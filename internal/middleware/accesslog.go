@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessLog logs every request with its method, path, status, latency, response size and client IP, along with the
+// request ID when registered before RequestID. Requests to skipPaths, such as health checks and metrics scrapes, are
+// left out to keep the logs readable
+func AccessLog(skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		path := c.Request.URL.Path
+		if slices.Contains(skipPaths, path) {
+			return
+		}
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+
+		slog.LogAttrs(c.Request.Context(), level, "Request served",
+			slog.String("method", c.Request.Method),
+			slog.String("path", path),
+			slog.Int("status", status),
+			slog.Float64("latencyMs", float64(time.Since(start).Microseconds())/1000),
+			slog.Int("bytes", max(c.Writer.Size(), 0)),
+			slog.String("clientIP", c.ClientIP()),
+		)
+	}
+}
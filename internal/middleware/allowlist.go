@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ParseNetworks parses CIDR networks, such as 10.20.0.0/16; single addresses stand for themselves
+func ParseNetworks(values []string) ([]netip.Prefix, error) {
+	networks := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if !strings.Contains(value, "/") {
+			addr, err := netip.ParseAddr(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid network %q: %w", value, err)
+			}
+			networks = append(networks, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+
+		network, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", value, err)
+		}
+		networks = append(networks, network.Masked())
+	}
+	return networks, nil
+}
+
+// Allowlist only lets through requests from clients of networks, counting and logging the others. The client
+// address is taken from forwarding headers only when the request comes from a trusted proxy. Every client is allowed
+// while networks is empty
+func Allowlist(networks []netip.Prefix, recorder metrics.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(networks) == 0 {
+			c.Next()
+			return
+		}
+
+		addr, err := netip.ParseAddr(c.ClientIP())
+		if err == nil && allowed(networks, addr.Unmap()) {
+			c.Next()
+			return
+		}
+
+		recorder.RequestDenied(c.FullPath(), metrics.DeniedAddress)
+		slog.WarnContext(c.Request.Context(), "Denied request from address outside the allowlist",
+			"clientIP", c.ClientIP(), "path", c.Request.URL.Path)
+		problem.Abort(c, http.StatusForbidden, models.ErrorCodeForbidden, "Forbidden",
+			"Requests from this address are not allowed")
+	}
+}
+
+// allowed tells whether addr belongs to one of networks
+func allowed(networks []netip.Prefix, addr netip.Addr) bool {
+	for _, network := range networks {
+		if network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
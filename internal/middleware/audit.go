@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/audit"
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Audit records every request that may change the service state to the audit log: requests with a method other than
+// GET, HEAD or OPTIONS, to an existing route. Routes in skipPaths, such as POST queries that change nothing, are left
+// out. The payload is recorded as the SHA-256 digest of the request body as sent, compressed or not
+func Audit(log *audit.Log, skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			c.Next()
+			return
+		}
+
+		body := &digestReader{ReadCloser: c.Request.Body, hash: sha256.New()}
+		if body.ReadCloser != nil {
+			c.Request.Body = body
+		}
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" || slices.Contains(skipPaths, route) {
+			return
+		}
+
+		entry := models.AuditEntry{
+			Time:      time.Now().UTC(),
+			Actor:     c.GetString(ActorKey),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			Status:    c.Writer.Status(),
+			RequestID: c.GetString(RequestIDKey),
+			ClientIP:  c.ClientIP(),
+		}
+		if body.ReadCloser != nil {
+			// Handlers may stop reading early, e.g. on a malformed payload; the digest covers the whole body
+			_, _ = io.Copy(io.Discard, body)
+			if body.size > 0 {
+				entry.PayloadDigest = "sha256:" + hex.EncodeToString(body.hash.Sum(nil))
+			}
+		}
+
+		log.Record(c.Request.Context(), entry)
+	}
+}
+
+// digestReader hashes a request body as it is read
+type digestReader struct {
+	io.ReadCloser
+	hash hash.Hash
+	size int64
+}
+
+func (r *digestReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	return n, err
+}
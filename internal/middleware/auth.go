@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/auth"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ActorKey is the Gin context key holding the identity of the authenticated caller
+const ActorKey = "actor"
+
+// APIKeyHeader carries the API key of a caller, as an alternative to a bearer token in the Authorization header
+const APIKeyHeader = "X-API-Key"
+
+// Auth authenticates callers with their API keys and keeps them within their rate limits and quotas
+type Auth struct {
+	keys     auth.KeyStore
+	quotas   *ratelimit.Quotas
+	recorder metrics.Recorder
+}
+
+// NewAuth creates the authentication of keys. Callers are unlimited while quotas is nil
+func NewAuth(keys auth.KeyStore, quotas *ratelimit.Quotas, recorder metrics.Recorder) *Auth {
+	return &Auth{keys: keys, quotas: quotas, recorder: recorder}
+}
+
+// Admin guards administrative endpoints with API keys granted the admin scope.
+// When no such key is configured the endpoints are disabled altogether
+func (a *Auth) Admin() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.keys.Enabled(auth.ScopeAdmin) {
+			problem.Abort(c, http.StatusForbidden, models.ErrorCodeAdminDisabled, "Admin endpoints disabled",
+				"Set ADMIN_TOKEN or admin API keys to enable administrative endpoints")
+			return
+		}
+
+		a.authenticate(c, auth.ScopeAdmin)
+	}
+}
+
+// Stream guards the issuance of stream tokens with API keys granted the stream scope.
+// When no such key is configured tokens can't be issued
+func (a *Auth) Stream() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.keys.Enabled(auth.ScopeStream) {
+			problem.Abort(c, http.StatusForbidden, models.ErrorCodeForbidden, "Stream tokens disabled",
+				"Configure API keys with the stream scope to issue stream tokens")
+			return
+		}
+
+		a.authenticate(c, auth.ScopeStream)
+	}
+}
+
+// Ingest guards the submission of messages with API keys granted the ingest scope.
+// Submissions stay open to anonymous callers until such a key is configured
+func (a *Auth) Ingest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !a.keys.Enabled(auth.ScopeIngest) {
+			c.Next()
+			return
+		}
+
+		a.authenticate(c, auth.ScopeIngest)
+	}
+}
+
+// authenticate resolves the key of the caller and checks it grants scope, attaching the identity of the caller to the
+// request for handlers, logs and the audit log
+func (a *Auth) authenticate(c *gin.Context, scope auth.Scope) {
+	key := c.GetHeader(APIKeyHeader)
+	if bearer, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); key == "" && found {
+		key = bearer
+	}
+
+	id, found := a.keys.Lookup(c.Request.Context(), key)
+	if key == "" || !found {
+		problem.Abort(c, http.StatusUnauthorized, models.ErrorCodeUnauthorized, "Unauthorized",
+			"A valid API key must be provided in the X-API-Key header or as a bearer token in the Authorization header")
+		return
+	}
+	if !id.Allows(scope) {
+		problem.Abort(c, http.StatusForbidden, models.ErrorCodeForbidden, "Forbidden",
+			"The credentials are not allowed to use this endpoint")
+		return
+	}
+
+	c.Set(ActorKey, id.Name)
+	ctx := auth.WithIdentity(c.Request.Context(), id)
+	c.Request = c.Request.WithContext(logging.With(ctx, "actor", id.Name))
+
+	if a.quotas != nil && !a.limit(c, id) {
+		return
+	}
+	c.Next()
+}
+
+// limit counts the request against the limits of the caller, announced in the X-RateLimit headers, and rejects it
+// once they are exceeded
+func (a *Auth) limit(c *gin.Context, id auth.Identity) bool {
+	decision := a.quotas.Take(id.Name, ratelimit.Limits{PerMinute: id.RateLimit, PerDay: id.DailyQuota}, time.Now())
+	if decision.Limit > 0 {
+		c.Header("X-RateLimit-Limit", strconv.Itoa(decision.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+		c.Header("X-RateLimit-Reset", strconv.FormatInt(decision.Reset.Unix(), 10))
+	}
+	if decision.Allowed {
+		return true
+	}
+
+	retryAfter := max(int(math.Ceil(time.Until(decision.Reset).Seconds())), 1)
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+
+	reason, detail := metrics.DeniedRateLimit, fmt.Sprintf("The limit of %d requests per minute is exceeded", decision.Limit)
+	if decision.Exceeded == ratelimit.ExceededQuota {
+		reason, detail = metrics.DeniedQuota, fmt.Sprintf("The daily quota of %d requests is exhausted", decision.Limit)
+	}
+	a.recorder.RequestDenied(c.FullPath(), reason)
+	slog.WarnContext(c.Request.Context(), "Request over the limits of its caller", "reason", reason)
+	problem.Abort(c, http.StatusTooManyRequests, models.ErrorCodeRateLimited, "Too many requests",
+		fmt.Sprintf("%s. Retry in %s.", detail, time.Duration(retryAfter)*time.Second))
+	return false
+}
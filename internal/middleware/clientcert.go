@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClientCert identifies callers by the client certificate verified during the TLS handshake, naming them after the
+// common name of its subject; API keys checked afterwards take over the identity. When required, requests without a
+// verified certificate are rejected, e.g. to keep ingestion to producers of the launch network while the TLS server
+// only verifies certificates when given
+func ClientCert(required bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.TLS == nil || len(c.Request.TLS.VerifiedChains) == 0 {
+			if required {
+				problem.Abort(c, http.StatusUnauthorized, models.ErrorCodeUnauthorized, "Unauthorized",
+					"A client certificate issued by a trusted CA must be presented")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		name := c.Request.TLS.VerifiedChains[0][0].Subject.CommonName
+		c.Set(ActorKey, name)
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "clientCert", name))
+		c.Next()
+	}
+}
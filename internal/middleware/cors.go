@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// CORSOptions configures cross-origin access for browser clients
+type CORSOptions struct {
+	AllowedOrigins []string      // Origins allowed to call the API; "*" allows any origin
+	AllowedMethods []string      // Defaults to the methods used by the API
+	AllowedHeaders []string      // Defaults to the headers used by the API
+	MaxAge         time.Duration // How long browsers may cache preflight responses
+}
+
+// CORS answers preflight requests and sets the CORS headers of cross-origin requests
+func CORS(opts CORSOptions) gin.HandlerFunc {
+	config := cors.Config{
+		AllowMethods: opts.AllowedMethods,
+		AllowHeaders: opts.AllowedHeaders,
+		// Let browser clients read the headers used for conditional requests, resumption and rate limits
+		ExposeHeaders: []string{"ETag", "Last-Modified", RequestIDHeader,
+			"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After"},
+		MaxAge: opts.MaxAge,
+	}
+
+	if len(opts.AllowedOrigins) == 1 && opts.AllowedOrigins[0] == "*" {
+		config.AllowAllOrigins = true
+	} else {
+		config.AllowOrigins = opts.AllowedOrigins
+	}
+
+	if len(config.AllowMethods) == 0 {
+		config.AllowMethods = []string{"GET", "POST", "PATCH", "DELETE", "OPTIONS"}
+	}
+	if len(config.AllowHeaders) == 0 {
+		config.AllowHeaders = []string{
+			"Origin", "Content-Type", "Content-Encoding", "Accept", "Authorization", APIKeyHeader,
+			"If-None-Match", "If-Modified-Since", "Last-Event-ID",
+		}
+	}
+	if config.MaxAge == 0 {
+		config.MaxAge = 12 * time.Hour
+	}
+
+	return cors.New(config)
+}
@@ -0,0 +1,124 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/cluster"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ForwardedByHeader names the instance that forwarded a request to the owner of its channel, which serves it
+// without forwarding it again even if the members disagree on the owner
+const ForwardedByHeader = "X-Forwarded-By-Instance"
+
+// PartialHeader marks the responses spanning several rockets that only cover the channels of the instance serving
+// them, which it names, as the other members of the cluster are not asked
+const PartialHeader = "X-Partial-Results"
+
+// maxPeekedBodySize bounds the part of a submission read to find its channel
+const maxPeekedBodySize = 1 << 20
+
+// Forward proxies the requests about a channel owned by another member of the cluster to that member, and serves
+// the others. The channel of a request is returned by channel, empty when it is unknown; such requests are served
+// locally and rejected by the handlers if invalid. Register it before any middleware wrapping the response writer.
+// Nothing is forwarded without a cluster
+func Forward(ring *cluster.Ring, channel func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ring == nil || c.GetHeader(ForwardedByHeader) != "" {
+			c.Next()
+			return
+		}
+		ch := channel(c)
+		if ch == "" || ring.Owns(ch) {
+			c.Next()
+			return
+		}
+
+		owner := ring.Owner(ch)
+		proxy := &httputil.ReverseProxy{
+			Rewrite: func(r *httputil.ProxyRequest) {
+				r.SetURL(owner.URL)
+				r.SetXForwarded()
+				r.Out.Header.Set(ForwardedByHeader, ring.Self().Name)
+				r.Out.Header.Set(RequestIDHeader, c.GetString(RequestIDKey))
+			},
+			// Streams and long polls are relayed as they are written
+			FlushInterval: -1,
+			// Headers set by the middlewares of this instance, such as the request ID, are not repeated
+			ModifyResponse: func(resp *http.Response) error {
+				for name := range c.Writer.Header() {
+					resp.Header.Del(name)
+				}
+				return nil
+			},
+			ErrorHandler: func(_ http.ResponseWriter, r *http.Request, err error) {
+				slog.WarnContext(r.Context(), "Failed to forward request", "channel", ch, "owner", owner.Name, "error", err)
+				problem.Respond(c, http.StatusBadGateway, models.ErrorCodeOwnerUnavailable, "Owner unavailable",
+					fmt.Sprintf("The channel belongs to instance %s, which could not be reached", owner.Name))
+			},
+		}
+		proxy.ServeHTTP(c.Writer, c.Request)
+		c.Abort()
+	}
+}
+
+// Partial marks the responses of a route spanning several rockets as covering only the channels of this instance.
+// Nothing is marked without a cluster
+func Partial(ring *cluster.Ring) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if ring != nil {
+			c.Header(PartialHeader, ring.Self().Name)
+		}
+		c.Next()
+	}
+}
+
+// ParamChannel returns the channel of a request from its path parameter name, e.g. the ID of a rocket
+func ParamChannel(name string) func(c *gin.Context) string {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// MessageChannel returns the channel of the message submitted in the body of a request, compressed or not. The body
+// is left unchanged for the handlers or the owner
+func MessageChannel(c *gin.Context) string {
+	peeked, err := io.ReadAll(io.LimitReader(c.Request.Body, maxPeekedBodySize))
+	c.Request.Body = readCloser{Reader: io.MultiReader(bytes.NewReader(peeked), c.Request.Body), Closer: c.Request.Body}
+	if err != nil {
+		return ""
+	}
+
+	var body io.Reader = bytes.NewReader(peeked)
+	if strings.EqualFold(c.GetHeader("Content-Encoding"), "gzip") {
+		if body, err = gzip.NewReader(body); err != nil {
+			return ""
+		}
+	}
+	var msg struct {
+		Metadata struct {
+			Channel string `json:"channel"`
+		} `json:"metadata"`
+	}
+	if err := json.NewDecoder(body).Decode(&msg); err != nil {
+		return ""
+	}
+	return msg.Metadata.Channel
+}
+
+// readCloser reads from Reader and closes Closer
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
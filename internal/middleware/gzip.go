@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"net/http"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Decompress transparently inflates request bodies sent with Content-Encoding: gzip. Unless maxSize is zero, bodies are
+// limited to maxSize bytes both as sent and once inflated, so that a small gzip bomb can't exhaust memory: reading
+// beyond fails with *http.MaxBytesError, which handlers answer with 413 Payload Too Large
+func Decompress(maxSize int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.EqualFold(c.GetHeader("Content-Encoding"), "gzip") {
+			c.Next()
+			return
+		}
+
+		body := c.Request.Body
+		if maxSize > 0 {
+			body = http.MaxBytesReader(c.Writer, body, maxSize)
+		}
+		reader, err := gzip.NewReader(body)
+		if err != nil {
+			problem.Abort(c, http.StatusBadRequest, models.ErrorCodeInvalidRequestBody, "Invalid request body",
+				"The request body is declared as gzip but could not be decompressed")
+			return
+		}
+		defer reader.Close()
+
+		c.Request.Body = reader
+		if maxSize > 0 {
+			c.Request.Body = http.MaxBytesReader(c.Writer, reader, maxSize)
+		}
+		c.Request.Header.Del("Content-Encoding")
+		c.Request.Header.Del("Content-Length")
+		c.Request.ContentLength = -1
+
+		c.Next()
+	}
+}
+
+// Compress gzips responses for clients that send Accept-Encoding: gzip.
+// Meant for endpoints returning large payloads, small responses gain little from it
+func Compress() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		AddVary(c, "Accept-Encoding")
+
+		if !acceptsGzip(c.GetHeader("Accept-Encoding")) || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &gzipWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer writer.close()
+
+		c.Next()
+	}
+}
+
+// acceptsGzip reports whether an Accept-Encoding header allows gzip
+func acceptsGzip(acceptEncoding string) bool {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(coding), ";")
+		if strings.EqualFold(strings.TrimSpace(name), "gzip") {
+			return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// gzipWriter compresses the response body. Compression starts with the first byte of body,
+// so that responses without one (such as 304 Not Modified) are left untouched
+type gzipWriter struct {
+	gin.ResponseWriter
+	gz *gzip.Writer
+}
+
+// start switches the response to gzip, unless headers are already sent or the response has no body
+func (w *gzipWriter) start() {
+	if w.gz != nil || w.ResponseWriter.Written() {
+		return
+	}
+
+	switch status := w.Status(); {
+	case status == http.StatusNoContent, status == http.StatusNotModified, status < http.StatusOK:
+		return
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return
+	}
+	header.Set("Content-Encoding", "gzip")
+	header.Del("Content-Length")
+
+	w.gz = gzip.NewWriter(w.ResponseWriter)
+}
+
+func (w *gzipWriter) Write(data []byte) (int, error) {
+	w.start()
+	if w.gz == nil {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.gz.Write(data)
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipWriter) WriteHeaderNow() {
+	w.start()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush sends the data compressed so far, keeping streamed responses such as exports progressive
+func (w *gzipWriter) Flush() {
+	w.start()
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *gzipWriter) close() {
+	if w.gz != nil {
+		_ = w.gz.Close()
+	}
+}
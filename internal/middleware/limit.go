@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+	"github.com/ahernandez9/rockets/internal/ratelimit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LimitOptions bounds the load of a group of routes; zero values are unlimited
+type LimitOptions struct {
+	Rate        float64 // Requests per second, on average
+	Burst       int     // Requests accepted at once above Rate; Rate rounded up by default
+	MaxInFlight int     // Requests handled concurrently
+}
+
+// groupLimiter enforces the LimitOptions of a group of routes
+type groupLimiter struct {
+	opts     LimitOptions
+	bucket   *ratelimit.Bucket // nil without a rate
+	inFlight chan struct{}     // Semaphore; nil without a concurrency limit
+}
+
+func newGroupLimiter(opts LimitOptions) *groupLimiter {
+	limiter := &groupLimiter{opts: opts}
+	if opts.Rate > 0 {
+		burst := opts.Burst
+		if burst <= 0 {
+			burst = int(math.Ceil(opts.Rate))
+		}
+		limiter.bucket = ratelimit.NewBucket(opts.Rate, burst)
+	}
+	if opts.MaxInFlight > 0 {
+		limiter.inFlight = make(chan struct{}, opts.MaxInFlight)
+	}
+	return limiter
+}
+
+// Limiter sheds the load above the limits of groups of routes, before it exhausts the message queue or the
+// repository: 429 above the rate of the group, 503 above its requests in flight. Limits can be changed while serving
+type Limiter struct {
+	recorder metrics.Recorder
+
+	mu     sync.Mutex
+	groups atomic.Pointer[map[string]*groupLimiter]
+}
+
+// NewLimiter creates a limiter enforcing limits by group name; groups left out or without limits are unlimited
+func NewLimiter(limits map[string]LimitOptions, recorder metrics.Recorder) *Limiter {
+	l := &Limiter{recorder: recorder}
+	l.SetLimits(limits)
+	return l
+}
+
+// SetLimits replaces the limits of every group. Groups whose limits are unchanged keep their state, while the
+// requests in flight of changed groups are no longer counted against the new limits
+func (l *Limiter) SetLimits(limits map[string]LimitOptions) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var current map[string]*groupLimiter
+	if groups := l.groups.Load(); groups != nil {
+		current = *groups
+	}
+	groups := make(map[string]*groupLimiter, len(limits))
+	for name, opts := range limits {
+		switch limiter, ok := current[name]; {
+		case opts.Rate <= 0 && opts.MaxInFlight <= 0:
+		case ok && limiter.opts == opts:
+			groups[name] = limiter
+		default:
+			groups[name] = newGroupLimiter(opts)
+		}
+	}
+	l.groups.Store(&groups)
+}
+
+// Handler limits the group of each route, named by group. Routes of groups without limits, such as health checks
+// when group returns an empty name, are never shed
+func (l *Limiter) Handler(group func(c *gin.Context) string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limiter, ok := (*l.groups.Load())[group(c)]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		if limiter.bucket != nil {
+			if ok, wait := limiter.bucket.Take(time.Now()); !ok {
+				retryAfter := max(int(math.Ceil(wait.Seconds())), 1)
+				shed(c, l.recorder, metrics.DeniedGroupRate, http.StatusTooManyRequests, models.ErrorCodeRateLimited,
+					"Too many requests", "The service receives more requests than it accepts", retryAfter)
+				return
+			}
+		}
+
+		if limiter.inFlight != nil {
+			select {
+			case limiter.inFlight <- struct{}{}:
+				defer func() { <-limiter.inFlight }()
+			default:
+				shed(c, l.recorder, metrics.DeniedInFlight, http.StatusServiceUnavailable, models.ErrorCodeOverloaded,
+					"Service overloaded", "The service handles as many requests as it can", 1)
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// shed rejects a request above the limits of its group
+func shed(c *gin.Context, recorder metrics.Recorder, reason string, status int, code models.ErrorCode, title,
+	detail string, retryAfter int) {
+	recorder.RequestDenied(c.FullPath(), reason)
+	slog.DebugContext(c.Request.Context(), "Shed request", "path", c.Request.URL.Path, "reason", reason)
+
+	c.Header("Retry-After", strconv.Itoa(retryAfter))
+	problem.Abort(c, status, code, title, fmt.Sprintf("%s. Retry in %s.", detail, time.Duration(retryAfter)*time.Second))
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LongLived lifts the read and write timeouts of the server for routes streaming for longer, such as event streams,
+// streamed ingestion, long polling and exports. Register it before any middleware wrapping the response writer
+func LongLived() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		controller := http.NewResponseController(c.Writer)
+		// Not supported by test recorders, which have no deadlines anyway
+		_ = controller.SetReadDeadline(time.Time{})
+		_ = controller.SetWriteDeadline(time.Time{})
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Recovery replaces Gin's recovery: panics of handlers are turned into problem details 500 responses, logged with
+// their stack, counted and reported with the method and route of the request. Register it after RequestID so that
+// responses, logs and reports carry the request ID
+func Recovery(reporter errreport.Reporter, recorder metrics.Recorder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// Handlers abort responses on purpose with this value, e.g. when the client went away
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+
+			slog.ErrorContext(c.Request.Context(), "Panic serving request", "method", c.Request.Method,
+				"path", c.Request.URL.Path, "panic", recovered, "stack", logging.Stack(0))
+			recorder.PanicRecovered(metrics.SourceHTTP)
+			reporter.ReportPanic(c.Request.Context(), recovered, map[string]string{
+				"method": c.Request.Method,
+				"route":  c.FullPath(),
+			})
+
+			problem.Abort(c, http.StatusInternalServerError, models.ErrorCodeInternal, "Internal server error",
+				"An unexpected error occurred. Please try again later.")
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"github.com/ahernandez9/rockets/internal/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID of a request, set by callers or generated, and echoed in the response
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey is the Gin context key holding the ID of the request
+const RequestIDKey = "requestID"
+
+// maxRequestIDLength bounds caller-provided IDs, which end up in every log record of the request
+const maxRequestIDLength = 128
+
+// RequestID identifies every request, keeping the X-Request-ID sent by callers or generating one. The ID is returned
+// in the response, added to the logs of the request and carried with the messages it submits
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = uuid.NewString()
+		}
+
+		c.Set(RequestIDKey, id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+// validRequestID accepts non-empty IDs of printable ASCII characters, keeping control characters out of the logs
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x21 || id[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
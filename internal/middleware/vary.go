@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddVary lists a request header in the Vary header of the response, merging it with the headers listed already so
+// that caches see a single Vary header naming everything the response depends on
+func AddVary(c *gin.Context, header string) {
+	vary := c.Writer.Header().Get("Vary")
+	for _, name := range strings.Split(vary, ",") {
+		if name = strings.TrimSpace(name); name == "*" || strings.EqualFold(name, header) {
+			return
+		}
+	}
+
+	if vary != "" {
+		header = vary + ", " + header
+	}
+	c.Header("Vary", header)
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// AnomalyKind identifies a kind of implausible telemetry transition
+type AnomalyKind string
+
+const (
+	AnomalySpeedJump     AnomalyKind = "SPEED_JUMP"     // Speed changed by more than the configured delta in a single message
+	AnomalyNegativeSpeed AnomalyKind = "NEGATIVE_SPEED" // Speed dropped below zero
+	AnomalyFuelIncrease  AnomalyKind = "FUEL_INCREASE"  // Fuel level went up in flight
+)
+
+// MaxAnomalies caps the number of anomalies kept per rocket; the oldest ones are dropped first
+const MaxAnomalies = 50
+
+// Anomaly records an implausible transition caused by a telemetry message, pointing at a sensor or producer bug.
+// The message is still applied
+type Anomaly struct {
+	Kind          AnomalyKind `json:"kind" xml:"kind" example:"SPEED_JUMP"`
+	Detail        string      `json:"detail" xml:"detail" example:"speed changed by 25000 in a single message, more than 10000"`
+	MessageNumber int64       `json:"messageNumber" xml:"messageNumber" example:"7"`
+	Time          time.Time   `json:"time" xml:"time" example:"2022-02-02T19:39:05.86337+01:00"`
+}
+
+// AddAnomalies appends anomalies to the rocket, keeping only the MaxAnomalies most recent ones
+func (r *Rocket) AddAnomalies(anomalies ...Anomaly) {
+	r.Anomalies = append(r.Anomalies, anomalies...)
+	if len(r.Anomalies) > MaxAnomalies {
+		r.Anomalies = r.Anomalies[len(r.Anomalies)-MaxAnomalies:]
+	}
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// AuditEntry records an operation changing the service state
+type AuditEntry struct {
+	ID            uint64    `json:"id" xml:"id,attr" example:"42"`
+	Time          time.Time `json:"time" xml:"time" example:"2022-02-02T19:39:06Z"`
+	Actor         string    `json:"actor,omitempty" xml:"actor,omitempty" example:"admin"`
+	Method        string    `json:"method" xml:"method" example:"PATCH"`
+	Path          string    `json:"path" xml:"path" example:"/rockets/193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Status        int       `json:"status" xml:"status" example:"200"`
+	PayloadDigest string    `json:"payloadDigest,omitempty" xml:"payloadDigest,omitempty" example:"sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	RequestID     string    `json:"requestId,omitempty" xml:"requestId,omitempty" example:"3f6c2a4e-8d1b-4f0a-9c2e-5b7d1e9a0c11"`
+	ClientIP      string    `json:"clientIp" xml:"clientIp" example:"10.0.0.12"`
+}
+
+// AuditListResponse lists audit entries, most recent first
+type AuditListResponse struct {
+	XMLName xml.Name `json:"-" xml:"audit" swaggerignore:"true"`
+
+	Count   int           `json:"count" xml:"count" example:"1"`
+	Entries []*AuditEntry `json:"entries" xml:"entry"`
+}
@@ -0,0 +1,70 @@
+package models
+
+import "time"
+
+// Kinds of records found in a backup dump
+const (
+	RecordKindRocket = "rocket"
+	RecordKindEvent  = "event"
+)
+
+// BackupRecord is a single line of a JSON Lines backup dump. Exactly one of Rocket or Event is set, according to Kind
+type BackupRecord struct {
+	Kind   string       `json:"kind" example:"rocket"`
+	Rocket *Rocket      `json:"rocket,omitempty"`
+	Event  *RocketEvent `json:"event,omitempty"`
+}
+
+// Outcomes of importing a backup record
+const (
+	ImportStatusImported = "imported"
+	ImportStatusSkipped  = "skipped"
+	ImportStatusFailed   = "failed"
+)
+
+// ImportResult reports the outcome of importing a single line of a dump
+type ImportResult struct {
+	Line   int    `json:"line" example:"1"`
+	Kind   string `json:"kind,omitempty" example:"rocket"`
+	ID     string `json:"id,omitempty" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Status string `json:"status" example:"imported"`
+	Error  string `json:"error,omitempty" example:"rocket: 'type' field is required"`
+}
+
+// ImportResponse summarizes an import and lists per-record results in line order
+type ImportResponse struct {
+	Total    int            `json:"total" example:"3"`
+	Imported int            `json:"imported" example:"2"`
+	Skipped  int            `json:"skipped" example:"0"`
+	Failed   int            `json:"failed" example:"1"`
+	Results  []ImportResult `json:"results"`
+}
+
+// PurgeResponse reports the removal of a rocket and its history
+type PurgeResponse struct {
+	ID            string `json:"id" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	EventsDeleted int    `json:"eventsDeleted" example:"42"`
+}
+
+// ErasureReport lists what was removed when erasing the data of a channel
+type ErasureReport struct {
+	Channel                 string    `json:"channel" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	RocketDeleted           bool      `json:"rocketDeleted" example:"true"`
+	EventsDeleted           int       `json:"eventsDeleted" example:"42"`
+	TrackPointsDeleted      int       `json:"trackPointsDeleted" example:"120"`
+	FleetMembershipsRemoved int       `json:"fleetMembershipsRemoved" example:"1"` // Fleets that listed the channel as a member
+	ChangesDeleted          int       `json:"changesDeleted" example:"14"`         // Changes retained for live streams to resume from
+	DeliveriesDeleted       int       `json:"deliveriesDeleted" example:"1"`       // Entries of the notification delivery log
+	ErasedAt                time.Time `json:"erasedAt" example:"2022-03-01T10:00:00Z"`
+}
+
+// LogLevel is the minimum level of the service logs
+type LogLevel struct {
+	Level string `json:"level" example:"debug" enums:"debug,info,warn,error"`
+}
+
+// ResetResponse reports how much state a reset removed
+type ResetResponse struct {
+	RocketsDeleted int `json:"rocketsDeleted" example:"12"`
+	EventsDeleted  int `json:"eventsDeleted" example:"480"`
+}
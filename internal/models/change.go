@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// Kinds of rocket state changes
+const (
+	ChangeUpdated = "updated"
+	ChangeDeleted = "deleted"
+)
+
+// RocketChange is a rocket state change published on the change feed.
+// IDs increase monotonically and can be used to resume a stream
+type RocketChange struct {
+	ID       uint64    `json:"id" example:"42"`
+	Kind     string    `json:"kind" example:"updated"`
+	RocketID string    `json:"rocketId" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Rocket   *Rocket   `json:"rocket,omitempty"`
+	Time     time.Time `json:"time" example:"2022-02-02T19:39:05.86337+01:00"`
+}
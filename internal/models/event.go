@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"time"
+)
+
+// EventManualCorrection is the event type recorded when an operator corrects a rocket by hand
+const EventManualCorrection = "ManualCorrection"
+
+// EventRenamed is the event type recorded when an operator assigns a display name to a rocket
+const EventRenamed = "Renamed"
+
+// RocketEvent is an entry in a rocket's history: either an applied telemetry message or a manual correction
+type RocketEvent struct {
+	XMLName xml.Name `json:"-" xml:"event" swaggerignore:"true"`
+
+	Channel       string          `json:"channel" xml:"channel" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Type          string          `json:"type" xml:"type" example:"RocketSpeedIncreased"`
+	MessageNumber int64           `json:"messageNumber,omitempty" xml:"messageNumber,omitempty" example:"2"`
+	Time          time.Time       `json:"time" xml:"time" example:"2022-02-02T19:39:05.86337+01:00"`
+	Payload       json.RawMessage `json:"payload,omitempty" xml:"payload,omitempty" swaggertype:"object"`
+	Actor         string          `json:"actor,omitempty" xml:"actor,omitempty" example:"admin"`
+}
+
+// RocketCorrection lists the fields an operator wants to overwrite. Nil fields are left untouched
+type RocketCorrection struct {
+	Mission *string       `json:"mission,omitempty" example:"ARTEMIS"`
+	Speed   *int          `json:"speed,omitempty" example:"3500"`
+	Status  *RocketStatus `json:"status,omitempty" example:"ACTIVE"`
+	Reason  string        `json:"reason,omitempty" example:"Speed sensor reported wrong values"`
+}
+
+// FieldChange records the previous and new value of a corrected field
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ManualCorrectionPayload is the payload stored with ManualCorrection events
+type ManualCorrectionPayload struct {
+	Changes map[string]FieldChange `json:"changes"`
+	Reason  string                 `json:"reason,omitempty"`
+}
+
+// RenamedPayload is the payload stored with Renamed events
+type RenamedPayload struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RocketEventsResponse lists the recorded history of a rocket, oldest first
+type RocketEventsResponse struct {
+	XMLName xml.Name `json:"-" xml:"rocketEvents" swaggerignore:"true"`
+
+	Count  int            `json:"count" xml:"count" example:"1"`
+	Events []*RocketEvent `json:"events" xml:"events>event"`
+}
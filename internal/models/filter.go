@@ -0,0 +1,200 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+)
+
+// RocketFilter narrows down a rocket listing. Empty fields match every rocket and
+// string comparisons are case-insensitive. Archived rockets are excluded unless IncludeArchived is set
+type RocketFilter struct {
+	Status          string
+	Mission         string
+	Type            string
+	Query           string // Free-text search over ID, name, type and mission
+	LowFuel         bool   // Only rockets whose fuel level is below LowFuelThreshold
+	Labels          Labels // Labels the rocket must carry; values are compared case-insensitively
+	Predicates      []Predicate
+	IncludeArchived bool
+}
+
+// Matches reports whether the rocket satisfies every criterion of the filter
+func (f RocketFilter) Matches(rocket *Rocket) bool {
+	if rocket.ArchivedAt != nil && !f.IncludeArchived {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(string(rocket.Status), f.Status) {
+		return false
+	}
+	if f.Mission != "" && !strings.EqualFold(rocket.Mission, f.Mission) {
+		return false
+	}
+	if f.Type != "" && !strings.EqualFold(rocket.Type, f.Type) {
+		return false
+	}
+	if f.Query != "" && !matchesQuery(rocket, f.Query) {
+		return false
+	}
+	if f.LowFuel && !rocket.IsLowOnFuel() {
+		return false
+	}
+	for key, want := range f.Labels {
+		if value, ok := rocket.Labels[key]; !ok || !strings.EqualFold(value, want) {
+			return false
+		}
+	}
+	for _, p := range f.Predicates {
+		if !p.Matches(rocket) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesQuery reports whether the query is a case-insensitive substring of the rocket ID, name, type or mission
+func matchesQuery(rocket *Rocket, query string) bool {
+	query = strings.ToLower(query)
+	for _, value := range []string{rocket.ID, rocket.Name, rocket.Type, rocket.Mission} {
+		if strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// SortField is a single key of a multi-field sort
+type SortField struct {
+	Field      string
+	Descending bool
+}
+
+// FilterOperator is a comparison operator usable in filter predicates
+type FilterOperator string
+
+const (
+	OpEqual          FilterOperator = "="
+	OpNotEqual       FilterOperator = "!="
+	OpGreater        FilterOperator = ">"
+	OpGreaterOrEqual FilterOperator = ">="
+	OpLess           FilterOperator = "<"
+	OpLessOrEqual    FilterOperator = "<="
+)
+
+// FieldKind describes how a filterable field is compared
+type FieldKind int
+
+const (
+	StringField FieldKind = iota
+	NumericField
+)
+
+// FilterableFields lists the rocket fields predicates can reference, keyed by their JSON name
+var FilterableFields = map[string]FieldKind{
+	"id":                StringField,
+	"name":              StringField,
+	"type":              StringField,
+	"mission":           StringField,
+	"status":            StringField,
+	"speed":             NumericField,
+	"maxSpeed":          NumericField,
+	"averageSpeed":      NumericField,
+	"estimatedDistance": NumericField,
+	"fuelLevel":         NumericField,
+	"currentStage":      NumericField,
+	"lastMessageNumber": NumericField,
+}
+
+// Predicate is a single comparison between a rocket field and a literal value
+type Predicate struct {
+	Field    string
+	Operator FilterOperator
+	Value    string
+}
+
+// Matches reports whether the rocket satisfies the predicate.
+// String fields are compared case-insensitively and only support equality operators.
+// Numeric fields a rocket has not reported yet, such as fuelLevel, never match
+func (p Predicate) Matches(rocket *Rocket) bool {
+	switch FilterableFields[p.Field] {
+	case NumericField:
+		want, err := strconv.ParseFloat(p.Value, 64)
+		if err != nil {
+			return false
+		}
+		got, ok := numericFieldValue(rocket, p.Field)
+		if !ok {
+			return false
+		}
+		return compareNumbers(got, p.Operator, want)
+	default:
+		equal := strings.EqualFold(stringFieldValue(rocket, p.Field), p.Value)
+		switch p.Operator {
+		case OpEqual:
+			return equal
+		case OpNotEqual:
+			return !equal
+		default:
+			return false
+		}
+	}
+}
+
+func compareNumbers(got float64, op FilterOperator, want float64) bool {
+	switch op {
+	case OpEqual:
+		return got == want
+	case OpNotEqual:
+		return got != want
+	case OpGreater:
+		return got > want
+	case OpGreaterOrEqual:
+		return got >= want
+	case OpLess:
+		return got < want
+	case OpLessOrEqual:
+		return got <= want
+	default:
+		return false
+	}
+}
+
+func stringFieldValue(rocket *Rocket, field string) string {
+	switch field {
+	case "id":
+		return rocket.ID
+	case "name":
+		return rocket.Name
+	case "type":
+		return rocket.Type
+	case "mission":
+		return rocket.Mission
+	case "status":
+		return string(rocket.Status)
+	default:
+		return ""
+	}
+}
+
+func numericFieldValue(rocket *Rocket, field string) (float64, bool) {
+	switch field {
+	case "speed":
+		return float64(rocket.Speed), true
+	case "maxSpeed":
+		return float64(rocket.MaxSpeed), true
+	case "averageSpeed":
+		return rocket.AverageSpeed, true
+	case "estimatedDistance":
+		return rocket.EstimatedDistance, true
+	case "fuelLevel":
+		if rocket.FuelLevel == nil {
+			return 0, false
+		}
+		return *rocket.FuelLevel, true
+	case "currentStage":
+		return float64(rocket.CurrentStage), true
+	case "lastMessageNumber":
+		return float64(rocket.LastMessageNumber), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Fleet is a group of rockets managed together, such as a constellation. Rockets belong to a fleet
+// when they are listed as members or when they carry every label of the fleet selector
+type Fleet struct {
+	XMLName xml.Name `json:"-" xml:"fleet" swaggerignore:"true"`
+
+	ID        string    `json:"id" xml:"id,attr" example:"7c1e4a2b-9f3d-4e8a-b6c5-0d2f1a3e4b5c"`
+	Name      string    `json:"name" xml:"name" example:"Starlink Group 4"`
+	Members   []string  `json:"members,omitempty" xml:"members>member,omitempty" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Selector  Labels    `json:"selector,omitempty" xml:"selector,omitempty" swaggertype:"object,string"`
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt" example:"2022-02-02T19:39:05.86337+01:00"`
+
+	// Aggregates of the non-archived rockets currently in the fleet, computed when the fleet is read
+	RocketCount int          `json:"rocketCount" xml:"rocketCount" example:"4"`
+	ByStatus    StatusCounts `json:"byStatus" xml:"byStatus" swaggertype:"object,integer"`
+	Speed       SpeedStats   `json:"speed" xml:"speed"`
+}
+
+// FleetRequest defines a fleet. At least one of members or selector must be set
+type FleetRequest struct {
+	Name     string   `json:"name" binding:"required" example:"Starlink Group 4"`
+	Members  []string `json:"members" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Selector Labels   `json:"selector" swaggertype:"object,string"`
+}
+
+// FleetListResponse lists the fleets
+type FleetListResponse struct {
+	XMLName xml.Name `json:"-" xml:"fleetList" swaggerignore:"true"`
+
+	Count  int      `json:"count" xml:"count" example:"1"`
+	Fleets []*Fleet `json:"fleets" xml:"fleets>fleet"`
+}
+
+// FleetRocketsResponse lists the rockets of a fleet
+type FleetRocketsResponse struct {
+	XMLName xml.Name `json:"-" xml:"fleetRockets" swaggerignore:"true"`
+
+	Fleet   string `json:"fleet" xml:"fleet" example:"7c1e4a2b-9f3d-4e8a-b6c5-0d2f1a3e4b5c"`
+	Count   int    `json:"count" xml:"count" example:"1"`
+	Rockets any    `json:"rockets" xml:"rockets>rocket" swaggertype:"array,object"` // []*Rocket, or sparse rockets when ?fields= is set
+	SortBy  string `json:"sortBy" xml:"sortBy" example:"id"`
+}
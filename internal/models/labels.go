@@ -0,0 +1,41 @@
+package models
+
+import (
+	"encoding/xml"
+	"slices"
+)
+
+// EventLabelsUpdated is the event type recorded when an operator replaces the labels of a rocket
+const EventLabelsUpdated = "LabelsUpdated"
+
+// Labels are arbitrary key/value pairs operators attach to a rocket, e.g. team=alpha
+type Labels map[string]string
+
+// MarshalXML encodes the labels as label elements, as XML has no map type
+func (l Labels) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	keys := make([]string, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		element := xml.StartElement{
+			Name: xml.Name{Local: "label"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}},
+		}
+		if err := e.EncodeElement(l[key], element); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// LabelsUpdatedPayload is the payload stored with LabelsUpdated events
+type LabelsUpdatedPayload struct {
+	From Labels `json:"from"`
+	To   Labels `json:"to"`
+}
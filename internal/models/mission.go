@@ -0,0 +1,37 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Mission is a mission rockets are assigned to. Missions are not created explicitly: they are a projection
+// of the rocket state, existing for as long as at least one non-archived rocket is assigned to them
+type Mission struct {
+	XMLName xml.Name `json:"-" xml:"mission" swaggerignore:"true"`
+
+	Name        string       `json:"name" xml:"name,attr" example:"ARTEMIS"`
+	RocketCount int          `json:"rocketCount" xml:"rocketCount" example:"4"`
+	ByStatus    StatusCounts `json:"byStatus" xml:"byStatus" swaggertype:"object,integer"`
+	Speed       SpeedStats   `json:"speed" xml:"speed"`
+	FirstSeen   time.Time    `json:"firstSeen" xml:"firstSeen" example:"2022-02-02T19:39:05.86337+01:00"`
+	LastUpdated time.Time    `json:"lastUpdated" xml:"lastUpdated" example:"2022-02-02T19:45:05.86337+01:00"`
+}
+
+// MissionListResponse lists the missions
+type MissionListResponse struct {
+	XMLName xml.Name `json:"-" xml:"missionList" swaggerignore:"true"`
+
+	Count    int        `json:"count" xml:"count" example:"1"`
+	Missions []*Mission `json:"missions" xml:"missions>mission"`
+}
+
+// MissionRocketsResponse lists the rockets assigned to a mission
+type MissionRocketsResponse struct {
+	XMLName xml.Name `json:"-" xml:"missionRockets" swaggerignore:"true"`
+
+	Mission string `json:"mission" xml:"mission" example:"ARTEMIS"`
+	Count   int    `json:"count" xml:"count" example:"1"`
+	Rockets any    `json:"rockets" xml:"rockets>rocket" swaggertype:"array,object"` // []*Rocket, or sparse rockets when ?fields= is set
+	SortBy  string `json:"sortBy" xml:"sortBy" example:"id"`
+}
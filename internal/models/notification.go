@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Kinds of notifications
+const (
+	NotificationRocketExploded = "RocketExploded"
+)
+
+// Notification is an alert dispatched to the configured notification sinks
+type Notification struct {
+	Kind     string    `json:"kind" example:"RocketExploded"`
+	RocketID string    `json:"rocketId,omitempty" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Title    string    `json:"title" example:"Rocket exploded"`
+	Text     string    `json:"text" example:"Rocket 193270a9-c9cf-404a-8f83-838e71d9ae67 (Falcon-9, mission ARTEMIS) exploded: PRESSURE_VESSEL_FAILURE"`
+	Time     time.Time `json:"time" example:"2022-02-02T19:39:05.86337+01:00"`
+	Rocket   *Rocket   `json:"rocket,omitempty"`
+}
+
+// DeliveryStatus is the outcome of delivering a notification to a sink
+type DeliveryStatus string
+
+const (
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryFailed    DeliveryStatus = "FAILED"
+)
+
+// Delivery records the delivery of a notification to a single sink, retries included
+type Delivery struct {
+	ID       uint64         `json:"id" xml:"id,attr" example:"12"`
+	Sink     string         `json:"sink" xml:"sink" example:"slack"`
+	Kind     string         `json:"kind" xml:"kind" example:"RocketExploded"`
+	RocketID string         `json:"rocketId,omitempty" xml:"rocketId,omitempty" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Status   DeliveryStatus `json:"status" xml:"status" example:"DELIVERED"`
+	Attempts int            `json:"attempts" xml:"attempts" example:"1"`
+	Error    string         `json:"error,omitempty" xml:"error,omitempty" example:"unexpected status 503"`
+	Time     time.Time      `json:"time" xml:"time" example:"2022-02-02T19:39:06Z"`
+}
+
+// DeliveryListResponse lists the most recent notification deliveries
+type DeliveryListResponse struct {
+	XMLName xml.Name `json:"-" xml:"deliveries" swaggerignore:"true"`
+
+	Count      int         `json:"count" xml:"count" example:"1"`
+	Deliveries []*Delivery `json:"deliveries" xml:"delivery"`
+}
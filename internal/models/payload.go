@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// RocketPayloadDeployedMessage reports that a rocket released a payload, such as a satellite
+type RocketPayloadDeployedMessage struct {
+	Name string `json:"name" example:"STARLINK-1234"`
+}
+
+// Payload records a payload deployed by a rocket
+type Payload struct {
+	Name          string    `json:"name" xml:"name" example:"STARLINK-1234"`
+	MessageNumber int64     `json:"messageNumber" xml:"messageNumber" example:"18"`
+	DeployedAt    time.Time `json:"deployedAt" xml:"deployedAt" example:"2022-02-02T19:48:05.86337+01:00"`
+}
@@ -0,0 +1,48 @@
+package models
+
+// ProblemTypeBlank is the problem type of errors described by their title and status alone (RFC 7807, section 4.2)
+const ProblemTypeBlank = "about:blank"
+
+// ErrorCode is a stable, machine-readable identifier of an error.
+// Codes never change between releases, unlike titles and details
+type ErrorCode string
+
+const (
+	ErrorCodeInvalidRequestBody ErrorCode = "INVALID_REQUEST_BODY"
+	ErrorCodeInvalidParameter   ErrorCode = "INVALID_PARAMETER"
+	ErrorCodeInvalidFilter      ErrorCode = "INVALID_FILTER"
+	ErrorCodeInvalidRocketID    ErrorCode = "INVALID_ROCKET_ID"
+	ErrorCodeRocketNotFound     ErrorCode = "ROCKET_NOT_FOUND"
+	ErrorCodeRocketNameTaken    ErrorCode = "ROCKET_NAME_TAKEN"
+	ErrorCodeMissionNotFound    ErrorCode = "MISSION_NOT_FOUND"
+	ErrorCodeFleetNotFound      ErrorCode = "FLEET_NOT_FOUND"
+	ErrorCodeWebhookNotFound    ErrorCode = "WEBHOOK_NOT_FOUND"
+	ErrorCodeInvalidMessage     ErrorCode = "INVALID_MESSAGE"
+	ErrorCodeInvalidMessageType ErrorCode = "INVALID_MESSAGE_TYPE"
+	ErrorCodeMessageRejected    ErrorCode = "MESSAGE_REJECTED"
+	ErrorCodeProcessingTimeout  ErrorCode = "PROCESSING_TIMEOUT"
+	ErrorCodeQueueFull          ErrorCode = "QUEUE_FULL"
+	ErrorCodeBatchTooLarge      ErrorCode = "BATCH_TOO_LARGE"
+	ErrorCodeUnauthorized       ErrorCode = "UNAUTHORIZED"
+	ErrorCodeForbidden          ErrorCode = "FORBIDDEN"
+	ErrorCodeInvalidSignature   ErrorCode = "INVALID_SIGNATURE"
+	ErrorCodePayloadTooLarge    ErrorCode = "PAYLOAD_TOO_LARGE"
+	ErrorCodeRateLimited        ErrorCode = "RATE_LIMITED"
+	ErrorCodeOverloaded         ErrorCode = "OVERLOADED"
+	ErrorCodeAdminDisabled      ErrorCode = "ADMIN_DISABLED"
+	ErrorCodeChannelNotOwned    ErrorCode = "CHANNEL_NOT_OWNED"
+	ErrorCodeOwnerUnavailable   ErrorCode = "OWNER_UNAVAILABLE"
+	ErrorCodeInternal           ErrorCode = "INTERNAL_ERROR"
+)
+
+// Problem is an RFC 7807 problem details error response, served as application/problem+json
+type Problem struct {
+	Type      string    `json:"type" example:"about:blank"`
+	Title     string    `json:"title" example:"Rocket not found"`
+	Status    int       `json:"status" example:"404"`
+	Detail    string    `json:"detail,omitempty" example:"No rocket exists with the provided ID."`
+	Instance  string    `json:"instance,omitempty" example:"/rockets/193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	ErrorCode ErrorCode `json:"errorCode" example:"ROCKET_NOT_FOUND"`
+	// RequestID identifies the failed request in the logs, worth quoting when reporting the error
+	RequestID string `json:"requestId,omitempty" example:"0b4e3f4c-2b8a-4c1e-9d55-7f3c1a2e9b61"`
+}
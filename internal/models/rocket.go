@@ -1,6 +1,13 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"encoding/xml"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
 
 // MessageMetadata contains metadata about the rocket message
 type MessageMetadata struct {
@@ -8,12 +15,15 @@ type MessageMetadata struct {
 	MessageNumber int64     `json:"messageNumber" example:"1"`
 	MessageTime   time.Time `json:"messageTime" example:"2022-02-02T19:39:05.86337+01:00"`
 	MessageType   string    `json:"messageType" example:"RocketLaunched"`
+	// RequestID identifies the request that submitted the message, so that its processing can be correlated to it
+	RequestID string `json:"-"`
 }
 
-// RocketMessage represents an incoming rocket message
+// RocketMessage represents an incoming rocket message. Its content is kept as submitted, and only decoded into the
+// structure of its type by validation and processing
 type RocketMessage struct {
 	Metadata MessageMetadata `json:"metadata"`
-	Message  interface{}     `json:"message"`
+	Message  json.RawMessage `json:"message" swaggertype:"object"`
 }
 
 // RocketLaunchedMessage represents a rocket launch event
@@ -33,39 +43,236 @@ type RocketExplodedMessage struct {
 	Reason string `json:"reason" example:"PRESSURE_VESSEL_FAILURE"`
 }
 
+// RocketLandedMessage reports that a rocket touched down safely. It carries no fields
+type RocketLandedMessage struct{}
+
+// RocketDecommissionedMessage reports that a landed rocket was retired from service. It carries no fields
+type RocketDecommissionedMessage struct{}
+
 // RocketMissionChangedMessage represents a mission change event
 type RocketMissionChangedMessage struct {
 	NewMission string `json:"newMission" example:"SHUTTLE_MIR"`
 }
 
+// RocketFuelUpdatedMessage reports the remaining fuel of a rocket
+type RocketFuelUpdatedMessage struct {
+	FuelLevel *float64 `json:"fuelLevel" example:"87.5"` // Percentage of a full tank, from 0 to 100
+}
+
+// RocketStageSeparatedMessage reports that a stage of a multi-stage rocket was jettisoned
+type RocketStageSeparatedMessage struct {
+	Stage int `json:"stage" example:"1"` // Number of the separated stage; the rocket continues on the next one
+}
+
+// LowFuelThreshold is the fuel level, in percent, below which a rocket is considered low on fuel
+const LowFuelThreshold = 20.0
+
 // RocketStatus represents the possible states of a rocket
 type RocketStatus string
 
 const (
-	StatusActive   RocketStatus = "ACTIVE"
-	StatusExploded RocketStatus = "EXPLODED"
+	StatusActive         RocketStatus = "ACTIVE"
+	StatusExploded       RocketStatus = "EXPLODED"
+	StatusLanded         RocketStatus = "LANDED"
+	StatusDecommissioned RocketStatus = "DECOMMISSIONED"
 )
 
+// statusTransitions lists the statuses telemetry can move a rocket to from each status.
+// EXPLODED and DECOMMISSIONED are terminal; a rocket only leaves them by being launched again
+var statusTransitions = map[RocketStatus][]RocketStatus{
+	StatusActive: {StatusExploded, StatusLanded},
+	StatusLanded: {StatusDecommissioned},
+}
+
+// ParseStatus resolves a rocket status case-insensitively
+func ParseStatus(value string) (RocketStatus, bool) {
+	switch status := RocketStatus(strings.ToUpper(value)); status {
+	case StatusActive, StatusExploded, StatusLanded, StatusDecommissioned:
+		return status, true
+	default:
+		return "", false
+	}
+}
+
+// CanTransitionTo reports whether telemetry can move a rocket from this status to next
+func (s RocketStatus) CanTransitionTo(next RocketStatus) bool {
+	return slices.Contains(statusTransitions[s], next)
+}
+
 // Rocket represents the current state of a rocket
 type Rocket struct {
-	ID                string       `json:"id" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
-	Type              string       `json:"type" example:"Falcon-9"`
-	Speed             int          `json:"speed" example:"3500"`
-	Mission           string       `json:"mission" example:"ARTEMIS"`
-	Status            RocketStatus `json:"status" example:"ACTIVE"`
-	ExplosionReason   string       `json:"explosionReason,omitempty" example:"PRESSURE_VESSEL_FAILURE"`
-	LastMessageNumber int64        `json:"lastMessageNumber" example:"42"`
-	LastUpdated       time.Time    `json:"lastUpdated" example:"2022-02-02T19:39:05.86337+01:00"`
+	XMLName xml.Name `json:"-" xml:"rocket" swaggerignore:"true"`
+
+	ID                string            `json:"id" xml:"id" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Name              string            `json:"name,omitempty" xml:"name,omitempty" example:"Odyssey"` // Unique display name assigned by an operator
+	Type              string            `json:"type" xml:"type" example:"Falcon-9"`
+	Speed             int               `json:"speed" xml:"speed" example:"3500"`
+	MaxSpeed          int               `json:"maxSpeed,omitempty" xml:"maxSpeed,omitempty" example:"4200"`           // Highest speed reported since launch
+	AverageSpeed      float64           `json:"averageSpeed,omitempty" xml:"averageSpeed,omitempty" example:"2950.5"` // Mean of the speeds reported since launch
+	SpeedSamples      int64             `json:"speedSamples,omitempty" xml:"speedSamples,omitempty" example:"12"`     // Number of speeds averaged into AverageSpeed
+	SpeedUnit         SpeedUnit         `json:"speedUnit,omitempty" xml:"speedUnit,omitempty" example:"kmh"`          // Set when converted with ?units=
+	Mission           string            `json:"mission" xml:"mission" example:"ARTEMIS"`
+	FuelLevel         *float64          `json:"fuelLevel,omitempty" xml:"fuelLevel,omitempty" example:"87.5"` // Unset until a RocketFuelUpdated message is applied
+	Position          *Position         `json:"position,omitempty" xml:"position,omitempty"`                  // Unset until a RocketPositionUpdated message is applied
+	CurrentStage      int               `json:"currentStage,omitempty" xml:"currentStage,omitempty" example:"2"`
+	Stages            []StageSeparation `json:"stages,omitempty" xml:"stages>stage,omitempty"`         // Stage separations, oldest first
+	Payloads          []Payload         `json:"payloads,omitempty" xml:"payloads>payload,omitempty"`   // Deployed payloads, oldest first
+	Anomalies         []Anomaly         `json:"anomalies,omitempty" xml:"anomalies>anomaly,omitempty"` // Implausible transitions detected in telemetry, oldest first
+	Labels            Labels            `json:"labels,omitempty" xml:"labels,omitempty" swaggertype:"object,string"`
+	Status            RocketStatus      `json:"status" xml:"status" example:"ACTIVE"`
+	ExplosionReason   string            `json:"explosionReason,omitempty" xml:"explosionReason,omitempty" example:"PRESSURE_VESSEL_FAILURE"`
+	LastMessageNumber int64             `json:"lastMessageNumber" xml:"lastMessageNumber" example:"42"`
+	LaunchTime        *time.Time        `json:"launchTime,omitempty" xml:"launchTime,omitempty" example:"2022-02-02T19:39:01.86337+01:00"` // Message time of the RocketLaunched message
+	EstimatedDistance float64           `json:"estimatedDistance,omitempty" xml:"estimatedDistance,omitempty" example:"1520.3"`            // Kilometers covered since launch, integrating the speed between applied messages
+	FlightDuration    float64           `json:"flightDuration,omitempty" xml:"flightDuration,omitempty" example:"245.5"`                   // Seconds from launch to the latest applied message
+	Version           int64             `json:"version,omitempty" xml:"version,omitempty" example:"7"`                                     // Increased by the repository on every change to the stored rocket, telemetry or operator edit
+	LastUpdated       time.Time         `json:"lastUpdated" xml:"lastUpdated" example:"2022-02-02T19:39:05.86337+01:00"`
+	ModifiedAt        time.Time         `json:"-" xml:"-"` // Server time of the latest stored change, as opposed to the message time of LastUpdated
+	ArchivedAt        *time.Time        `json:"archivedAt,omitempty" xml:"archivedAt,omitempty" example:"2022-03-01T10:00:00Z"`
+}
+
+// StageSeparation records when a stage of the rocket was jettisoned
+type StageSeparation struct {
+	Stage         int       `json:"stage" xml:"stage" example:"1"`
+	MessageNumber int64     `json:"messageNumber" xml:"messageNumber" example:"12"`
+	Time          time.Time `json:"time" xml:"time" example:"2022-02-02T19:41:05.86337+01:00"`
+}
+
+// Clone returns a deep copy of the rocket, so that copies handed out by repositories share no state
+func (r *Rocket) Clone() *Rocket {
+	clone := *r
+	if r.FuelLevel != nil {
+		level := *r.FuelLevel
+		clone.FuelLevel = &level
+	}
+	if r.Position != nil {
+		position := *r.Position
+		clone.Position = &position
+	}
+	if r.LaunchTime != nil {
+		launchTime := *r.LaunchTime
+		clone.LaunchTime = &launchTime
+	}
+	if r.ArchivedAt != nil {
+		archivedAt := *r.ArchivedAt
+		clone.ArchivedAt = &archivedAt
+	}
+	clone.Stages = slices.Clone(r.Stages)
+	clone.Payloads = slices.Clone(r.Payloads)
+	clone.Anomalies = slices.Clone(r.Anomalies)
+	clone.Labels = maps.Clone(r.Labels)
+	return &clone
+}
+
+// AccumulateDistance adds the distance covered at speed (km/h) from since to the latest update of the rocket
+func (r *Rocket) AccumulateDistance(speed int, since time.Time) {
+	if elapsed := r.LastUpdated.Sub(since); elapsed > 0 && speed > 0 {
+		r.EstimatedDistance += float64(speed) * elapsed.Hours()
+	}
+}
+
+// RecordSpeed folds the current speed into the maximum and running average speed of the rocket
+func (r *Rocket) RecordSpeed() {
+	r.SpeedSamples++
+	r.AverageSpeed += (float64(r.Speed) - r.AverageSpeed) / float64(r.SpeedSamples)
+	if r.SpeedSamples == 1 || r.Speed > r.MaxSpeed {
+		r.MaxSpeed = r.Speed
+	}
+}
+
+// IsLowOnFuel reports whether the rocket has reported a fuel level below LowFuelThreshold
+func (r *Rocket) IsLowOnFuel() bool {
+	return r.FuelLevel != nil && *r.FuelLevel < LowFuelThreshold
+}
+
+// RocketNameRequest assigns a display name to a rocket
+type RocketNameRequest struct {
+	Name string `json:"name" binding:"required" example:"Odyssey"`
+}
+
+// RocketListResponse lists the rockets matching a query
+type RocketListResponse struct {
+	XMLName xml.Name `json:"-" xml:"rocketList" swaggerignore:"true"`
+
+	Count   int    `json:"count" xml:"count" example:"1"`
+	Rockets any    `json:"rockets" xml:"rockets>rocket" swaggertype:"array,object"` // []*Rocket, or sparse rockets when ?fields= is set
+	SortBy  string `json:"sortBy" xml:"sortBy" example:"id"`
+}
+
+// StreamLineError reports why a line of a streamed ingest was rejected
+type StreamLineError struct {
+	Line      int       `json:"line" example:"3"`
+	ErrorCode ErrorCode `json:"errorCode" example:"INVALID_MESSAGE"`
+	Error     string    `json:"error" example:"messageNumber must be positive, got: 0"`
+}
+
+// StreamIngestResponse summarizes a streamed ingest once the request body has been fully consumed
+type StreamIngestResponse struct {
+	Total    int               `json:"total" example:"3"`
+	Accepted int               `json:"accepted" example:"2"`
+	Rejected int               `json:"rejected" example:"1"`
+	Errors   []StreamLineError `json:"errors"`                        // The first rejected lines, in order
+	Omitted  int               `json:"omitted,omitempty" example:"0"` // Rejected lines left out of Errors
+}
+
+// TopRocketsResponse lists the highest-ranked active rockets, best first
+type TopRocketsResponse struct {
+	XMLName xml.Name `json:"-" xml:"topRockets" swaggerignore:"true"`
+
+	By      string `json:"by" xml:"by" example:"speed"`
+	Limit   int    `json:"limit" xml:"limit" example:"10"`
+	Count   int    `json:"count" xml:"count" example:"2"`
+	Rockets any    `json:"rockets" xml:"rockets>rocket" swaggertype:"array,object"` // []*Rocket, or sparse rockets when ?fields= is set
+}
+
+// BatchGetRequest lists the rocket IDs to fetch in a single call
+type BatchGetRequest struct {
+	IDs []string `json:"ids" binding:"required" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
 }
 
-// ErrorResponse represents an API error response
-type ErrorResponse struct {
-	Error   string `json:"error" example:"Invalid message format"`
-	Message string `json:"message,omitempty" example:"The provided message could not be parsed"`
+// BatchGetResult is the lookup outcome for a single requested ID
+type BatchGetResult struct {
+	ID     string  `json:"id" xml:"id" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Found  bool    `json:"found" xml:"found" example:"true"`
+	Rocket *Rocket `json:"rocket,omitempty" xml:"rocket,omitempty"`
+	Error  string  `json:"error,omitempty" xml:"error,omitempty" example:"invalid UUID"`
+}
+
+// BatchGetResponse contains one result per requested ID, in request order
+type BatchGetResponse struct {
+	XMLName xml.Name `json:"-" xml:"batchGet" swaggerignore:"true"`
+
+	Count   int              `json:"count" xml:"count" example:"2"`
+	Found   int              `json:"found" xml:"found" example:"1"`
+	Results []BatchGetResult `json:"results" xml:"results>result"`
 }
 
-// HealthResponse represents a health check response
+// HealthResponse represents a health check response. The service is degraded while any of its components is down
 type HealthResponse struct {
-	Status  string `json:"status" example:"ok"`
-	Service string `json:"service" example:"rockets"`
+	Status     string            `json:"status" example:"ok" enums:"ok,degraded"`
+	Service    string            `json:"service" example:"rockets"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// ComponentHealth is the result of checking a component the service depends on
+type ComponentHealth struct {
+	Name      string  `json:"name" example:"repository"`
+	Status    string  `json:"status" example:"up" enums:"up,down"`
+	LatencyMs float64 `json:"latencyMs" example:"0.012"`
+	// LastError is the latest failure of the component, kept once it recovers
+	LastError   string     `json:"lastError,omitempty" example:"message queue closed"`
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty" example:"2022-02-02T19:39:05.86337+01:00"`
+}
+
+// ReadinessResponse tells whether the service can take traffic, and why not
+type ReadinessResponse struct {
+	Status string `json:"status" example:"ready" enums:"ready,unavailable"`
+	Reason string `json:"reason,omitempty" example:"pubsub: message queue closed"`
+}
+
+// GraphQLRequest is the body of a GraphQL query
+type GraphQLRequest struct {
+	Query         string                 `json:"query" binding:"required" example:"{ rockets(status: \"ACTIVE\") { id speed } }"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
 }
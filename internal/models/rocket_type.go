@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// RocketType is an entry of the catalog of rocket types observed in telemetry.
+// Types stay in the catalog once observed, even when no rocket of that type is left
+type RocketType struct {
+	XMLName xml.Name `json:"-" xml:"rocketType" swaggerignore:"true"`
+
+	Name        string       `json:"name" xml:"name,attr" example:"Falcon-9"`
+	RocketCount int          `json:"rocketCount" xml:"rocketCount" example:"3"`
+	ByStatus    StatusCounts `json:"byStatus" xml:"byStatus" swaggertype:"object,integer"`
+	Missions    []string     `json:"missions" xml:"missions>mission" example:"ARTEMIS"`
+	FirstSeen   time.Time    `json:"firstSeen" xml:"firstSeen" example:"2022-02-02T19:39:05.86337+01:00"`
+	LastSeen    time.Time    `json:"lastSeen" xml:"lastSeen" example:"2022-02-02T19:45:05.86337+01:00"`
+}
+
+// RocketTypeListResponse lists the rocket type catalog
+type RocketTypeListResponse struct {
+	XMLName xml.Name `json:"-" xml:"rocketTypes" swaggerignore:"true"`
+
+	Count int           `json:"count" xml:"count" example:"1"`
+	Types []*RocketType `json:"types" xml:"types>rocketType"`
+}
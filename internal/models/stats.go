@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ProcessingStats reports how far behind real time message processing runs, i.e. the delay between the time of a
+// message and its processing. Percentiles are computed over the most recent messages, in seconds
+type ProcessingStats struct {
+	XMLName           xml.Name `json:"-" xml:"processingStats" swaggerignore:"true"`
+	MessagesProcessed int64    `json:"messagesProcessed" xml:"messagesProcessed" example:"15230"` // Since the service started
+	Samples           int      `json:"samples" xml:"samples" example:"1000"`                      // Recent messages the percentiles are computed over
+	LagP50            float64  `json:"lagP50Seconds" xml:"lagP50Seconds" example:"0.012"`
+	LagP99            float64  `json:"lagP99Seconds" xml:"lagP99Seconds" example:"0.35"`
+	LagMax            float64  `json:"lagMaxSeconds" xml:"lagMaxSeconds" example:"1.2"`
+}
+
+// ChannelStats reports how the messages of a single channel were processed, since the service started
+type ChannelStats struct {
+	XMLName          xml.Name `json:"-" xml:"channelStats" swaggerignore:"true"`
+	Channel          string   `json:"channel" xml:"channel" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	MessagesReceived int64    `json:"messagesReceived" xml:"messagesReceived" example:"120"` // Submitted, whether applied or not
+	// LastMessageNumber is the number of the latest applied message
+	LastMessageNumber int64 `json:"lastMessageNumber" xml:"lastMessageNumber" example:"118"`
+	// Gaps counts the applied messages that skipped numbers, MissingMessages the numbers skipped overall
+	Gaps              int64      `json:"gaps" xml:"gaps" example:"1"`
+	MissingMessages   int64      `json:"missingMessages" xml:"missingMessages" example:"2"`
+	LastProcessingLag float64    `json:"lastProcessingLagSeconds" xml:"lastProcessingLagSeconds" example:"0.012"`
+	LastProcessedAt   *time.Time `json:"lastProcessedAt,omitempty" xml:"lastProcessedAt,omitempty"`
+	Dropped           DropCounts `json:"dropped" xml:"dropped"`
+}
+
+// DropCounts counts the messages that were not applied, by reason
+type DropCounts struct {
+	QueueFull   int64 `json:"queueFull" xml:"queueFull" example:"0"`
+	Duplicate   int64 `json:"duplicate" xml:"duplicate" example:"2"`
+	OutOfOrder  int64 `json:"outOfOrder" xml:"outOfOrder" example:"0"`
+	UnknownType int64 `json:"unknownType" xml:"unknownType" example:"0"`
+	Invalid     int64 `json:"invalid" xml:"invalid" example:"0"`
+}
+
+// IngestionStats reports the load of submitted messages: rates over rolling windows and counts since the service
+// started, overall and by message type. Messages are rejected when they fail validation or the queue is full
+type IngestionStats struct {
+	XMLName  xml.Name        `json:"-" xml:"ingestionStats" swaggerignore:"true"`
+	Rates    IngestionRates  `json:"rates" xml:"rates"`
+	Accepted int64           `json:"accepted" xml:"accepted" example:"15230"`
+	Rejected int64           `json:"rejected" xml:"rejected" example:"12"`
+	ByType   []TypeIngestion `json:"byType" xml:"byType>type"`
+}
+
+// IngestionRates are rates of submitted messages, accepted or not, in messages per second
+type IngestionRates struct {
+	Rate1m  float64 `json:"1m" xml:"rate1m" example:"12.5"`
+	Rate5m  float64 `json:"5m" xml:"rate5m" example:"10.2"`
+	Rate15m float64 `json:"15m" xml:"rate15m" example:"9.8"`
+}
+
+// TypeIngestion reports the load of the messages of a single type; unknown types are grouped as "unknown"
+type TypeIngestion struct {
+	Type     string         `json:"type" xml:"name" example:"RocketSpeedIncreased"`
+	Accepted int64          `json:"accepted" xml:"accepted" example:"9800"`
+	Rejected int64          `json:"rejected" xml:"rejected" example:"3"`
+	Rates    IngestionRates `json:"rates" xml:"rates"`
+}
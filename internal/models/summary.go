@@ -0,0 +1,59 @@
+package models
+
+import (
+	"encoding/xml"
+	"slices"
+	"strconv"
+)
+
+// StatusCounts counts rockets by status
+type StatusCounts map[RocketStatus]int
+
+// MarshalXML encodes the counts as status elements, as XML has no map type
+func (s StatusCounts) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	statuses := make([]RocketStatus, 0, len(s))
+	for status := range s {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		element := xml.StartElement{
+			Name: xml.Name{Local: "status"},
+			Attr: []xml.Attr{{Name: xml.Name{Local: "name"}, Value: string(status)}},
+		}
+		if err := e.EncodeElement(strconv.Itoa(s[status]), element); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// SpeedStats aggregates the current speed of a group of rockets
+type SpeedStats struct {
+	Min     int       `json:"min" xml:"min" example:"500"`
+	Max     int       `json:"max" xml:"max" example:"12000"`
+	Average float64   `json:"average" xml:"average" example:"4250.5"`
+	Total   int       `json:"total" xml:"total" example:"17002"`
+	Unit    SpeedUnit `json:"unit,omitempty" xml:"unit,omitempty" example:"kmh"` // Set when converted with ?units=
+}
+
+// GroupSummary aggregates the rockets sharing the same value of the grouping field
+type GroupSummary struct {
+	Key         string       `json:"key" xml:"key,attr" example:"Falcon-9"`
+	RocketCount int          `json:"rocketCount" xml:"rocketCount" example:"4"`
+	ByStatus    StatusCounts `json:"byStatus" xml:"byStatus" swaggertype:"object,integer"`
+	Speed       SpeedStats   `json:"speed" xml:"speed"`
+}
+
+// SummaryResponse lists the groups of a fleet summary
+type SummaryResponse struct {
+	XMLName xml.Name `json:"-" xml:"summary" swaggerignore:"true"`
+
+	GroupBy string          `json:"groupBy" xml:"groupBy" example:"type"`
+	Count   int             `json:"count" xml:"count" example:"3"`
+	Groups  []*GroupSummary `json:"groups" xml:"groups>group"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Position is the location of a rocket
+type Position struct {
+	Latitude  float64 `json:"latitude" xml:"latitude" example:"28.5721"`
+	Longitude float64 `json:"longitude" xml:"longitude" example:"-80.648"`
+	Altitude  float64 `json:"altitude" xml:"altitude" example:"12500"` // Meters above sea level
+}
+
+// RocketPositionUpdatedMessage reports the current position of a rocket
+type RocketPositionUpdatedMessage struct {
+	Latitude  *float64 `json:"latitude" example:"28.5721"`  // Degrees, from -90 to 90
+	Longitude *float64 `json:"longitude" example:"-80.648"` // Degrees, from -180 to 180
+	Altitude  *float64 `json:"altitude" example:"12500"`    // Meters above sea level, non-negative
+}
+
+// TrackPoint is a position of a rocket at the time it was reported
+type TrackPoint struct {
+	Position
+	MessageNumber int64     `json:"messageNumber" xml:"messageNumber" example:"7"`
+	Time          time.Time `json:"time" xml:"time" example:"2022-02-02T19:39:05.86337+01:00"`
+}
+
+// TrackResponse lists the recent positions of a rocket, oldest first
+type TrackResponse struct {
+	XMLName xml.Name `json:"-" xml:"track" swaggerignore:"true"`
+
+	ID     string       `json:"id" xml:"id" example:"193270a9-c9cf-404a-8f83-838e71d9ae67"`
+	Count  int          `json:"count" xml:"count" example:"1"`
+	Points []TrackPoint `json:"points" xml:"points>point"`
+}
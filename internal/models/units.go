@@ -0,0 +1,34 @@
+package models
+
+import "strings"
+
+// SpeedUnit is a unit in which speeds can be reported
+type SpeedUnit string
+
+const (
+	SpeedUnitKMH SpeedUnit = "kmh" // Kilometers per hour
+	SpeedUnitMPH SpeedUnit = "mph" // Miles per hour
+	SpeedUnitMS  SpeedUnit = "ms"  // Meters per second
+)
+
+// StoredSpeedUnit is the unit of the speeds reported by telemetry, in which rockets are stored
+const StoredSpeedUnit = SpeedUnitKMH
+
+// speedUnitFactors converts a speed in the stored unit to each unit
+var speedUnitFactors = map[SpeedUnit]float64{
+	SpeedUnitKMH: 1,
+	SpeedUnitMPH: 1 / 1.609344,
+	SpeedUnitMS:  1 / 3.6,
+}
+
+// ParseSpeedUnit resolves a speed unit case-insensitively
+func ParseSpeedUnit(value string) (SpeedUnit, bool) {
+	unit := SpeedUnit(strings.ToLower(value))
+	_, ok := speedUnitFactors[unit]
+	return unit, ok
+}
+
+// FromStored converts a speed expressed in the stored unit to this unit
+func (u SpeedUnit) FromStored(speed float64) float64 {
+	return speed * speedUnitFactors[u]
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// Webhook events, named after the change kinds of the rocket change feed
+const (
+	WebhookEventRocketUpdated = "rocket." + ChangeUpdated
+	WebhookEventRocketDeleted = "rocket." + ChangeDeleted
+)
+
+// WebhookEvents lists the events webhooks can subscribe to
+var WebhookEvents = []string{WebhookEventRocketUpdated, WebhookEventRocketDeleted}
+
+// Webhook is a subscription of an external endpoint to rocket state changes. Each change is POSTed
+// to the URL as a RocketChange, signed with the webhook secret
+type Webhook struct {
+	XMLName xml.Name `json:"-" xml:"webhook" swaggerignore:"true"`
+
+	ID        string    `json:"id" xml:"id,attr" example:"5a0c7e1d-3b2f-4c6a-9d8e-1f2a3b4c5d6e"`
+	URL       string    `json:"url" xml:"url" example:"https://example.com/hooks/rockets"`
+	Events    []string  `json:"events" xml:"events>event" example:"rocket.updated"`
+	Secret    string    `json:"secret,omitempty" xml:"secret,omitempty" example:"3f9a..."` // Only returned when the webhook is created
+	CreatedAt time.Time `json:"createdAt" xml:"createdAt" example:"2022-02-02T19:39:05.86337+01:00"`
+}
+
+// WebhookRequest registers a webhook. Events default to every event, and a secret is generated when none is given
+type WebhookRequest struct {
+	URL    string   `json:"url" binding:"required" example:"https://example.com/hooks/rockets"`
+	Events []string `json:"events" example:"rocket.updated"`
+	Secret string   `json:"secret" example:"my-shared-secret"`
+}
+
+// WebhookListResponse lists the registered webhooks
+type WebhookListResponse struct {
+	XMLName xml.Name `json:"-" xml:"webhookList" swaggerignore:"true"`
+
+	Count    int        `json:"count" xml:"count" example:"1"`
+	Webhooks []*Webhook `json:"webhooks" xml:"webhooks>webhook"`
+}
@@ -0,0 +1,175 @@
+// Package notify dispatches alerts, such as rocket explosions, to external notification sinks
+// (Slack, generic HTTP webhooks, email) with retries, and keeps a log of the recent deliveries.
+package notify
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// Sink delivers notifications to an external system
+type Sink interface {
+	// Name identifies the sink in the delivery log
+	Name() string
+	Send(ctx context.Context, notification *models.Notification) error
+}
+
+// Options tunes the dispatcher
+type Options struct {
+	QueueSize   int           // Notifications waiting for delivery; further ones are dropped
+	MaxAttempts int           // Delivery attempts per sink before giving up
+	Backoff     time.Duration // Delay before the first retry, doubled after each failed attempt
+	LogSize     int           // Deliveries kept in the delivery log
+}
+
+// DefaultOptions returns the dispatcher settings used when none are configured
+func DefaultOptions() Options {
+	return Options{
+		QueueSize:   100,
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		LogSize:     500,
+	}
+}
+
+// Dispatcher delivers notifications to every sink in the background
+type Dispatcher struct {
+	sinks []Sink
+	opts  Options
+	queue chan *models.Notification
+
+	mu         sync.Mutex
+	lastID     uint64
+	deliveries []*models.Delivery // oldest first
+}
+
+// NewDispatcher creates a dispatcher for the given sinks. Call Run to start delivering
+func NewDispatcher(sinks []Sink, opts Options) *Dispatcher {
+	return &Dispatcher{
+		sinks: sinks,
+		opts:  opts,
+		queue: make(chan *models.Notification, opts.QueueSize),
+	}
+}
+
+// Notify queues a notification for delivery without blocking. Notifications are dropped when
+// no sink is configured or the queue is full
+func (d *Dispatcher) Notify(notification *models.Notification) {
+	if len(d.sinks) == 0 {
+		return
+	}
+
+	select {
+	case d.queue <- notification:
+	default:
+		slog.Warn("Notification queue full, dropping notification", "kind", notification.Kind,
+			"rocket", notification.RocketID)
+	}
+}
+
+// Run delivers queued notifications until ctx is done
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case notification := <-d.queue:
+			d.dispatch(ctx, notification)
+		}
+	}
+}
+
+// Deliveries returns the logged deliveries, most recent first
+func (d *Dispatcher) Deliveries() []*models.Delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	deliveries := make([]*models.Delivery, 0, len(d.deliveries))
+	for i := len(d.deliveries) - 1; i >= 0; i-- {
+		delivery := *d.deliveries[i]
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries
+}
+
+// Forget removes the logged deliveries of the notifications about a rocket and returns how many were removed
+func (d *Dispatcher) Forget(rocketID string) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	logged := len(d.deliveries)
+	d.deliveries = slices.DeleteFunc(d.deliveries, func(delivery *models.Delivery) bool {
+		return delivery.RocketID == rocketID
+	})
+	return logged - len(d.deliveries)
+}
+
+// dispatch delivers a notification to every sink concurrently, so that a failing sink does not delay the others
+func (d *Dispatcher) dispatch(ctx context.Context, notification *models.Notification) {
+	var wg sync.WaitGroup
+	for _, sink := range d.sinks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			d.record(notification, sink, d.deliver(ctx, sink, notification))
+		}()
+	}
+	wg.Wait()
+}
+
+// deliver sends a notification to a sink, retrying with exponential backoff
+func (d *Dispatcher) deliver(ctx context.Context, sink Sink, notification *models.Notification) *models.Delivery {
+	delivery := &models.Delivery{Status: models.DeliveryFailed}
+	backoff := d.opts.Backoff
+
+	for attempt := 1; attempt <= d.opts.MaxAttempts; attempt++ {
+		delivery.Attempts = attempt
+
+		err := sink.Send(ctx, notification)
+		if err == nil {
+			delivery.Status = models.DeliveryDelivered
+			delivery.Error = ""
+			return delivery
+		}
+		delivery.Error = err.Error()
+
+		if attempt == d.opts.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return delivery
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+
+	slog.ErrorContext(ctx, "Giving up on notification", "kind", notification.Kind, "rocket", notification.RocketID,
+		"sink", sink.Name(), "attempts", delivery.Attempts, "error", delivery.Error)
+	return delivery
+}
+
+// record adds a delivery to the log, evicting the oldest one when the log is full
+func (d *Dispatcher) record(notification *models.Notification, sink Sink, delivery *models.Delivery) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.lastID++
+	delivery.ID = d.lastID
+	delivery.Sink = sink.Name()
+	delivery.Kind = notification.Kind
+	delivery.RocketID = notification.RocketID
+	delivery.Time = time.Now().UTC()
+
+	if len(d.deliveries) == d.opts.LogSize {
+		copy(d.deliveries, d.deliveries[1:])
+		d.deliveries = d.deliveries[:len(d.deliveries)-1]
+	}
+	d.deliveries = append(d.deliveries, delivery)
+}
@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flakySink fails as many sends as its failures count, then records the notifications it receives
+type flakySink struct {
+	mu       sync.Mutex
+	failures int
+	received []*models.Notification
+}
+
+func (s *flakySink) Name() string {
+	return "flaky"
+}
+
+func (s *flakySink) Send(ctx context.Context, notification *models.Notification) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("unavailable")
+	}
+	s.received = append(s.received, notification)
+	return nil
+}
+
+func TestDispatcher(t *testing.T) {
+	opts := Options{QueueSize: 10, MaxAttempts: 3, Backoff: time.Millisecond, LogSize: 10}
+
+	t.Run("deliveries are retried until they succeed", func(t *testing.T) {
+		sink := &flakySink{failures: 2}
+		d := NewDispatcher([]Sink{sink}, opts)
+
+		d.dispatch(context.Background(), &models.Notification{Kind: "Test", RocketID: "rocket"})
+
+		deliveries := d.Deliveries()
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, models.DeliveryDelivered, deliveries[0].Status)
+		assert.Equal(t, 3, deliveries[0].Attempts)
+		assert.Empty(t, deliveries[0].Error)
+		assert.Equal(t, "flaky", deliveries[0].Sink)
+		assert.Len(t, sink.received, 1)
+	})
+
+	t.Run("deliveries fail after the last attempt", func(t *testing.T) {
+		sink := &flakySink{failures: 5}
+		d := NewDispatcher([]Sink{sink}, opts)
+
+		d.dispatch(context.Background(), &models.Notification{Kind: "Test"})
+
+		deliveries := d.Deliveries()
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, models.DeliveryFailed, deliveries[0].Status)
+		assert.Equal(t, 3, deliveries[0].Attempts)
+		assert.Equal(t, "unavailable", deliveries[0].Error)
+		assert.Empty(t, sink.received)
+	})
+
+	t.Run("the delivery log keeps the most recent deliveries first", func(t *testing.T) {
+		d := NewDispatcher([]Sink{&flakySink{}}, Options{QueueSize: 10, MaxAttempts: 1, LogSize: 2})
+
+		for _, id := range []string{"a", "b", "c"} {
+			d.dispatch(context.Background(), &models.Notification{Kind: "Test", RocketID: id})
+		}
+
+		deliveries := d.Deliveries()
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, "c", deliveries[0].RocketID)
+		assert.Equal(t, "b", deliveries[1].RocketID)
+	})
+
+	t.Run("forgetting a rocket removes its deliveries", func(t *testing.T) {
+		d := NewDispatcher([]Sink{&flakySink{}}, opts)
+		for _, id := range []string{"a", "b", "a"} {
+			d.dispatch(context.Background(), &models.Notification{Kind: "Test", RocketID: id})
+		}
+
+		assert.Equal(t, 2, d.Forget("a"))
+		deliveries := d.Deliveries()
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, "b", deliveries[0].RocketID)
+	})
+}
+
+func TestObserve(t *testing.T) {
+	d := NewDispatcher([]Sink{&flakySink{}}, Options{QueueSize: 10, MaxAttempts: 1, LogSize: 10})
+	statuses := make(map[string]models.RocketStatus)
+
+	change := func(status models.RocketStatus) models.RocketChange {
+		return models.RocketChange{
+			Kind:     models.ChangeUpdated,
+			RocketID: "rocket",
+			Rocket:   &models.Rocket{ID: "rocket", Status: status},
+		}
+	}
+
+	// Rockets first seen exploded are not reported
+	observe(change(models.StatusExploded), statuses, d)
+	assert.Empty(t, d.queue)
+
+	observe(models.RocketChange{Kind: models.ChangeDeleted, RocketID: "rocket"}, statuses, d)
+	observe(change(models.StatusActive), statuses, d)
+	observe(change(models.StatusExploded), statuses, d)
+	observe(change(models.StatusExploded), statuses, d)
+
+	require.Len(t, d.queue, 1)
+	notification := <-d.queue
+	assert.Equal(t, models.NotificationRocketExploded, notification.Kind)
+	assert.Equal(t, "rocket", notification.RocketID)
+}
@@ -0,0 +1,129 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// sinkTimeout bounds a single delivery attempt
+const sinkTimeout = 10 * time.Second
+
+// SlackSink posts notifications to a Slack incoming webhook
+type SlackSink struct {
+	WebhookURL string
+	Client     *http.Client
+}
+
+func (s *SlackSink) Name() string {
+	return "slack"
+}
+
+func (s *SlackSink) Send(ctx context.Context, notification *models.Notification) error {
+	message := map[string]string{
+		"text": fmt.Sprintf("*%s*\n%s", notification.Title, notification.Text),
+	}
+	return postJSON(ctx, s.Client, s.WebhookURL, nil, message)
+}
+
+// WebhookSink posts notifications as JSON to an HTTP endpoint
+type WebhookSink struct {
+	URL     string
+	Headers map[string]string // Sent with every request, e.g. an Authorization header
+	Client  *http.Client
+}
+
+func (s *WebhookSink) Name() string {
+	return "webhook"
+}
+
+func (s *WebhookSink) Send(ctx context.Context, notification *models.Notification) error {
+	return postJSON(ctx, s.Client, s.URL, s.Headers, notification)
+}
+
+// EmailSink emails notifications through an SMTP server
+type EmailSink struct {
+	Addr     string // SMTP server, host:port
+	Username string // Authenticates with PLAIN auth when set
+	Password string
+	From     string
+	To       []string
+}
+
+func (s *EmailSink) Name() string {
+	return "email"
+}
+
+func (s *EmailSink) Send(ctx context.Context, notification *models.Notification) error {
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, _ := strings.Cut(s.Addr, ":")
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", notification.Title)
+	fmt.Fprintf(&msg, "Date: %s\r\n", notification.Time.Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(notification.Text)
+	msg.WriteString("\r\n")
+
+	// net/smtp has no context support; run the exchange in the background so ctx still bounds the attempt
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.Addr, auth, s.From, s.To, msg.Bytes())
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// postJSON posts body as JSON, failing on any non-2xx response
+func postJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, sinkTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ahernandez9/rockets/internal/feed"
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// WatchExplosions follows the change feed and notifies the dispatcher of every rocket that explodes, until ctx is done.
+// Rockets first seen already exploded, e.g. restored from a dump, are not reported
+func WatchExplosions(ctx context.Context, changes *feed.Feed, d *Dispatcher) {
+	statuses := make(map[string]models.RocketStatus)
+	changes.Follow(ctx, func(change models.RocketChange) {
+		observe(change, statuses, d)
+	})
+}
+
+// observe tracks the status of a rocket, notifying when it turns EXPLODED
+func observe(change models.RocketChange, statuses map[string]models.RocketStatus, d *Dispatcher) {
+	if change.Kind == models.ChangeDeleted || change.Rocket == nil {
+		delete(statuses, change.RocketID)
+		return
+	}
+
+	rocket := change.Rocket
+	previous, known := statuses[rocket.ID]
+	statuses[rocket.ID] = rocket.Status
+
+	if known && previous != models.StatusExploded && rocket.Status == models.StatusExploded {
+		d.Notify(&models.Notification{
+			Kind:     models.NotificationRocketExploded,
+			RocketID: rocket.ID,
+			Title:    "Rocket exploded",
+			Text: fmt.Sprintf("Rocket %s (%s, mission %s) exploded: %s",
+				rocket.ID, rocket.Type, rocket.Mission, rocket.ExplosionReason),
+			Time:   rocket.LastUpdated,
+			Rocket: rocket,
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Package problem writes RFC 7807 problem details error responses
+package problem
+
+import (
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContentType is the media type of problem details responses
+const ContentType = "application/problem+json"
+
+// New builds the problem details of an error occurring while serving the request
+func New(c *gin.Context, status int, code models.ErrorCode, title, detail string) models.Problem {
+	return models.Problem{
+		Type:      models.ProblemTypeBlank,
+		Title:     title,
+		Status:    status,
+		Detail:    detail,
+		Instance:  c.Request.URL.Path,
+		ErrorCode: code,
+		RequestID: logging.RequestID(c.Request.Context()),
+	}
+}
+
+// Respond writes a problem details response
+func Respond(c *gin.Context, status int, code models.ErrorCode, title, detail string) {
+	// Gin keeps an explicitly set content type when rendering JSON
+	c.Header("Content-Type", ContentType)
+	c.JSON(status, New(c, status, code, title, detail))
+}
+
+// Abort writes a problem details response and stops the handler chain
+func Abort(c *gin.Context, status int, code models.ErrorCode, title, detail string) {
+	c.Abort()
+	Respond(c, status, code, title, detail)
+}
@@ -2,38 +2,63 @@ package channel
 
 import (
 	"context"
-	"fmt"
-	"log"
+	"log/slog"
+	"sync/atomic"
 
 	"github.com/ahernandez9/rockets/internal/models"
 	"github.com/ahernandez9/rockets/internal/pubsub"
+	"github.com/ahernandez9/rockets/internal/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// envelope carries a message through the channel along with the trace context of its publisher,
+// as a message broker would carry it in message headers
+type envelope struct {
+	msg     *models.RocketMessage
+	headers propagation.MapCarrier
+}
+
 // PubSub implements PubSub using Go channels
 type PubSub struct {
-	messageChan chan *models.RocketMessage
-	closed      bool
+	messageChan chan envelope
+	closed      atomic.Bool
 }
 
 // NewPubSub creates a new channel-based pub/sub
 func NewPubSub(bufferSize int) *PubSub {
 	return &PubSub{
-		messageChan: make(chan *models.RocketMessage, bufferSize),
+		messageChan: make(chan envelope, bufferSize),
 	}
 }
 
 // Publish sends a message to the channel
 func (p *PubSub) Publish(ctx context.Context, msg *models.RocketMessage) error {
+	ctx, span := tracing.Tracer().Start(ctx, "rockets publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messageAttributes(msg)...))
+	defer span.End()
+
+	headers := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, headers)
+
 	select {
-	case p.messageChan <- msg:
-		log.Printf("Message published: channel=%s, type=%s, number=%d",
-			msg.Metadata.Channel, msg.Metadata.MessageType, msg.Metadata.MessageNumber)
+	case p.messageChan <- envelope{msg: msg, headers: headers}:
+		slog.DebugContext(ctx, "Message published", "channel", msg.Metadata.Channel,
+			"messageNumber", msg.Metadata.MessageNumber, "messageType", msg.Metadata.MessageType)
 		return nil
 	case <-ctx.Done():
+		span.SetStatus(codes.Error, ctx.Err().Error())
 		return ctx.Err()
 	default:
-		log.Printf("Warning: message channel full, dropping message: channel=%s", msg.Metadata.Channel)
-		return fmt.Errorf("channel full")
+		slog.WarnContext(ctx, "Message queue full, dropping message", "channel", msg.Metadata.Channel,
+			"messageNumber", msg.Metadata.MessageNumber)
+		span.SetStatus(codes.Error, pubsub.ErrQueueFull.Error())
+		return pubsub.ErrQueueFull
 		// trade-off: we don't want to block HTTP handlers (bad UX) nor store overflow messages in memory (dangerous)
 		// for a Production ready system, consider using a persistent message broker like RabbitMQ, or Redis Streams
 	}
@@ -43,26 +68,60 @@ func (p *PubSub) Publish(ctx context.Context, msg *models.RocketMessage) error {
 func (p *PubSub) Subscribe(ctx context.Context, handler pubsub.MessageHandler) error {
 	for {
 		select {
-		case msg, ok := <-p.messageChan:
+		case next, ok := <-p.messageChan:
 			if !ok {
-				log.Println("PubSub: Channel closed")
+				slog.Info("Message queue closed")
 				return nil
 			}
-			if err := handler(ctx, msg); err != nil {
-				log.Printf("PubSub: Error handling message: %v", err)
+			if err := p.deliver(ctx, handler, next); err != nil {
+				slog.Error("Failed to handle message", "channel", next.msg.Metadata.Channel,
+					"messageNumber", next.msg.Metadata.MessageNumber, "error", err)
 			}
 		case <-ctx.Done():
-			log.Println("PubSub: Context canceled")
+			slog.Info("Message subscriber canceled")
 			return ctx.Err()
 		}
 	}
 }
 
+// deliver calls handler for a message within a span continuing the trace of its publisher
+func (p *PubSub) deliver(ctx context.Context, handler pubsub.MessageHandler, next envelope) error {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, next.headers)
+	ctx, span := tracing.Tracer().Start(ctx, "rockets process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messageAttributes(next.msg)...))
+	defer span.End()
+
+	err := handler(ctx, next.msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
+
 // Close closes the pub/sub channel
 func (p *PubSub) Close() error {
-	if !p.closed {
-		p.closed = true
+	if p.closed.CompareAndSwap(false, true) {
 		close(p.messageChan)
 	}
 	return nil
 }
+
+// Ping fails once the pub/sub is closed
+func (p *PubSub) Ping(ctx context.Context) error {
+	if p.closed.Load() {
+		return pubsub.ErrClosed
+	}
+	return ctx.Err()
+}
+
+// messageAttributes describes a message on its publish and process spans
+func messageAttributes(msg *models.RocketMessage) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("messaging.system", "go_channel"),
+		attribute.String("rocket.channel", msg.Metadata.Channel),
+		attribute.String("rocket.message_type", msg.Metadata.MessageType),
+		attribute.Int64("rocket.message_number", msg.Metadata.MessageNumber),
+	}
+}
@@ -56,6 +56,20 @@ func (mr *MockPublisherMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPublisher)(nil).Close))
 }
 
+// Ping mocks base method.
+func (m *MockPublisher) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockPublisherMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockPublisher)(nil).Ping), ctx)
+}
+
 // Publish mocks base method.
 func (m *MockPublisher) Publish(ctx context.Context, msg *models.RocketMessage) error {
 	m.ctrl.T.Helper()
@@ -160,6 +174,20 @@ func (mr *MockInterfaceMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockInterface)(nil).Close))
 }
 
+// Ping mocks base method.
+func (m *MockInterface) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockInterfaceMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockInterface)(nil).Ping), ctx)
+}
+
 // Publish mocks base method.
 func (m *MockInterface) Publish(ctx context.Context, msg *models.RocketMessage) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,64 @@
+package nats
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/nats-io/nats-server/v2/server"
+)
+
+// EmbeddedOptions configures a NATS server run within the process
+type EmbeddedOptions struct {
+	Addr     string // host:port the server listens on, also reachable by tools such as the nats CLI
+	StoreDir string // Directory of the JetStream files; a temporary directory removed on shutdown when empty
+}
+
+// RunEmbedded starts a NATS server with JetStream within the process, so that the broker code paths run locally
+// without a broker to deploy. It returns the URL of the server once it accepts connections, and the function
+// shutting it down
+func RunEmbedded(opts EmbeddedOptions) (url string, shutdown func(), err error) {
+	host, rawPort, err := net.SplitHostPort(opts.Addr)
+	if err != nil {
+		return "", nil, err
+	}
+	port, err := strconv.Atoi(rawPort)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid port %q", rawPort)
+	}
+
+	storeDir, cleanup := opts.StoreDir, func() {}
+	if storeDir == "" {
+		if storeDir, err = os.MkdirTemp("", "rockets-nats-"); err != nil {
+			return "", nil, err
+		}
+		cleanup = func() { os.RemoveAll(storeDir) }
+	}
+
+	srv, err := server.NewServer(&server.Options{
+		ServerName: "rockets-embedded",
+		Host:       host,
+		Port:       port,
+		JetStream:  true,
+		StoreDir:   storeDir,
+		NoSigs:     true,
+	})
+	if err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	go srv.Start()
+	if !srv.ReadyForConnections(setupTimeout) {
+		srv.Shutdown()
+		cleanup()
+		return "", nil, errors.New("embedded NATS server not ready in time")
+	}
+
+	return srv.ClientURL(), func() {
+		srv.Shutdown()
+		srv.WaitForShutdown()
+		cleanup()
+	}, nil
+}
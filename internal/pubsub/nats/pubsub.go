@@ -0,0 +1,295 @@
+// Package nats implements the pub/sub on NATS JetStream, a broker keeping the messages until they are processed, so
+// that they survive restarts of the service and are redelivered when their processing fails.
+package nats
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/bufpool"
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/pubsub"
+	"github.com/ahernandez9/rockets/internal/tracing"
+
+	natsclient "github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// requestIDHeader carries the request that submitted a message, which is not part of its JSON
+	requestIDHeader = "Rockets-Request-Id"
+	// encryptionHeader marks the messages whose data is sealed, so that the ones published before encryption was
+	// enabled are still read
+	encryptionHeader = "Rockets-Encryption"
+	encryptionAESGCM = "aes-gcm"
+	// setupTimeout bounds the creation of the stream and the consumer
+	setupTimeout = 10 * time.Second
+	// retryDelay is waited before redelivering a message whose processing failed, and before consuming again after
+	// the consumer failed
+	retryDelay = time.Second
+)
+
+// Options configures the broker connection, and the stream and consumer the messages go through
+type Options struct {
+	URL        string        // e.g. nats://localhost:4222
+	Stream     string        // Stream keeping the messages, created when missing
+	Subject    string        // Prefix of the subjects of the messages, followed by their channel
+	Consumer   string        // Durable consumer shared by the processors
+	AckWait    time.Duration // Time a message is processed before it is delivered again
+	MaxDeliver int           // Deliveries of a message before it is given up
+	// EncryptionKey seals the data of the messages with AES-GCM before they reach the stream, so that the broker
+	// never stores them in plaintext. A key of 16, 24 or 32 bytes; the messages are not encrypted when empty
+	EncryptionKey []byte
+}
+
+// DefaultOptions returns the broker settings used when none are configured
+func DefaultOptions() Options {
+	return Options{
+		URL:        natsclient.DefaultURL,
+		Stream:     "ROCKETS",
+		Subject:    "rockets.messages",
+		Consumer:   "rockets-processor",
+		AckWait:    30 * time.Second,
+		MaxDeliver: 5,
+	}
+}
+
+// PubSub implements PubSub on a JetStream stream, removing the messages once processed
+type PubSub struct {
+	conn   *natsclient.Conn
+	js     jetstream.JetStream
+	stream jetstream.Stream
+	aead   cipher.AEAD // Nil when the messages are not encrypted
+	opts   Options
+}
+
+// NewPubSub connects to the broker and creates the stream of the messages when missing
+func NewPubSub(opts Options) (*PubSub, error) {
+	var aead cipher.AEAD
+	if len(opts.EncryptionKey) > 0 {
+		block, err := aes.NewCipher(opts.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid encryption key: %w", err)
+		}
+		if aead, err = cipher.NewGCM(block); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := natsclient.Connect(opts.URL, natsclient.Name("rockets"), natsclient.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS at %s: %w", opts.URL, err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), setupTimeout)
+	defer cancel()
+	stream, err := js.CreateOrUpdateStream(ctx, jetstream.StreamConfig{
+		Name:      opts.Stream,
+		Subjects:  []string{opts.Subject + ".>"},
+		Retention: jetstream.WorkQueuePolicy,
+		Storage:   jetstream.FileStorage,
+	})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("creating stream %s: %w", opts.Stream, err)
+	}
+
+	return &PubSub{conn: conn, js: js, stream: stream, aead: aead, opts: opts}, nil
+}
+
+// Publish stores a message in the stream, on the subject of its channel
+func (p *PubSub) Publish(ctx context.Context, msg *models.RocketMessage) error {
+	ctx, span := tracing.Tracer().Start(ctx, "rockets publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(messageAttributes(msg)...))
+	defer span.End()
+
+	// The client copies the data on publishing, the buffer is free again once published
+	buf := bufpool.Get()
+	defer bufpool.Put(buf)
+	if err := jsoncodec.MarshalTo(buf, msg); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return err
+	}
+	out := natsclient.NewMsg(p.opts.Subject + "." + msg.Metadata.Channel)
+	out.Data = buf.Bytes()
+	if p.aead != nil {
+		out.Data = p.seal(out.Data, out.Subject)
+		out.Header.Set(encryptionHeader, encryptionAESGCM)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(out.Header))
+	if msg.Metadata.RequestID != "" {
+		out.Header.Set(requestIDHeader, msg.Metadata.RequestID)
+	}
+
+	if _, err := p.js.PublishMsg(ctx, out); err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		return fmt.Errorf("publishing to NATS: %w", err)
+	}
+	slog.DebugContext(ctx, "Message published", "channel", msg.Metadata.Channel,
+		"messageNumber", msg.Metadata.MessageNumber, "messageType", msg.Metadata.MessageType)
+	return nil
+}
+
+// Subscribe consumes the messages of the stream through the durable consumer until ctx is done or the pub/sub is
+// closed. Processed messages are acknowledged; the others are delivered again after a delay, up to MaxDeliver times
+func (p *PubSub) Subscribe(ctx context.Context, handler pubsub.MessageHandler) error {
+	setupCtx, cancel := context.WithTimeout(ctx, setupTimeout)
+	consumer, err := p.stream.CreateOrUpdateConsumer(setupCtx, jetstream.ConsumerConfig{
+		Durable:    p.opts.Consumer,
+		AckPolicy:  jetstream.AckExplicitPolicy,
+		AckWait:    p.opts.AckWait,
+		MaxDeliver: p.opts.MaxDeliver,
+	})
+	cancel()
+	if err != nil {
+		return fmt.Errorf("creating consumer %s: %w", p.opts.Consumer, err)
+	}
+
+	for {
+		err := p.consume(ctx, consumer, handler)
+		switch {
+		case ctx.Err() != nil:
+			slog.Info("Message subscriber canceled")
+			return ctx.Err()
+		case p.conn.IsClosed():
+			slog.Info("Message queue closed")
+			return nil
+		}
+		slog.Warn("NATS consumer failed, consuming again", "error", err)
+		select {
+		case <-time.After(retryDelay):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// consume handles the messages of consumer one at a time, in order, until ctx is done or the consumer fails
+func (p *PubSub) consume(ctx context.Context, consumer jetstream.Consumer, handler pubsub.MessageHandler) error {
+	messages, err := consumer.Messages()
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, messages.Stop)
+	defer stop()
+	defer messages.Stop()
+
+	for {
+		next, err := messages.Next()
+		if err != nil {
+			return err
+		}
+		p.deliver(ctx, handler, next)
+	}
+}
+
+// deliver calls handler for a message within a span continuing the trace of its publisher, and acknowledges it
+func (p *PubSub) deliver(ctx context.Context, handler pubsub.MessageHandler, next jetstream.Msg) {
+	data, err := p.open(next)
+	if err != nil {
+		slog.Error("Dropping undecryptable message", "subject", next.Subject(), "error", err)
+		_ = next.Term()
+		return
+	}
+	var msg models.RocketMessage
+	if err := jsoncodec.Unmarshal(data, &msg); err != nil {
+		slog.Error("Dropping undecodable message", "subject", next.Subject(), "error", err)
+		_ = next.Term()
+		return
+	}
+	msg.Metadata.RequestID = next.Headers().Get(requestIDHeader)
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(next.Headers()))
+	ctx, span := tracing.Tracer().Start(ctx, "rockets process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(messageAttributes(&msg)...))
+	defer span.End()
+
+	err = handler(ctx, &msg)
+	if err == nil {
+		if err := next.Ack(); err != nil {
+			slog.WarnContext(ctx, "Failed to acknowledge message", "channel", msg.Metadata.Channel,
+				"messageNumber", msg.Metadata.MessageNumber, "error", err)
+		}
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	var delivered uint64
+	if meta, metaErr := next.Metadata(); metaErr == nil {
+		delivered = meta.NumDelivered
+	}
+	slog.ErrorContext(ctx, "Failed to handle message", "channel", msg.Metadata.Channel,
+		"messageNumber", msg.Metadata.MessageNumber, "delivery", delivered, "error", err)
+	_ = next.NakWithDelay(retryDelay)
+}
+
+// seal encrypts data bound to the subject of its message, returning the random nonce followed by the ciphertext
+func (p *PubSub) seal(data []byte, subject string) []byte {
+	nonce := make([]byte, p.aead.NonceSize(), p.aead.NonceSize()+len(data)+p.aead.Overhead())
+	_, _ = rand.Read(nonce) // Never fails, see crypto/rand
+	return p.aead.Seal(nonce, nonce, data, []byte(subject))
+}
+
+// open returns the data of a message, decrypted when it was sealed
+func (p *PubSub) open(msg jetstream.Msg) ([]byte, error) {
+	switch scheme := msg.Headers().Get(encryptionHeader); {
+	case scheme == "":
+		return msg.Data(), nil
+	case scheme != encryptionAESGCM:
+		return nil, fmt.Errorf("unknown encryption %q", scheme)
+	case p.aead == nil:
+		return nil, errors.New("encrypted message but no encryption key configured")
+	}
+	data := msg.Data()
+	if len(data) < p.aead.NonceSize() {
+		return nil, errors.New("encrypted message too short")
+	}
+	nonce, ciphertext := data[:p.aead.NonceSize()], data[p.aead.NonceSize():]
+	return p.aead.Open(nil, nonce, ciphertext, []byte(msg.Subject()))
+}
+
+// Close closes the connection to the broker, which keeps the messages not processed yet
+func (p *PubSub) Close() error {
+	p.conn.Close()
+	return nil
+}
+
+// Ping fails while the broker is unreachable
+func (p *PubSub) Ping(ctx context.Context) error {
+	if p.conn.IsClosed() {
+		return pubsub.ErrClosed
+	}
+	if status := p.conn.Status(); status != natsclient.CONNECTED {
+		return fmt.Errorf("NATS connection %s", status)
+	}
+	return ctx.Err()
+}
+
+// messageAttributes describes a message on its publish and process spans
+func messageAttributes(msg *models.RocketMessage) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("messaging.system", "nats"),
+		attribute.String("rocket.channel", msg.Metadata.Channel),
+		attribute.String("rocket.message_type", msg.Metadata.MessageType),
+		attribute.Int64("rocket.message_number", msg.Metadata.MessageNumber),
+	}
+}
@@ -0,0 +1,100 @@
+package nats
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubSubRedeliversFailedMessages(t *testing.T) {
+	url, shutdown, err := RunEmbedded(EmbeddedOptions{Addr: "127.0.0.1:-1", StoreDir: t.TempDir()})
+	require.NoError(t, err)
+	defer shutdown()
+
+	opts := DefaultOptions()
+	opts.URL = url
+	ps, err := NewPubSub(opts)
+	require.NoError(t, err)
+	defer ps.Close()
+	require.NoError(t, ps.Ping(context.Background()))
+
+	msg := &models.RocketMessage{
+		Metadata: models.MessageMetadata{Channel: "193270a9-c9cf-404a-8f83-838e71d9ae67", MessageNumber: 1,
+			MessageType: "RocketLaunched", RequestID: "req-1"},
+		Message: json.RawMessage(`{"type":"Falcon-9","launchSpeed":500,"mission":"ARTEMIS"}`),
+	}
+	require.NoError(t, ps.Publish(context.Background(), msg))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var deliveries []*models.RocketMessage
+	err = ps.Subscribe(ctx, func(_ context.Context, received *models.RocketMessage) error {
+		deliveries = append(deliveries, received)
+		if len(deliveries) == 1 {
+			return errors.New("repository unavailable")
+		}
+		cancel()
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.Len(t, deliveries, 2, "the failed delivery is retried")
+	assert.Equal(t, msg.Metadata, deliveries[1].Metadata, "the request ID travels in the headers")
+	assert.JSONEq(t, string(msg.Message), string(deliveries[1].Message))
+
+	assert.Eventually(t, func() bool {
+		info, err := ps.stream.Info(context.Background())
+		return err == nil && info.State.Msgs == 0
+	}, time.Second, 10*time.Millisecond, "acknowledged messages are removed")
+}
+
+func TestPubSubEncryptsStoredMessages(t *testing.T) {
+	url, shutdown, err := RunEmbedded(EmbeddedOptions{Addr: "127.0.0.1:-1", StoreDir: t.TempDir()})
+	require.NoError(t, err)
+	defer shutdown()
+
+	opts := DefaultOptions()
+	opts.URL = url
+	opts.EncryptionKey = []byte("short")
+	_, err = NewPubSub(opts)
+	require.ErrorContains(t, err, "invalid encryption key")
+
+	opts.EncryptionKey = bytes.Repeat([]byte{0x2a}, 32)
+	ps, err := NewPubSub(opts)
+	require.NoError(t, err)
+	defer ps.Close()
+
+	msg := &models.RocketMessage{
+		Metadata: models.MessageMetadata{Channel: "193270a9-c9cf-404a-8f83-838e71d9ae67", MessageNumber: 1,
+			MessageType: "RocketLaunched"},
+		Message: json.RawMessage(`{"type":"Falcon-9","launchSpeed":500,"mission":"ARTEMIS"}`),
+	}
+	require.NoError(t, ps.Publish(context.Background(), msg))
+
+	stored, err := ps.stream.GetMsg(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, encryptionAESGCM, stored.Header.Get(encryptionHeader))
+	assert.NotContains(t, string(stored.Data), "ARTEMIS", "the broker stores the sealed message")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var received *models.RocketMessage
+	err = ps.Subscribe(ctx, func(_ context.Context, msg *models.RocketMessage) error {
+		received = msg
+		cancel()
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	require.NotNil(t, received)
+	assert.Equal(t, msg.Metadata, received.Metadata)
+	assert.JSONEq(t, string(msg.Message), string(received.Message))
+}
@@ -2,11 +2,22 @@ package pubsub
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ahernandez9/rockets/internal/models"
 )
 
-// MessageHandler processes received messages (callback function)
+var (
+	// ErrQueueFull is returned by publishers that cannot accept more messages without blocking
+	ErrQueueFull = errors.New("message queue full")
+
+	// ErrClosed is returned by a pub/sub that was closed
+	ErrClosed = errors.New("message queue closed")
+)
+
+// MessageHandler processes received messages (callback function). Returning nil acknowledges the message, while an
+// error leaves it to the implementation whether to redeliver it: the channel pub/sub logs and drops it, a broker
+// would requeue it
 type MessageHandler func(ctx context.Context, msg *models.RocketMessage) error
 
 //go:generate go run go.uber.org/mock/mockgen -source=pubsub.go -destination=mocks/mock_pubsub.go -package=mocks
@@ -14,6 +25,8 @@ type MessageHandler func(ctx context.Context, msg *models.RocketMessage) error
 // Publisher defines the interface for publishing messages
 type Publisher interface {
 	Publish(ctx context.Context, msg *models.RocketMessage) error
+	// Ping tells whether messages can be published, e.g. whether the broker is reachable
+	Ping(ctx context.Context) error
 	Close() error
 }
 
@@ -0,0 +1,38 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// Bucket is a token bucket: requests take a token, tokens are refilled at a steady rate up to a burst
+type Bucket struct {
+	rate  float64 // Tokens per second
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket creates a full bucket refilled with perSecond tokens, holding up to burst tokens
+func NewBucket(perSecond float64, burst int) *Bucket {
+	return &Bucket{rate: perSecond, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Take takes a token at now. When none is left, it returns false with the time until the next one
+func (b *Bucket) Take(now time.Time) (bool, time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.last.IsZero() {
+		b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
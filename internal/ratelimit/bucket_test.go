@@ -0,0 +1,31 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBucket(t *testing.T) {
+	bucket := NewBucket(2, 3)
+	now := time.Date(2022, 2, 2, 19, 39, 5, 0, time.UTC)
+
+	for range 3 {
+		ok, _ := bucket.Take(now)
+		assert.True(t, ok, "the burst is available at once")
+	}
+	ok, wait := bucket.Take(now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	ok, _ = bucket.Take(now.Add(500 * time.Millisecond))
+	assert.True(t, ok, "tokens are refilled at the rate")
+
+	for range 3 {
+		ok, _ = bucket.Take(now.Add(time.Hour))
+		assert.True(t, ok)
+	}
+	ok, _ = bucket.Take(now.Add(time.Hour))
+	assert.False(t, ok, "tokens don't accumulate beyond the burst")
+}
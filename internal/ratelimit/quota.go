@@ -0,0 +1,162 @@
+// Package ratelimit keeps callers and routes within their share of the service: per-caller rate limits and quotas,
+// so that one chatty producer can't starve the others.
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// Limits bounds the requests of a caller; zero values are unlimited
+type Limits struct {
+	PerMinute int // Requests per minute, counted in fixed windows
+	PerDay    int // Requests per UTC day
+}
+
+// Limits exceeded by refused requests
+const (
+	ExceededRate  = "rate"  // Requests per minute
+	ExceededQuota = "quota" // Requests per day
+)
+
+// Decision is the outcome of a request against the limits of its caller
+type Decision struct {
+	Allowed   bool
+	Exceeded  string    // Limit refusing the request, one of the Exceeded values; empty when allowed
+	Limit     int       // Tightest limit applying to the caller, 0 when unlimited
+	Remaining int       // Requests left under Limit
+	Reset     time.Time // End of the window of Limit
+}
+
+// Quotas counts the requests of every caller against their limits
+type Quotas struct {
+	defaults Limits
+
+	mu     sync.Mutex
+	usages map[string]*usage
+}
+
+// usage is the count of requests of a caller
+type usage struct {
+	minute      time.Time // Start of the current minute window
+	minuteCount int
+	day         time.Time // Start of the current day
+	dayCount    int
+	total       int64
+	denied      int64
+}
+
+// NewQuotas creates a tracker applying defaults to callers without limits of their own
+func NewQuotas(defaults Limits) *Quotas {
+	return &Quotas{defaults: defaults, usages: make(map[string]*usage)}
+}
+
+// SetDefaults changes the limits of callers without limits of their own, keeping the requests counted so far
+func (q *Quotas) SetDefaults(defaults Limits) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.defaults = defaults
+}
+
+// Take counts a request of caller at now unless it exceeds limits, where zero values fall back to the defaults
+func (q *Quotas) Take(caller string, limits Limits, now time.Time) Decision {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if limits.PerMinute == 0 {
+		limits.PerMinute = q.defaults.PerMinute
+	}
+	if limits.PerDay == 0 {
+		limits.PerDay = q.defaults.PerDay
+	}
+
+	u, ok := q.usages[caller]
+	if !ok {
+		u = &usage{}
+		q.usages[caller] = u
+	}
+
+	now = now.UTC()
+	if minute := now.Truncate(time.Minute); !minute.Equal(u.minute) {
+		u.minute, u.minuteCount = minute, 0
+	}
+	if day := now.Truncate(24 * time.Hour); !day.Equal(u.day) {
+		u.day, u.dayCount = day, 0
+	}
+
+	rate := window{limits.PerMinute, u.minuteCount, u.minute.Add(time.Minute)}
+	quota := window{limits.PerDay, u.dayCount, u.day.Add(24 * time.Hour)}
+
+	decision := Decision{Allowed: true}
+	switch {
+	case quota.exhausted():
+		decision = quota.decision(false)
+		decision.Exceeded = ExceededQuota
+	case rate.exhausted():
+		decision = rate.decision(false)
+		decision.Exceeded = ExceededRate
+	}
+	if !decision.Allowed {
+		u.denied++
+		return decision
+	}
+
+	u.minuteCount++
+	u.dayCount++
+	u.total++
+	rate.used++
+	quota.used++
+
+	// Callers are told about the limit they'll hit first
+	switch {
+	case rate.limit > 0 && (quota.limit == 0 || rate.remaining() <= quota.remaining()):
+		return rate.decision(true)
+	case quota.limit > 0:
+		return quota.decision(true)
+	}
+	return decision
+}
+
+// Usage reports the requests of every caller seen since the service started, sorted by caller
+func (q *Quotas) Usage(now time.Time) []models.KeyUsage {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now = now.UTC()
+	usages := make([]models.KeyUsage, 0, len(q.usages))
+	for caller, u := range q.usages {
+		usage := models.KeyUsage{Name: caller, Total: u.total, Denied: u.denied}
+		if u.minute.Equal(now.Truncate(time.Minute)) {
+			usage.LastMinute = u.minuteCount
+		}
+		if u.day.Equal(now.Truncate(24 * time.Hour)) {
+			usage.Today = u.dayCount
+		}
+		usages = append(usages, usage)
+	}
+
+	sort.Slice(usages, func(i, j int) bool { return usages[i].Name < usages[j].Name })
+	return usages
+}
+
+// window is the count of requests against a limit over a fixed window
+type window struct {
+	limit int
+	used  int
+	reset time.Time
+}
+
+func (w window) exhausted() bool {
+	return w.limit > 0 && w.used >= w.limit
+}
+
+func (w window) remaining() int {
+	return max(w.limit-w.used, 0)
+}
+
+func (w window) decision(allowed bool) Decision {
+	return Decision{Allowed: allowed, Limit: w.limit, Remaining: w.remaining(), Reset: w.reset}
+}
@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotasRateLimit(t *testing.T) {
+	quotas := NewQuotas(Limits{PerMinute: 2})
+	now := time.Date(2022, 2, 2, 19, 39, 5, 0, time.UTC)
+
+	decision := quotas.Take("producer-eu", Limits{}, now)
+	assert.True(t, decision.Allowed)
+	assert.Equal(t, 2, decision.Limit)
+	assert.Equal(t, 1, decision.Remaining)
+	assert.Equal(t, time.Date(2022, 2, 2, 19, 40, 0, 0, time.UTC), decision.Reset)
+
+	assert.True(t, quotas.Take("producer-eu", Limits{}, now).Allowed)
+	decision = quotas.Take("producer-eu", Limits{}, now)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, ExceededRate, decision.Exceeded)
+
+	assert.True(t, quotas.Take("producer-us", Limits{}, now).Allowed, "callers are limited separately")
+	assert.True(t, quotas.Take("producer-eu", Limits{}, now.Add(time.Minute)).Allowed, "the limit resets every minute")
+	assert.True(t, quotas.Take("producer-eu", Limits{PerMinute: 10}, now.Add(time.Minute)).Allowed, "callers may have their own limit")
+}
+
+func TestQuotasDailyQuota(t *testing.T) {
+	quotas := NewQuotas(Limits{PerMinute: 10, PerDay: 3})
+	now := time.Date(2022, 2, 2, 23, 59, 0, 0, time.UTC)
+
+	for i := range 3 {
+		decision := quotas.Take("producer-eu", Limits{}, now.Add(time.Duration(i)*time.Second))
+		require.True(t, decision.Allowed)
+	}
+	decision := quotas.Take("producer-eu", Limits{}, now)
+	assert.False(t, decision.Allowed)
+	assert.Equal(t, ExceededQuota, decision.Exceeded)
+	assert.Equal(t, time.Date(2022, 2, 3, 0, 0, 0, 0, time.UTC), decision.Reset)
+
+	assert.True(t, quotas.Take("producer-eu", Limits{}, now.Add(time.Minute)).Allowed, "the quota resets every day")
+
+	usage := quotas.Usage(now.Add(time.Minute))
+	require.Len(t, usage, 1)
+	assert.Equal(t, int64(4), usage[0].Total)
+	assert.Equal(t, int64(1), usage[0].Denied)
+	assert.Equal(t, 1, usage[0].Today)
+}
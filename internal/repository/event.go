@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=event.go -destination=mocks/mock_event_repository.go -package=mocks
+
+// EventRepository defines the interface for storing the event history of rockets
+type EventRepository interface {
+	Append(ctx context.Context, event *models.RocketEvent) error
+	FindByChannel(ctx context.Context, channel string) []*models.RocketEvent
+	// Contains tells whether the history of the channel of event already holds an event of the same type, message
+	// number and time
+	Contains(ctx context.Context, event *models.RocketEvent) bool
+	DeleteByChannel(ctx context.Context, channel string) int
+	DeleteAll(ctx context.Context) int
+	// Ping tells whether the storage is reachable and responsive
+	Ping(ctx context.Context) error
+}
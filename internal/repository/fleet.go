@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=fleet.go -destination=mocks/mock_fleet_repository.go -package=mocks
+
+// ErrFleetNotFound is returned when no fleet exists with the requested ID
+var ErrFleetNotFound = errors.New("fleet not found")
+
+// FleetRepository defines the interface for storing fleet definitions
+type FleetRepository interface {
+	Save(ctx context.Context, fleet *models.Fleet) error
+	FindByID(ctx context.Context, id string) (*models.Fleet, error)
+	FindAll(ctx context.Context) []*models.Fleet
+	Delete(ctx context.Context, id string) error
+}
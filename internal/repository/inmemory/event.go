@@ -0,0 +1,125 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// EventRepository implements EventRepository with in-memory storage.
+// Only the most recent events of each channel are kept to bound memory usage
+type EventRepository struct {
+	events        map[string][]*models.RocketEvent
+	index         map[string]map[eventKey]int // Events of each channel counted by key, for Contains
+	maxPerChannel int
+	mu            sync.RWMutex
+}
+
+// eventKey identifies the occurrence an event describes
+type eventKey struct {
+	eventType     string
+	messageNumber int64
+	time          int64 // Unix nanoseconds, as time.Time values of the same instant may differ
+}
+
+func keyOf(event *models.RocketEvent) eventKey {
+	return eventKey{eventType: event.Type, messageNumber: event.MessageNumber, time: event.Time.UnixNano()}
+}
+
+// NewInMemoryEventRepository creates a new in-memory event repository keeping up to maxPerChannel events per rocket
+func NewInMemoryEventRepository(maxPerChannel int) *EventRepository {
+	return &EventRepository{
+		events:        make(map[string][]*models.RocketEvent),
+		index:         make(map[string]map[eventKey]int),
+		maxPerChannel: maxPerChannel,
+	}
+}
+
+// Ping checks that the store isn't stuck behind a held lock
+func (r *EventRepository) Ping(ctx context.Context) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return ctx.Err()
+}
+
+// Append records an event, evicting the oldest one of the channel when the limit is reached
+func (r *EventRepository) Append(ctx context.Context, event *models.RocketEvent) error {
+	if event == nil {
+		return fmt.Errorf("cannot append nil event")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	index, ok := r.index[event.Channel]
+	if !ok {
+		index = make(map[eventKey]int)
+		r.index[event.Channel] = index
+	}
+	index[keyOf(event)]++
+
+	events := append(r.events[event.Channel], event)
+	if len(events) > r.maxPerChannel {
+		for _, evicted := range events[:len(events)-r.maxPerChannel] {
+			key := keyOf(evicted)
+			if index[key]--; index[key] == 0 {
+				delete(index, key)
+			}
+		}
+		events = events[len(events)-r.maxPerChannel:]
+	}
+	r.events[event.Channel] = events
+
+	return nil
+}
+
+// FindByChannel retrieves the events of a channel, oldest first
+func (r *EventRepository) FindByChannel(ctx context.Context, channel string) []*models.RocketEvent {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := make([]*models.RocketEvent, 0, len(r.events[channel]))
+	for _, event := range r.events[channel] {
+		eventCopy := *event
+		events = append(events, &eventCopy)
+	}
+
+	return events
+}
+
+// Contains tells whether the channel of event already holds an event of the same type, message number and time
+func (r *EventRepository) Contains(ctx context.Context, event *models.RocketEvent) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.index[event.Channel][keyOf(event)] > 0
+}
+
+// DeleteByChannel removes every event of a channel and returns how many were removed
+func (r *EventRepository) DeleteByChannel(ctx context.Context, channel string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := len(r.events[channel])
+	delete(r.events, channel)
+	delete(r.index, channel)
+
+	return count
+}
+
+// DeleteAll removes every event and returns how many were removed
+func (r *EventRepository) DeleteAll(ctx context.Context) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, events := range r.events {
+		count += len(events)
+	}
+	r.events = make(map[string][]*models.RocketEvent)
+	r.index = make(map[string]map[eventKey]int)
+
+	return count
+}
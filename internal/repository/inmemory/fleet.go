@@ -0,0 +1,92 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+)
+
+// FleetRepository implements FleetRepository with in-memory storage
+type FleetRepository struct {
+	fleets map[string]*models.Fleet
+	mu     sync.RWMutex
+}
+
+// NewInMemoryFleetRepository creates a new in-memory fleet repository
+func NewInMemoryFleetRepository() *FleetRepository {
+	return &FleetRepository{
+		fleets: make(map[string]*models.Fleet),
+	}
+}
+
+// Save stores or replaces a fleet definition
+func (r *FleetRepository) Save(ctx context.Context, fleet *models.Fleet) error {
+	if fleet == nil {
+		return fmt.Errorf("cannot save nil fleet")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fleets[fleet.ID] = cloneFleet(fleet)
+	return nil
+}
+
+// FindByID retrieves a fleet definition by ID
+func (r *FleetRepository) FindByID(ctx context.Context, id string) (*models.Fleet, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fleet, exists := r.fleets[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", repository.ErrFleetNotFound, id)
+	}
+
+	return cloneFleet(fleet), nil
+}
+
+// FindAll retrieves every fleet definition, sorted by name
+func (r *FleetRepository) FindAll(ctx context.Context) []*models.Fleet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	fleets := make([]*models.Fleet, 0, len(r.fleets))
+	for _, fleet := range r.fleets {
+		fleets = append(fleets, cloneFleet(fleet))
+	}
+	sort.Slice(fleets, func(i, j int) bool {
+		if fleets[i].Name != fleets[j].Name {
+			return fleets[i].Name < fleets[j].Name
+		}
+		return fleets[i].ID < fleets[j].ID
+	})
+
+	return fleets
+}
+
+// Delete removes a fleet definition
+func (r *FleetRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.fleets[id]; !exists {
+		return fmt.Errorf("%w: %s", repository.ErrFleetNotFound, id)
+	}
+
+	delete(r.fleets, id)
+	return nil
+}
+
+// cloneFleet returns a copy of the fleet sharing no state with the original
+func cloneFleet(fleet *models.Fleet) *models.Fleet {
+	clone := *fleet
+	clone.Members = slices.Clone(fleet.Members)
+	clone.Selector = maps.Clone(fleet.Selector)
+	return &clone
+}
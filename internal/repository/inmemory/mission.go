@@ -0,0 +1,193 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+)
+
+// MissionProjection implements MissionRepository by projecting the rocket changes made through
+// the rocket repository it wraps. Archived rockets are not taken into account
+type MissionProjection struct {
+	missions      map[string]*missionState
+	rocketMission map[string]string // rocket ID -> mission the rocket is counted in
+	mu            sync.RWMutex
+}
+
+// missionState is the projected state of a mission
+type missionState struct {
+	firstSeen   time.Time
+	lastUpdated time.Time
+	rockets     map[string]missionRocket
+}
+
+// missionRocket is the part of a rocket's state the mission aggregates depend on
+type missionRocket struct {
+	status models.RocketStatus
+	speed  int
+}
+
+// NewMissionProjection creates an empty mission projection
+func NewMissionProjection() *MissionProjection {
+	return &MissionProjection{
+		missions:      make(map[string]*missionState),
+		rocketMission: make(map[string]string),
+	}
+}
+
+// Wrap returns a rocket repository keeping the projection up to date with every change made through it
+func (p *MissionProjection) Wrap(repo repository.RocketRepository) repository.RocketRepository {
+	return &projectedRepository{RocketRepository: repo, projection: p}
+}
+
+// FindByName retrieves a mission by its exact name
+func (p *MissionProjection) FindByName(ctx context.Context, name string) (*models.Mission, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	state, exists := p.missions[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", repository.ErrMissionNotFound, name)
+	}
+
+	return state.mission(name), nil
+}
+
+// FindAll retrieves every mission, sorted by name
+func (p *MissionProjection) FindAll(ctx context.Context) []*models.Mission {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	missions := make([]*models.Mission, 0, len(p.missions))
+	for name, state := range p.missions {
+		missions = append(missions, state.mission(name))
+	}
+	sort.Slice(missions, func(i, j int) bool {
+		return missions[i].Name < missions[j].Name
+	})
+
+	return missions
+}
+
+// apply accounts for the current state of a rocket, moving it between missions when its mission changed
+func (p *MissionProjection) apply(rocket *models.Rocket) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if previous, counted := p.rocketMission[rocket.ID]; counted && (previous != rocket.Mission || rocket.ArchivedAt != nil) {
+		p.removeLocked(rocket.ID)
+	}
+	if rocket.ArchivedAt != nil {
+		return
+	}
+
+	state, exists := p.missions[rocket.Mission]
+	if !exists {
+		state = &missionState{firstSeen: rocket.LastUpdated, rockets: make(map[string]missionRocket)}
+		p.missions[rocket.Mission] = state
+	}
+	if rocket.LastUpdated.After(state.lastUpdated) {
+		state.lastUpdated = rocket.LastUpdated
+	}
+	state.rockets[rocket.ID] = missionRocket{status: rocket.Status, speed: rocket.Speed}
+	p.rocketMission[rocket.ID] = rocket.Mission
+}
+
+// remove stops accounting for a rocket
+func (p *MissionProjection) remove(id string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.removeLocked(id)
+}
+
+// removeLocked stops accounting for a rocket, dropping its mission once empty. Callers must hold the write lock
+func (p *MissionProjection) removeLocked(id string) {
+	name, counted := p.rocketMission[id]
+	if !counted {
+		return
+	}
+	delete(p.rocketMission, id)
+
+	state := p.missions[name]
+	delete(state.rockets, id)
+	if len(state.rockets) == 0 {
+		delete(p.missions, name)
+	}
+}
+
+// mission aggregates the projected state into a mission
+func (s *missionState) mission(name string) *models.Mission {
+	mission := &models.Mission{
+		Name:        name,
+		RocketCount: len(s.rockets),
+		ByStatus:    make(models.StatusCounts),
+		FirstSeen:   s.firstSeen,
+		LastUpdated: s.lastUpdated,
+	}
+
+	first := true
+	for _, rocket := range s.rockets {
+		if first || rocket.speed < mission.Speed.Min {
+			mission.Speed.Min = rocket.speed
+		}
+		if first || rocket.speed > mission.Speed.Max {
+			mission.Speed.Max = rocket.speed
+		}
+		first = false
+
+		mission.ByStatus[rocket.status]++
+		mission.Speed.Total += rocket.speed
+	}
+	if mission.RocketCount > 0 {
+		mission.Speed.Average = float64(mission.Speed.Total) / float64(mission.RocketCount)
+	}
+
+	return mission
+}
+
+// projectedRepository decorates a rocket repository to feed the mission projection
+type projectedRepository struct {
+	repository.RocketRepository
+	projection *MissionProjection
+}
+
+// Save stores the rocket and projects it
+func (r *projectedRepository) Save(ctx context.Context, rocket *models.Rocket) error {
+	if err := r.RocketRepository.Save(ctx, rocket); err != nil {
+		return err
+	}
+
+	r.projection.apply(rocket)
+	return nil
+}
+
+// Update modifies the rocket and projects the result
+func (r *projectedRepository) Update(
+	ctx context.Context,
+	id string,
+	fn func(rocket *models.Rocket) error,
+) (*models.Rocket, error) {
+	rocket, err := r.RocketRepository.Update(ctx, id, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	r.projection.apply(rocket)
+	return rocket, nil
+}
+
+// Delete removes the rocket from the repository and the projection
+func (r *projectedRepository) Delete(ctx context.Context, id string) error {
+	if err := r.RocketRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.projection.remove(id)
+	return nil
+}
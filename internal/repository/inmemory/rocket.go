@@ -4,21 +4,30 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
 )
 
 // RocketRepository implements Repository with in-memory storage
 type RocketRepository struct {
-	rockets map[string]*models.Rocket
-	mu      sync.RWMutex
+	rockets   map[string]*models.Rocket
+	byMission map[string]map[string]struct{} // mission -> rocket IDs
+	byName    map[string]string              // lower-cased name -> rocket ID
+	revision  int64                          // Version of the latest stored rocket
+	modified  time.Time                      // Time of the latest change, deletions included
+	mu        sync.RWMutex
 }
 
 // NewInMemoryRepository creates a new in-memory repository
 func NewInMemoryRepository() *RocketRepository {
 	return &RocketRepository{
-		rockets: make(map[string]*models.Rocket),
+		rockets:   make(map[string]*models.Rocket),
+		byMission: make(map[string]map[string]struct{}),
+		byName:    make(map[string]string),
 	}
 }
 
@@ -31,8 +40,14 @@ func (r *RocketRepository) Save(ctx context.Context, rocket *models.Rocket) erro
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	r.rockets[rocket.ID] = rocket
-	return nil
+	return r.store(rocket.Clone())
+}
+
+// Ping checks that the store isn't stuck behind a held lock
+func (r *RocketRepository) Ping(ctx context.Context) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return ctx.Err()
 }
 
 // FindByID retrieves a rocket by ID
@@ -42,23 +57,74 @@ func (r *RocketRepository) FindByID(ctx context.Context, id string) (*models.Roc
 
 	rocket, exists := r.rockets[id]
 	if !exists {
-		return nil, fmt.Errorf("rocket not found: %s", id)
+		return nil, fmt.Errorf("%w: %s", repository.ErrNotFound, id)
 	}
 
 	// Return a copy to prevent external modifications
-	rocketCopy := *rocket
-	return &rocketCopy, nil
+	return rocket.Clone(), nil
 }
 
-// FindAll retrieves all rockets
-func (r *RocketRepository) FindAll(ctx context.Context) []*models.Rocket {
+// FindByName retrieves a rocket by display name, case-insensitively
+func (r *RocketRepository) FindByName(ctx context.Context, name string) (*models.Rocket, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, exists := r.byName[strings.ToLower(name)]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", repository.ErrNotFound, name)
+	}
+
+	return r.rockets[id].Clone(), nil
+}
+
+// Update atomically modifies a rocket through fn
+func (r *RocketRepository) Update(ctx context.Context, id string, fn func(rocket *models.Rocket) error) (*models.Rocket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rocket, exists := r.rockets[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", repository.ErrNotFound, id)
+	}
+
+	// Work on a copy so a failing fn leaves the stored rocket untouched
+	rocketCopy := rocket.Clone()
+	if err := fn(rocketCopy); err != nil {
+		return nil, err
+	}
+	if err := r.store(rocketCopy); err != nil {
+		return nil, err
+	}
+
+	return rocketCopy.Clone(), nil
+}
+
+// FindAll retrieves all rockets matching the filter. Mission filters are served from the mission index
+func (r *RocketRepository) FindAll(ctx context.Context, filter models.RocketFilter) []*models.Rocket {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
 	rockets := make([]*models.Rocket, 0, len(r.rockets))
-	for _, rocket := range r.rockets {
-		rocketCopy := *rocket
-		rockets = append(rockets, &rocketCopy)
+	collect := func(rocket *models.Rocket) {
+		if !filter.Matches(rocket) {
+			return
+		}
+		rockets = append(rockets, rocket.Clone())
+	}
+
+	if filter.Mission != "" {
+		for mission, ids := range r.byMission {
+			if !strings.EqualFold(mission, filter.Mission) {
+				continue
+			}
+			for id := range ids {
+				collect(r.rockets[id])
+			}
+		}
+	} else {
+		for _, rocket := range r.rockets {
+			collect(rocket)
+		}
 	}
 
 	// Default sort by ID
@@ -75,3 +141,75 @@ func (r *RocketRepository) GetCount(ctx context.Context) int {
 	defer r.mu.RUnlock()
 	return len(r.rockets)
 }
+
+// LastModified returns the time of the latest change to any rocket, deletions included
+func (r *RocketRepository) LastModified(ctx context.Context) time.Time {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.modified
+}
+
+// Delete removes a rocket
+func (r *RocketRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.rockets[id]; !exists {
+		return fmt.Errorf("%w: %s", repository.ErrNotFound, id)
+	}
+
+	r.remove(id)
+	return nil
+}
+
+// store saves a rocket under a new version and keeps the indexes in sync, refusing names taken by another rocket.
+// Callers must hold the write lock
+func (r *RocketRepository) store(rocket *models.Rocket) error {
+	name := strings.ToLower(rocket.Name)
+	if owner, taken := r.byName[name]; rocket.Name != "" && taken && owner != rocket.ID {
+		return fmt.Errorf("%w: %s", repository.ErrNameTaken, rocket.Name)
+	}
+
+	if previous, exists := r.rockets[rocket.ID]; exists {
+		if previous.Mission != rocket.Mission {
+			r.unindexMission(previous.Mission, previous.ID)
+		}
+		if previous.Name != "" && !strings.EqualFold(previous.Name, rocket.Name) {
+			delete(r.byName, strings.ToLower(previous.Name))
+		}
+	}
+
+	r.revision++
+	r.modified = time.Now().UTC()
+	rocket.Version, rocket.ModifiedAt = r.revision, r.modified
+	r.rockets[rocket.ID] = rocket
+
+	if r.byMission[rocket.Mission] == nil {
+		r.byMission[rocket.Mission] = make(map[string]struct{})
+	}
+	r.byMission[rocket.Mission][rocket.ID] = struct{}{}
+
+	if rocket.Name != "" {
+		r.byName[name] = rocket.ID
+	}
+	return nil
+}
+
+// remove deletes a rocket and its index entries. Callers must hold the write lock
+func (r *RocketRepository) remove(id string) {
+	if rocket, exists := r.rockets[id]; exists {
+		r.unindexMission(rocket.Mission, id)
+		if rocket.Name != "" {
+			delete(r.byName, strings.ToLower(rocket.Name))
+		}
+		delete(r.rockets, id)
+		r.modified = time.Now().UTC()
+	}
+}
+
+func (r *RocketRepository) unindexMission(mission, id string) {
+	delete(r.byMission[mission], id)
+	if len(r.byMission[mission]) == 0 {
+		delete(r.byMission, mission)
+	}
+}
@@ -0,0 +1,157 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+)
+
+// TypeCatalog implements RocketTypeRepository by projecting the rocket changes made through
+// the rocket repository it wraps. Types are kept once observed, with the counts of their current rockets
+type TypeCatalog struct {
+	types      map[string]*typeState
+	rocketType map[string]string // rocket ID -> type the rocket is counted in
+	mu         sync.RWMutex
+}
+
+// typeState is the projected state of a rocket type
+type typeState struct {
+	firstSeen time.Time
+	lastSeen  time.Time
+	rockets   map[string]typeRocket
+}
+
+// typeRocket is the part of a rocket's state the type catalog depends on
+type typeRocket struct {
+	status  models.RocketStatus
+	mission string
+}
+
+// NewTypeCatalog creates an empty rocket type catalog
+func NewTypeCatalog() *TypeCatalog {
+	return &TypeCatalog{
+		types:      make(map[string]*typeState),
+		rocketType: make(map[string]string),
+	}
+}
+
+// Wrap returns a rocket repository keeping the catalog up to date with every change made through it
+func (t *TypeCatalog) Wrap(repo repository.RocketRepository) repository.RocketRepository {
+	return &catalogedRepository{RocketRepository: repo, catalog: t}
+}
+
+// FindAll retrieves every observed rocket type, sorted by name
+func (t *TypeCatalog) FindAll(ctx context.Context) []*models.RocketType {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	types := make([]*models.RocketType, 0, len(t.types))
+	for name, state := range t.types {
+		types = append(types, state.rocketType(name))
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].Name < types[j].Name
+	})
+
+	return types
+}
+
+// apply accounts for the current state of a rocket
+func (t *TypeCatalog) apply(rocket *models.Rocket) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if previous, counted := t.rocketType[rocket.ID]; counted && previous != rocket.Type {
+		delete(t.types[previous].rockets, rocket.ID)
+	}
+
+	state, exists := t.types[rocket.Type]
+	if !exists {
+		state = &typeState{firstSeen: rocket.LastUpdated, rockets: make(map[string]typeRocket)}
+		t.types[rocket.Type] = state
+	}
+	if rocket.LastUpdated.After(state.lastSeen) {
+		state.lastSeen = rocket.LastUpdated
+	}
+	state.rockets[rocket.ID] = typeRocket{status: rocket.Status, mission: rocket.Mission}
+	t.rocketType[rocket.ID] = rocket.Type
+}
+
+// remove stops counting a rocket, keeping its type in the catalog
+func (t *TypeCatalog) remove(id string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if name, counted := t.rocketType[id]; counted {
+		delete(t.types[name].rockets, id)
+		delete(t.rocketType, id)
+	}
+}
+
+// rocketType builds the catalog entry from the projected state
+func (s *typeState) rocketType(name string) *models.RocketType {
+	rocketType := &models.RocketType{
+		Name:        name,
+		RocketCount: len(s.rockets),
+		ByStatus:    make(models.StatusCounts),
+		Missions:    []string{},
+		FirstSeen:   s.firstSeen,
+		LastSeen:    s.lastSeen,
+	}
+
+	for _, rocket := range s.rockets {
+		rocketType.ByStatus[rocket.status]++
+		if !slices.Contains(rocketType.Missions, rocket.mission) {
+			rocketType.Missions = append(rocketType.Missions, rocket.mission)
+		}
+	}
+	slices.Sort(rocketType.Missions)
+
+	return rocketType
+}
+
+// catalogedRepository decorates a rocket repository to feed the type catalog
+type catalogedRepository struct {
+	repository.RocketRepository
+	catalog *TypeCatalog
+}
+
+// Save stores the rocket and catalogs its type
+func (r *catalogedRepository) Save(ctx context.Context, rocket *models.Rocket) error {
+	if err := r.RocketRepository.Save(ctx, rocket); err != nil {
+		return err
+	}
+
+	r.catalog.apply(rocket)
+	return nil
+}
+
+// Update modifies the rocket and catalogs the result
+func (r *catalogedRepository) Update(
+	ctx context.Context,
+	id string,
+	fn func(rocket *models.Rocket) error,
+) (*models.Rocket, error) {
+	rocket, err := r.RocketRepository.Update(ctx, id, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	r.catalog.apply(rocket)
+	return rocket, nil
+}
+
+// Delete removes the rocket and stops counting it
+func (r *catalogedRepository) Delete(ctx context.Context, id string) error {
+	if err := r.RocketRepository.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	r.catalog.remove(id)
+	return nil
+}
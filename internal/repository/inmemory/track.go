@@ -0,0 +1,77 @@
+package inmemory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// TrackRepository implements TrackRepository with in-memory storage.
+// Only the most recent positions of each channel are kept to bound memory usage
+type TrackRepository struct {
+	points        map[string][]models.TrackPoint
+	maxPerChannel int
+	mu            sync.RWMutex
+}
+
+// NewInMemoryTrackRepository creates a new in-memory track repository keeping up to maxPerChannel positions per rocket
+func NewInMemoryTrackRepository(maxPerChannel int) *TrackRepository {
+	return &TrackRepository{
+		points:        make(map[string][]models.TrackPoint),
+		maxPerChannel: maxPerChannel,
+	}
+}
+
+// Append records a position, evicting the oldest one of the channel when the limit is reached
+func (r *TrackRepository) Append(ctx context.Context, channel string, point models.TrackPoint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	points := append(r.points[channel], point)
+	if len(points) > r.maxPerChannel {
+		points = points[len(points)-r.maxPerChannel:]
+	}
+	r.points[channel] = points
+
+	return nil
+}
+
+// FindByChannel retrieves the positions of a channel, oldest first
+func (r *TrackRepository) FindByChannel(ctx context.Context, channel string) []models.TrackPoint {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	points := slices.Clone(r.points[channel])
+	if points == nil {
+		points = []models.TrackPoint{}
+	}
+
+	return points
+}
+
+// DeleteByChannel removes every position of a channel and returns how many were removed
+func (r *TrackRepository) DeleteByChannel(ctx context.Context, channel string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := len(r.points[channel])
+	delete(r.points, channel)
+
+	return count
+}
+
+// DeleteAll removes every position and returns how many were removed
+func (r *TrackRepository) DeleteAll(ctx context.Context) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := 0
+	for _, points := range r.points {
+		count += len(points)
+	}
+	r.points = make(map[string][]models.TrackPoint)
+
+	return count
+}
@@ -0,0 +1,90 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+)
+
+// WebhookRepository implements WebhookRepository with in-memory storage
+type WebhookRepository struct {
+	webhooks map[string]*models.Webhook
+	mu       sync.RWMutex
+}
+
+// NewInMemoryWebhookRepository creates a new in-memory webhook repository
+func NewInMemoryWebhookRepository() *WebhookRepository {
+	return &WebhookRepository{
+		webhooks: make(map[string]*models.Webhook),
+	}
+}
+
+// Save stores or replaces a webhook
+func (r *WebhookRepository) Save(ctx context.Context, webhook *models.Webhook) error {
+	if webhook == nil {
+		return fmt.Errorf("cannot save nil webhook")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.webhooks[webhook.ID] = cloneWebhook(webhook)
+	return nil
+}
+
+// FindByID retrieves a webhook by ID
+func (r *WebhookRepository) FindByID(ctx context.Context, id string) (*models.Webhook, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhook, exists := r.webhooks[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", repository.ErrWebhookNotFound, id)
+	}
+
+	return cloneWebhook(webhook), nil
+}
+
+// FindAll retrieves every webhook, oldest first
+func (r *WebhookRepository) FindAll(ctx context.Context) []*models.Webhook {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	webhooks := make([]*models.Webhook, 0, len(r.webhooks))
+	for _, webhook := range r.webhooks {
+		webhooks = append(webhooks, cloneWebhook(webhook))
+	}
+	sort.Slice(webhooks, func(i, j int) bool {
+		if !webhooks[i].CreatedAt.Equal(webhooks[j].CreatedAt) {
+			return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+		}
+		return webhooks[i].ID < webhooks[j].ID
+	})
+
+	return webhooks
+}
+
+// Delete removes a webhook
+func (r *WebhookRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.webhooks[id]; !exists {
+		return fmt.Errorf("%w: %s", repository.ErrWebhookNotFound, id)
+	}
+
+	delete(r.webhooks, id)
+	return nil
+}
+
+// cloneWebhook returns a copy of the webhook sharing no state with the original
+func cloneWebhook(webhook *models.Webhook) *models.Webhook {
+	clone := *webhook
+	clone.Events = slices.Clone(webhook.Events)
+	return &clone
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=mission.go -destination=mocks/mock_mission_repository.go -package=mocks
+
+// ErrMissionNotFound is returned when no rocket is assigned to the requested mission
+var ErrMissionNotFound = errors.New("mission not found")
+
+// MissionRepository gives read access to the missions, which are maintained as a projection of the rocket state
+type MissionRepository interface {
+	FindByName(ctx context.Context, name string) (*models.Mission, error)
+	FindAll(ctx context.Context) []*models.Mission
+}
@@ -0,0 +1,126 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: event.go
+//
+// Generated by this command:
+//
+//	mockgen -source=event.go -destination=mocks/mock_event_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ahernandez9/rockets/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventRepository is a mock of EventRepository interface.
+type MockEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockEventRepositoryMockRecorder is the mock recorder for MockEventRepository.
+type MockEventRepositoryMockRecorder struct {
+	mock *MockEventRepository
+}
+
+// NewMockEventRepository creates a new mock instance.
+func NewMockEventRepository(ctrl *gomock.Controller) *MockEventRepository {
+	mock := &MockEventRepository{ctrl: ctrl}
+	mock.recorder = &MockEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventRepository) EXPECT() *MockEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockEventRepository) Append(ctx context.Context, event *models.RocketEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockEventRepositoryMockRecorder) Append(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockEventRepository)(nil).Append), ctx, event)
+}
+
+// Contains mocks base method.
+func (m *MockEventRepository) Contains(ctx context.Context, event *models.RocketEvent) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Contains", ctx, event)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Contains indicates an expected call of Contains.
+func (mr *MockEventRepositoryMockRecorder) Contains(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Contains", reflect.TypeOf((*MockEventRepository)(nil).Contains), ctx, event)
+}
+
+// DeleteAll mocks base method.
+func (m *MockEventRepository) DeleteAll(ctx context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", ctx)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockEventRepositoryMockRecorder) DeleteAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockEventRepository)(nil).DeleteAll), ctx)
+}
+
+// DeleteByChannel mocks base method.
+func (m *MockEventRepository) DeleteByChannel(ctx context.Context, channel string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByChannel", ctx, channel)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// DeleteByChannel indicates an expected call of DeleteByChannel.
+func (mr *MockEventRepositoryMockRecorder) DeleteByChannel(ctx, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByChannel", reflect.TypeOf((*MockEventRepository)(nil).DeleteByChannel), ctx, channel)
+}
+
+// FindByChannel mocks base method.
+func (m *MockEventRepository) FindByChannel(ctx context.Context, channel string) []*models.RocketEvent {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByChannel", ctx, channel)
+	ret0, _ := ret[0].([]*models.RocketEvent)
+	return ret0
+}
+
+// FindByChannel indicates an expected call of FindByChannel.
+func (mr *MockEventRepositoryMockRecorder) FindByChannel(ctx, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByChannel", reflect.TypeOf((*MockEventRepository)(nil).FindByChannel), ctx, channel)
+}
+
+// Ping mocks base method.
+func (m *MockEventRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockEventRepositoryMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockEventRepository)(nil).Ping), ctx)
+}
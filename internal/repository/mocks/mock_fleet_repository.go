@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fleet.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fleet.go -destination=mocks/mock_fleet_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ahernandez9/rockets/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFleetRepository is a mock of FleetRepository interface.
+type MockFleetRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFleetRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockFleetRepositoryMockRecorder is the mock recorder for MockFleetRepository.
+type MockFleetRepositoryMockRecorder struct {
+	mock *MockFleetRepository
+}
+
+// NewMockFleetRepository creates a new mock instance.
+func NewMockFleetRepository(ctrl *gomock.Controller) *MockFleetRepository {
+	mock := &MockFleetRepository{ctrl: ctrl}
+	mock.recorder = &MockFleetRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFleetRepository) EXPECT() *MockFleetRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFleetRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFleetRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFleetRepository)(nil).Delete), ctx, id)
+}
+
+// FindAll mocks base method.
+func (m *MockFleetRepository) FindAll(ctx context.Context) []*models.Fleet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*models.Fleet)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockFleetRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockFleetRepository)(nil).FindAll), ctx)
+}
+
+// FindByID mocks base method.
+func (m *MockFleetRepository) FindByID(ctx context.Context, id string) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, id)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockFleetRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockFleetRepository)(nil).FindByID), ctx, id)
+}
+
+// Save mocks base method.
+func (m *MockFleetRepository) Save(ctx context.Context, fleet *models.Fleet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, fleet)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockFleetRepositoryMockRecorder) Save(ctx, fleet any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockFleetRepository)(nil).Save), ctx, fleet)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mission.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mission.go -destination=mocks/mock_mission_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ahernandez9/rockets/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMissionRepository is a mock of MissionRepository interface.
+type MockMissionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMissionRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockMissionRepositoryMockRecorder is the mock recorder for MockMissionRepository.
+type MockMissionRepositoryMockRecorder struct {
+	mock *MockMissionRepository
+}
+
+// NewMockMissionRepository creates a new mock instance.
+func NewMockMissionRepository(ctrl *gomock.Controller) *MockMissionRepository {
+	mock := &MockMissionRepository{ctrl: ctrl}
+	mock.recorder = &MockMissionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMissionRepository) EXPECT() *MockMissionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockMissionRepository) FindAll(ctx context.Context) []*models.Mission {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*models.Mission)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockMissionRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockMissionRepository)(nil).FindAll), ctx)
+}
+
+// FindByName mocks base method.
+func (m *MockMissionRepository) FindByName(ctx context.Context, name string) (*models.Mission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*models.Mission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockMissionRepositoryMockRecorder) FindByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockMissionRepository)(nil).FindByName), ctx, name)
+}
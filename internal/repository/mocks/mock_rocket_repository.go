@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/ahernandez9/rockets/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,18 +42,32 @@ func (m *MockRocketRepository) EXPECT() *MockRocketRepositoryMockRecorder {
 	return m.recorder
 }
 
+// Delete mocks base method.
+func (m *MockRocketRepository) Delete(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockRocketRepositoryMockRecorder) Delete(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRocketRepository)(nil).Delete), ctx, id)
+}
+
 // FindAll mocks base method.
-func (m *MockRocketRepository) FindAll(ctx context.Context) []*models.Rocket {
+func (m *MockRocketRepository) FindAll(ctx context.Context, filter models.RocketFilter) []*models.Rocket {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret := m.ctrl.Call(m, "FindAll", ctx, filter)
 	ret0, _ := ret[0].([]*models.Rocket)
 	return ret0
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockRocketRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+func (mr *MockRocketRepositoryMockRecorder) FindAll(ctx, filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRocketRepository)(nil).FindAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRocketRepository)(nil).FindAll), ctx, filter)
 }
 
 // FindByID mocks base method.
@@ -70,6 +85,21 @@ func (mr *MockRocketRepositoryMockRecorder) FindByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRocketRepository)(nil).FindByID), ctx, id)
 }
 
+// FindByName mocks base method.
+func (m *MockRocketRepository) FindByName(ctx context.Context, name string) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByName", ctx, name)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByName indicates an expected call of FindByName.
+func (mr *MockRocketRepositoryMockRecorder) FindByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByName", reflect.TypeOf((*MockRocketRepository)(nil).FindByName), ctx, name)
+}
+
 // GetCount mocks base method.
 func (m *MockRocketRepository) GetCount(ctx context.Context) int {
 	m.ctrl.T.Helper()
@@ -84,6 +114,34 @@ func (mr *MockRocketRepositoryMockRecorder) GetCount(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRocketRepository)(nil).GetCount), ctx)
 }
 
+// LastModified mocks base method.
+func (m *MockRocketRepository) LastModified(ctx context.Context) time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastModified", ctx)
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastModified indicates an expected call of LastModified.
+func (mr *MockRocketRepositoryMockRecorder) LastModified(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastModified", reflect.TypeOf((*MockRocketRepository)(nil).LastModified), ctx)
+}
+
+// Ping mocks base method.
+func (m *MockRocketRepository) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockRocketRepositoryMockRecorder) Ping(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockRocketRepository)(nil).Ping), ctx)
+}
+
 // Save mocks base method.
 func (m *MockRocketRepository) Save(ctx context.Context, rocket *models.Rocket) error {
 	m.ctrl.T.Helper()
@@ -97,3 +155,18 @@ func (mr *MockRocketRepositoryMockRecorder) Save(ctx, rocket any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRocketRepository)(nil).Save), ctx, rocket)
 }
+
+// Update mocks base method.
+func (m *MockRocketRepository) Update(ctx context.Context, id string, fn func(*models.Rocket) error) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, id, fn)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockRocketRepositoryMockRecorder) Update(ctx, id, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRocketRepository)(nil).Update), ctx, id, fn)
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rocket_type.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rocket_type.go -destination=mocks/mock_rocket_type_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ahernandez9/rockets/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRocketTypeRepository is a mock of RocketTypeRepository interface.
+type MockRocketTypeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRocketTypeRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockRocketTypeRepositoryMockRecorder is the mock recorder for MockRocketTypeRepository.
+type MockRocketTypeRepositoryMockRecorder struct {
+	mock *MockRocketTypeRepository
+}
+
+// NewMockRocketTypeRepository creates a new mock instance.
+func NewMockRocketTypeRepository(ctrl *gomock.Controller) *MockRocketTypeRepository {
+	mock := &MockRocketTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockRocketTypeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRocketTypeRepository) EXPECT() *MockRocketTypeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockRocketTypeRepository) FindAll(ctx context.Context) []*models.RocketType {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", ctx)
+	ret0, _ := ret[0].([]*models.RocketType)
+	return ret0
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRocketTypeRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRocketTypeRepository)(nil).FindAll), ctx)
+}
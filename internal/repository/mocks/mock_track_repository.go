@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: track.go
+//
+// Generated by this command:
+//
+//	mockgen -source=track.go -destination=mocks/mock_track_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ahernandez9/rockets/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrackRepository is a mock of TrackRepository interface.
+type MockTrackRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrackRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTrackRepositoryMockRecorder is the mock recorder for MockTrackRepository.
+type MockTrackRepositoryMockRecorder struct {
+	mock *MockTrackRepository
+}
+
+// NewMockTrackRepository creates a new mock instance.
+func NewMockTrackRepository(ctrl *gomock.Controller) *MockTrackRepository {
+	mock := &MockTrackRepository{ctrl: ctrl}
+	mock.recorder = &MockTrackRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrackRepository) EXPECT() *MockTrackRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockTrackRepository) Append(ctx context.Context, channel string, point models.TrackPoint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", ctx, channel, point)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockTrackRepositoryMockRecorder) Append(ctx, channel, point any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockTrackRepository)(nil).Append), ctx, channel, point)
+}
+
+// DeleteAll mocks base method.
+func (m *MockTrackRepository) DeleteAll(ctx context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAll", ctx)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// DeleteAll indicates an expected call of DeleteAll.
+func (mr *MockTrackRepositoryMockRecorder) DeleteAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAll", reflect.TypeOf((*MockTrackRepository)(nil).DeleteAll), ctx)
+}
+
+// DeleteByChannel mocks base method.
+func (m *MockTrackRepository) DeleteByChannel(ctx context.Context, channel string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByChannel", ctx, channel)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// DeleteByChannel indicates an expected call of DeleteByChannel.
+func (mr *MockTrackRepositoryMockRecorder) DeleteByChannel(ctx, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByChannel", reflect.TypeOf((*MockTrackRepository)(nil).DeleteByChannel), ctx, channel)
+}
+
+// FindByChannel mocks base method.
+func (m *MockTrackRepository) FindByChannel(ctx context.Context, channel string) []models.TrackPoint {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByChannel", ctx, channel)
+	ret0, _ := ret[0].([]models.TrackPoint)
+	return ret0
+}
+
+// FindByChannel indicates an expected call of FindByChannel.
+func (mr *MockTrackRepositoryMockRecorder) FindByChannel(ctx, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByChannel", reflect.TypeOf((*MockTrackRepository)(nil).FindByChannel), ctx, channel)
+}
@@ -2,16 +2,38 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/ahernandez9/rockets/internal/models"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -source=rocket.go -destination=mocks/mock_rocket_repository.go -package=mocks
 
-// RocketRepository defines the interface for rocket storage
+var (
+	// ErrNotFound is returned when the requested rocket does not exist
+	ErrNotFound = errors.New("rocket not found")
+
+	// ErrNameTaken is returned when saving a rocket under a display name another rocket already has
+	ErrNameTaken = errors.New("rocket name already taken")
+)
+
+// RocketRepository defines the interface for rocket storage. Every Save and Update stores the rocket under a new
+// Version, never reused by the repository even after the rocket is deleted, and stamps its ModifiedAt
 type RocketRepository interface {
 	Save(ctx context.Context, rocket *models.Rocket) error
 	FindByID(ctx context.Context, id string) (*models.Rocket, error)
-	FindAll(ctx context.Context) []*models.Rocket
+	// FindByName retrieves a rocket by display name; names are unique and compared case-insensitively
+	FindByName(ctx context.Context, name string) (*models.Rocket, error)
+	// Update atomically applies fn to the stored rocket and saves the result, returning the updated rocket.
+	// Nothing is saved if fn returns an error
+	Update(ctx context.Context, id string, fn func(rocket *models.Rocket) error) (*models.Rocket, error)
+	FindAll(ctx context.Context, filter models.RocketFilter) []*models.Rocket
 	GetCount(ctx context.Context) int
+	Delete(ctx context.Context, id string) error
+	// LastModified returns the server time of the latest change to any rocket, deletions included.
+	// It is zero until a rocket is stored
+	LastModified(ctx context.Context) time.Time
+	// Ping tells whether the storage is reachable and responsive
+	Ping(ctx context.Context) error
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=rocket_type.go -destination=mocks/mock_rocket_type_repository.go -package=mocks
+
+// RocketTypeRepository gives read access to the catalog of rocket types, maintained as a projection of the rocket state
+type RocketTypeRepository interface {
+	FindAll(ctx context.Context) []*models.RocketType
+}
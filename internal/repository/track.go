@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=track.go -destination=mocks/mock_track_repository.go -package=mocks
+
+// TrackRepository defines the interface for storing the recent positions of rockets
+type TrackRepository interface {
+	Append(ctx context.Context, channel string, point models.TrackPoint) error
+	FindByChannel(ctx context.Context, channel string) []models.TrackPoint
+	DeleteByChannel(ctx context.Context, channel string) int
+	DeleteAll(ctx context.Context) int
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=webhook.go -destination=mocks/mock_webhook_repository.go -package=mocks
+
+// ErrWebhookNotFound is returned when no webhook exists with the requested ID
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// WebhookRepository defines the interface for storing webhook subscriptions
+type WebhookRepository interface {
+	Save(ctx context.Context, webhook *models.Webhook) error
+	FindByID(ctx context.Context, id string) (*models.Webhook, error)
+	FindAll(ctx context.Context) []*models.Webhook
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,158 @@
+// Package retention reaps rockets that stopped reporting, so that long-lived instances don't accumulate dead channels.
+//
+// A rocket is stale once its LastUpdated, the time of its latest applied message, is older than the configured age.
+// Exploded rockets have an age of their own, usually shorter since they will never report again.
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/service"
+)
+
+// Action is what happens to a stale rocket
+type Action string
+
+const (
+	// ActionArchive hides stale rockets from default listings, keeping them and their history
+	ActionArchive Action = "archive"
+	// ActionDelete purges stale rockets together with their history and position trail
+	ActionDelete Action = "delete"
+)
+
+// ParseAction resolves a retention action by name
+func ParseAction(value string) (Action, error) {
+	switch action := Action(value); action {
+	case ActionArchive, ActionDelete:
+		return action, nil
+	default:
+		return "", fmt.Errorf("unknown retention action %q, must be archive or delete", value)
+	}
+}
+
+// Policy configures which rockets are reaped and how
+type Policy struct {
+	MaxAge         time.Duration // Age after which rockets are reaped; 0 keeps them forever
+	ExplodedMaxAge time.Duration // Age after which exploded rockets are reaped; 0 applies MaxAge
+	Action         Action
+	Interval       time.Duration // Delay between two runs
+}
+
+// Enabled reports whether the policy reaps any rocket
+func (p Policy) Enabled() bool {
+	return p.MaxAge > 0 || p.ExplodedMaxAge > 0
+}
+
+// maxAge returns the age after which a rocket is stale, or 0 when it is kept forever
+func (p Policy) maxAge(rocket *models.Rocket) time.Duration {
+	if rocket.Status == models.StatusExploded && p.ExplodedMaxAge > 0 {
+		return p.ExplodedMaxAge
+	}
+	return p.MaxAge
+}
+
+// Reaper applies a retention policy to the rockets of a service
+type Reaper struct {
+	rockets service.RocketService
+	metrics metrics.Recorder
+
+	mu     sync.Mutex
+	policy Policy
+}
+
+// NewReaper creates a reaper for the rockets of the service. Call Run to reap periodically
+func NewReaper(rockets service.RocketService, policy Policy, m metrics.Recorder) *Reaper {
+	return &Reaper{
+		rockets: rockets,
+		policy:  policy,
+		metrics: m,
+	}
+}
+
+// SetMaxAges changes the ages after which rockets are reaped, from the next run on. Setting an age enables a disabled
+// policy
+func (r *Reaper) SetMaxAges(maxAge, explodedMaxAge time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.policy.MaxAge, r.policy.ExplodedMaxAge = maxAge, explodedMaxAge
+}
+
+func (r *Reaper) currentPolicy() Policy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.policy
+}
+
+// Run reaps stale rockets every policy interval until ctx is done. Runs are skipped while the policy is disabled
+func (r *Reaper) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.currentPolicy().Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if reaped := r.Reap(ctx, time.Now()); reaped > 0 {
+				slog.InfoContext(ctx, "Reaped stale rockets", "count", reaped, "action", r.currentPolicy().Action)
+			}
+		}
+	}
+}
+
+// Reap applies the policy once, as of now, and returns how many rockets were reaped
+func (r *Reaper) Reap(ctx context.Context, now time.Time) int {
+	policy := r.currentPolicy()
+	if !policy.Enabled() {
+		return 0
+	}
+
+	// Archived rockets are already out of the way unless they are to be deleted
+	filter := models.RocketFilter{IncludeArchived: policy.Action == ActionDelete}
+	rockets, err := r.rockets.ListRockets(ctx, filter, []models.SortField{{Field: "id"}})
+	if err != nil {
+		slog.ErrorContext(ctx, "Retention failed to list rockets", "error", err)
+		return 0
+	}
+
+	reaped := 0
+	for _, rocket := range rockets {
+		maxAge := policy.maxAge(rocket)
+		if maxAge == 0 || now.Sub(rocket.LastUpdated) <= maxAge {
+			continue
+		}
+
+		if err := r.reap(ctx, policy.Action, rocket.ID); err != nil {
+			// The rocket may have been removed meanwhile
+			if !errors.Is(err, repository.ErrNotFound) {
+				slog.ErrorContext(ctx, "Retention failed to reap rocket", "rocket", rocket.ID,
+					"action", policy.Action, "error", err)
+			}
+			continue
+		}
+
+		r.metrics.RocketReaped(string(policy.Action), string(rocket.Status))
+		reaped++
+	}
+
+	return reaped
+}
+
+// reap applies a policy action to a single rocket
+func (r *Reaper) reap(ctx context.Context, action Action, id string) error {
+	if action == ActionDelete {
+		_, err := r.rockets.PurgeRocket(ctx, id)
+		return err
+	}
+
+	_, err := r.rockets.ArchiveRocket(ctx, id)
+	return err
+}
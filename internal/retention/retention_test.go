@@ -0,0 +1,64 @@
+package retention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+	"github.com/ahernandez9/rockets/internal/service"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReap(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	policy := Policy{MaxAge: 24 * time.Hour, ExplodedMaxAge: time.Hour, Interval: time.Hour}
+
+	seed := func(t *testing.T) service.RocketService {
+		rockets := service.NewRocketService(inmemory.NewInMemoryRepository(), inmemory.NewInMemoryEventRepository(10),
+			inmemory.NewInMemoryTrackRepository(10), inmemory.NewMissionProjection(), inmemory.NewTypeCatalog())
+
+		for _, rocket := range []*models.Rocket{
+			{ID: "fresh", Status: models.StatusActive, LastUpdated: now.Add(-2 * time.Hour)},
+			{ID: "stale", Status: models.StatusActive, LastUpdated: now.Add(-48 * time.Hour)},
+			{ID: "exploded", Status: models.StatusExploded, LastUpdated: now.Add(-2 * time.Hour)},
+		} {
+			require.NoError(t, rockets.UpdateRocket(context.Background(), rocket))
+		}
+		return rockets
+	}
+
+	t.Run("archive", func(t *testing.T) {
+		rockets := seed(t)
+		policy := policy
+		policy.Action = ActionArchive
+
+		assert.Equal(t, 2, NewReaper(rockets, policy, metrics.Nop{}).Reap(context.Background(), now))
+
+		listed, err := rockets.ListRockets(context.Background(), models.RocketFilter{}, nil)
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "fresh", listed[0].ID)
+		assert.Equal(t, 3, rockets.GetCount(context.Background()))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		rockets := seed(t)
+		policy := policy
+		policy.Action = ActionDelete
+
+		assert.Equal(t, 2, NewReaper(rockets, policy, metrics.Nop{}).Reap(context.Background(), now))
+		assert.Equal(t, 1, rockets.GetCount(context.Background()))
+	})
+
+	t.Run("exploded rockets fall back to the general age", func(t *testing.T) {
+		rockets := seed(t)
+		policy := Policy{MaxAge: 24 * time.Hour, Action: ActionDelete}
+
+		assert.Equal(t, 1, NewReaper(rockets, policy, metrics.Nop{}).Reap(context.Background(), now))
+	})
+}
@@ -0,0 +1,40 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+// NewAWSSecretsManager reads once the secret secretID of AWS Secrets Manager, a JSON object whose fields are the
+// secrets of the same name. Credentials and region come from the default AWS configuration chain
+func NewAWSSecretsManager(ctx context.Context, secretID string) (Static, error) {
+	if secretID == "" {
+		return nil, fmt.Errorf("the secret ID is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading AWS configuration: %w", err)
+	}
+	out, err := secretsmanager.NewFromConfig(cfg).GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading AWS secret %s: %w", secretID, err)
+	}
+
+	var values map[string]string
+	if err := json.Unmarshal([]byte(aws.ToString(out.SecretString)), &values); err != nil {
+		return nil, fmt.Errorf("AWS secret %s must be a JSON object of strings: %w", secretID, err)
+	}
+	return Static(values), nil
+}
@@ -0,0 +1,56 @@
+// Package secrets loads credentials such as API keys and signing secrets from the environment, files mounted by the
+// orchestrator, HashiCorp Vault or AWS Secrets Manager, so that components don't each read their own.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Provider looks up secrets by name, e.g. ADMIN_TOKEN
+type Provider interface {
+	// Lookup returns the value of a secret, and false when it is not set
+	Lookup(ctx context.Context, name string) (string, bool, error)
+}
+
+// Env reads secrets from environment variables of the same name
+type Env struct{}
+
+func (Env) Lookup(_ context.Context, name string) (string, bool, error) {
+	value := os.Getenv(name)
+	return value, value != "", nil
+}
+
+// File reads secrets from files named after them in a directory, as Docker and Kubernetes mount them
+type File struct {
+	Dir string
+}
+
+func (f File) Lookup(_ context.Context, name string) (string, bool, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return "", false, fmt.Errorf("invalid secret name %q", name)
+	}
+
+	data, err := os.ReadFile(filepath.Join(f.Dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("reading secret %s: %w", name, err)
+	}
+	// Editors and kubectl leave a trailing newline in secret files
+	value := strings.TrimRight(string(data), "\r\n")
+	return value, value != "", nil
+}
+
+// Static serves secrets loaded at once, such as the fields of a Vault or Secrets Manager secret
+type Static map[string]string
+
+func (s Static) Lookup(_ context.Context, name string) (string, bool, error) {
+	value, ok := s[name]
+	return value, ok && value != "", nil
+}
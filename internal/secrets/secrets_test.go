@@ -0,0 +1,52 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ADMIN_TOKEN"), []byte("s3cret\n"), 0o600))
+	provider := File{Dir: dir}
+
+	value, found, err := provider.Lookup(context.Background(), "ADMIN_TOKEN")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "s3cret", value)
+
+	_, found, err = provider.Lookup(context.Background(), "API_KEYS")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	_, _, err = provider.Lookup(context.Background(), "../ADMIN_TOKEN")
+	assert.Error(t, err)
+}
+
+func TestVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "t0ken" || r.URL.Path != "/v1/secret/data/rockets" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data": {"data": {"ADMIN_TOKEN": "s3cret"}, "metadata": {"version": 3}}}`))
+	}))
+	defer server.Close()
+
+	provider, err := NewVault(context.Background(), VaultOptions{Addr: server.URL, Token: "t0ken", Path: "secret/data/rockets"})
+	require.NoError(t, err)
+	value, found, err := provider.Lookup(context.Background(), "ADMIN_TOKEN")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "s3cret", value)
+
+	_, err = NewVault(context.Background(), VaultOptions{Addr: server.URL, Token: "wrong", Path: "secret/data/rockets"})
+	assert.Error(t, err)
+}
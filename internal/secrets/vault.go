@@ -0,0 +1,53 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultOptions locates a secret of the KV version 2 engine of HashiCorp Vault
+type VaultOptions struct {
+	Addr  string // Address of the Vault server, e.g. https://vault.example.com:8200
+	Token string // Token allowed to read the secret
+	Path  string // API path of the secret, e.g. secret/data/rockets
+}
+
+// NewVault reads the secret at opts.Path once; each of its fields is the secret of the same name
+func NewVault(ctx context.Context, opts VaultOptions) (Static, error) {
+	if opts.Addr == "" || opts.Token == "" || opts.Path == "" {
+		return nil, fmt.Errorf("the Vault address, token and secret path are required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	url := strings.TrimSuffix(opts.Addr, "/") + "/v1/" + strings.TrimPrefix(opts.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", opts.Token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("reading Vault secret %s: %w", opts.Path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("reading Vault secret %s: status %d", opts.Path, resp.StatusCode)
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]string `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding Vault secret %s: %w", opts.Path, err)
+	}
+	return Static(body.Data.Data), nil
+}
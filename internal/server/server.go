@@ -0,0 +1,83 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Options bounds the time and memory a client can hold on the server
+type Options struct {
+	ReadHeaderTimeout time.Duration // Reading the request headers
+	ReadTimeout       time.Duration // Reading the whole request, body included
+	WriteTimeout      time.Duration // Handling the request and writing the response
+	IdleTimeout       time.Duration // Keeping an idle keep-alive connection open
+	MaxHeaderBytes    int           // Size of the request headers
+	H2C               bool          // HTTP/2 without TLS, for load balancers terminating TLS. Always offered over TLS
+	// Requests in flight on a single HTTP/2 connection, multiplexed by producers over few connections
+	MaxConcurrentStreams int
+}
+
+// DefaultOptions returns the server settings used when none are configured. Long-lived routes, such as event streams,
+// lift the read and write timeouts themselves
+func DefaultOptions() Options {
+	return Options{
+		ReadHeaderTimeout:    10 * time.Second,
+		ReadTimeout:          30 * time.Second,
+		WriteTimeout:         30 * time.Second,
+		IdleTimeout:          2 * time.Minute,
+		MaxHeaderBytes:       1 << 20,
+		MaxConcurrentStreams: 250,
+	}
+}
+
+// New creates the HTTP server of handler, serving TLS when tlsConfig is set
+func New(addr string, handler http.Handler, tlsConfig *tls.Config, opts Options) *http.Server {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(opts.H2C)
+
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: opts.ReadHeaderTimeout,
+		ReadTimeout:       opts.ReadTimeout,
+		WriteTimeout:      opts.WriteTimeout,
+		IdleTimeout:       opts.IdleTimeout,
+		MaxHeaderBytes:    opts.MaxHeaderBytes,
+		Protocols:         protocols,
+		HTTP2:             &http.HTTP2Config{MaxConcurrentStreams: opts.MaxConcurrentStreams},
+	}
+}
+
+// Serve accepts connections on ln until the server is shut down, over TLS when configured. It can be called for
+// several listeners of the same server
+func Serve(srv *http.Server, ln net.Listener) error {
+	var err error
+	// The first call sets up HTTP/2 with an empty TLS configuration when there is none, so certificates tell
+	// whether TLS was configured
+	if srv.TLSConfig != nil && (len(srv.TLSConfig.Certificates) > 0 || srv.TLSConfig.GetCertificate != nil) {
+		err = srv.ServeTLS(ln, "", "")
+	} else {
+		err = srv.Serve(ln)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
+
+// Shutdown stops accepting connections and waits for the requests in flight until ctx is done. Connections still
+// open then, such as event streams, are closed; their clients reconnect to another instance
+func Shutdown(ctx context.Context, srv *http.Server) error {
+	err := srv.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return errors.Join(err, srv.Close())
+	}
+	return err
+}
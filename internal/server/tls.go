@@ -0,0 +1,80 @@
+// Package server configures the HTTP server of the API: TLS, client certificates and connection limits.
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// Client certificate modes
+const (
+	ClientAuthNone     = "none"     // Client certificates are not requested
+	ClientAuthOptional = "optional" // Certificates are verified when presented; routes decide whether they're required
+	ClientAuthRequired = "required" // Connections without a verified certificate are refused
+)
+
+// TLSOptions configures TLS serving. TLS is disabled while no certificate is set
+type TLSOptions struct {
+	CertFile     string // PEM certificate chain of the server
+	KeyFile      string // PEM private key of the server
+	ClientCAFile string // PEM bundle of the CAs issuing client certificates
+	ClientAuth   string // One of the ClientAuth modes; ClientAuthRequired by default once ClientCAFile is set
+}
+
+// Enabled tells whether TLS is configured
+func (o TLSOptions) Enabled() bool {
+	return o.CertFile != ""
+}
+
+// TLSConfig loads the certificate of the server and, with a client CA bundle, the verification of client certificates
+func TLSConfig(opts TLSOptions) (*tls.Config, error) {
+	if opts.CertFile == "" || opts.KeyFile == "" {
+		return nil, errors.New("TLS needs both a certificate and a key")
+	}
+
+	cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	mode := opts.ClientAuth
+	if mode == "" {
+		mode = ClientAuthNone
+		if opts.ClientCAFile != "" {
+			mode = ClientAuthRequired
+		}
+	}
+
+	switch mode {
+	case ClientAuthNone:
+		return config, nil
+	case ClientAuthOptional:
+		config.ClientAuth = tls.VerifyClientCertIfGiven
+	case ClientAuthRequired:
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	default:
+		return nil, fmt.Errorf("unknown client certificate mode %q, must be one of: %s, %s, %s", mode,
+			ClientAuthNone, ClientAuthOptional, ClientAuthRequired)
+	}
+
+	if opts.ClientCAFile == "" {
+		return nil, fmt.Errorf("client certificate mode %q needs a client CA bundle", mode)
+	}
+	bundle, err := os.ReadFile(opts.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientCAs = x509.NewCertPool()
+	if !config.ClientCAs.AppendCertsFromPEM(bundle) {
+		return nil, fmt.Errorf("no certificate found in client CA bundle %s", opts.ClientCAFile)
+	}
+
+	return config, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"slices"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// groupKeys extract the grouping value of a rocket, keyed by the name of the grouping field
+var groupKeys = map[string]func(rocket *models.Rocket) string{
+	"type": func(rocket *models.Rocket) string {
+		return rocket.Type
+	},
+	"mission": func(rocket *models.Rocket) string {
+		return rocket.Mission
+	},
+	"status": func(rocket *models.Rocket) string {
+		return string(rocket.Status)
+	},
+}
+
+// GroupableFields returns the names of the fields rockets can be grouped by
+func GroupableFields() []string {
+	fields := make([]string, 0, len(groupKeys))
+	for field := range groupKeys {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// groupStats accumulates rocket counts and speed statistics for a group of rockets
+type groupStats struct {
+	count    int
+	byStatus map[models.RocketStatus]int
+	speed    models.SpeedStats
+}
+
+func newGroupStats() *groupStats {
+	return &groupStats{byStatus: make(map[models.RocketStatus]int)}
+}
+
+// add accounts for a rocket in the group
+func (g *groupStats) add(rocket *models.Rocket) {
+	if g.count == 0 || rocket.Speed < g.speed.Min {
+		g.speed.Min = rocket.Speed
+	}
+	if g.count == 0 || rocket.Speed > g.speed.Max {
+		g.speed.Max = rocket.Speed
+	}
+
+	g.count++
+	g.byStatus[rocket.Status]++
+	g.speed.Total += rocket.Speed
+	g.speed.Average = float64(g.speed.Total) / float64(g.count)
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// AnomalyDetector flags physically implausible transitions between consecutive states of a rocket
+type AnomalyDetector struct {
+	// MaxSpeedDelta is the largest plausible speed change, in km/h, caused by a single message. Zero disables the check
+	MaxSpeedDelta int
+}
+
+// Inspect compares the state of a rocket before and after a message was applied and reports the anomalies found
+func (d AnomalyDetector) Inspect(previous, current *models.Rocket, msg *models.RocketMessage) []models.Anomaly {
+	var anomalies []models.Anomaly
+	flag := func(kind models.AnomalyKind, format string, args ...any) {
+		anomalies = append(anomalies, models.Anomaly{
+			Kind:          kind,
+			Detail:        fmt.Sprintf(format, args...),
+			MessageNumber: msg.Metadata.MessageNumber,
+			Time:          msg.Metadata.MessageTime,
+		})
+	}
+
+	delta := current.Speed - previous.Speed
+	if delta < 0 {
+		delta = -delta
+	}
+	if d.MaxSpeedDelta > 0 && delta > d.MaxSpeedDelta {
+		flag(models.AnomalySpeedJump, "speed changed by %d in a single message, more than %d", delta, d.MaxSpeedDelta)
+	}
+
+	if current.Speed < 0 && previous.Speed >= 0 {
+		flag(models.AnomalyNegativeSpeed, "speed dropped to %d", current.Speed)
+	}
+
+	if previous.FuelLevel != nil && current.FuelLevel != nil && *current.FuelLevel > *previous.FuelLevel {
+		flag(models.AnomalyFuelIncrease, "fuel level rose from %g to %g", *previous.FuelLevel, *current.FuelLevel)
+	}
+
+	return anomalies
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImport(t *testing.T) {
+	event := func(number int64) *models.BackupRecord {
+		return &models.BackupRecord{Kind: models.RecordKindEvent, Event: &models.RocketEvent{
+			Channel: testChannel, Type: "RocketSpeedIncreased", MessageNumber: number, Time: launchedAt,
+		}}
+	}
+	rocket := func(lastMessageNumber int64) *models.BackupRecord {
+		return &models.BackupRecord{Kind: models.RecordKindRocket, Rocket: &models.Rocket{
+			ID: testChannel, Mission: "ARTEMIS", Status: models.StatusActive, LastMessageNumber: lastMessageNumber,
+		}}
+	}
+
+	tests := []struct {
+		name                string
+		stored              []*models.BackupRecord // Imported first
+		record              *models.BackupRecord
+		opts                ImportOptions
+		expectedImported    bool
+		expectedErr         bool
+		expectedEvents      int
+		expectedLastMessage int64 // Of the stored rocket, if any
+	}{
+		{name: "new rocket", record: rocket(5), expectedImported: true, expectedLastMessage: 5},
+		{
+			name:                "rocket overwrites the stored one",
+			stored:              []*models.BackupRecord{rocket(9)},
+			record:              rocket(5),
+			expectedImported:    true,
+			expectedLastMessage: 5,
+		},
+		{
+			name:                "rocket older than the stored one kept out",
+			stored:              []*models.BackupRecord{rocket(9)},
+			record:              rocket(5),
+			opts:                ImportOptions{KeepNewer: true},
+			expectedLastMessage: 9,
+		},
+		{
+			name:                "rocket newer than the stored one",
+			stored:              []*models.BackupRecord{rocket(5)},
+			record:              rocket(9),
+			opts:                ImportOptions{KeepNewer: true},
+			expectedImported:    true,
+			expectedLastMessage: 9,
+		},
+		{name: "new event", record: event(1), expectedImported: true, expectedEvents: 1},
+		{
+			name:           "event imported again skipped",
+			stored:         []*models.BackupRecord{event(1), event(2)},
+			record:         event(1),
+			expectedEvents: 2,
+		},
+		{
+			name:             "event of another message",
+			stored:           []*models.BackupRecord{event(1)},
+			record:           event(2),
+			expectedImported: true,
+			expectedEvents:   2,
+		},
+		{name: "unknown kind", record: &models.BackupRecord{Kind: "fleet"}, expectedErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rockets := inmemory.NewInMemoryRepository()
+			events := inmemory.NewInMemoryEventRepository(100)
+			s := NewBackupService(rockets, events)
+			for _, record := range tt.stored {
+				imported, err := s.Import(ctx, record, ImportOptions{})
+				require.NoError(t, err)
+				require.True(t, imported)
+			}
+
+			imported, err := s.Import(ctx, tt.record, tt.opts)
+
+			if tt.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedImported, imported)
+			assert.Len(t, events.FindByChannel(ctx, testChannel), tt.expectedEvents)
+			if tt.expectedLastMessage > 0 {
+				stored, err := rockets.FindByID(ctx, testChannel)
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedLastMessage, stored.LastMessageNumber)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// maxChannelStats is the number of channels whose counters are kept, those of the channel submitted to the least
+// recently being forgotten first: clients can submit to as many channels as they like
+const maxChannelStats = 10000
+
+// channelStats keeps processing counters of recently active channels, to debug a single misbehaving producer
+type channelStats struct {
+	mu       sync.Mutex
+	limit    int
+	channels map[string]*list.Element // Values are *models.ChannelStats
+	recent   *list.List               // Most recently used first
+}
+
+func newChannelStats(limit int) *channelStats {
+	return &channelStats{
+		limit:    limit,
+		channels: make(map[string]*list.Element),
+		recent:   list.New(),
+	}
+}
+
+// channel returns the counters of a channel, creating them on first use and evicting the least recently used ones
+// beyond the limit. Must be called with the lock held
+func (c *channelStats) channel(channel string) *models.ChannelStats {
+	if element, ok := c.channels[channel]; ok {
+		c.recent.MoveToFront(element)
+		return element.Value.(*models.ChannelStats)
+	}
+
+	stats := &models.ChannelStats{Channel: channel}
+	c.channels[channel] = c.recent.PushFront(stats)
+	if c.recent.Len() > c.limit {
+		oldest := c.recent.Remove(c.recent.Back()).(*models.ChannelStats)
+		delete(c.channels, oldest.Channel)
+	}
+	return stats
+}
+
+// forget removes the counters of a channel, e.g. once its rocket is deleted
+func (c *channelStats) forget(channel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.channels[channel]; ok {
+		c.recent.Remove(element)
+		delete(c.channels, channel)
+	}
+}
+
+// received counts a message submitted for the channel, whether it is applied or not
+func (c *channelStats) received(channel string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.channel(channel).MessagesReceived++
+}
+
+// applied records the number of a message applied to the channel's rocket, and how many numbers it skipped
+func (c *channelStats) applied(channel string, number, skipped int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.channel(channel)
+	stats.LastMessageNumber = number
+	if skipped > 0 {
+		stats.Gaps++
+		stats.MissingMessages += skipped
+	}
+}
+
+// processed records the processing lag of the latest message of the channel
+func (c *channelStats) processed(channel string, lag time.Duration, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.channel(channel)
+	stats.LastProcessingLag = lag.Seconds()
+	stats.LastProcessedAt = &at
+}
+
+// dropped counts a message of the channel that was not applied, for one of the metrics.Reason values
+func (c *channelStats) dropped(channel, reason string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	drops := &c.channel(channel).Dropped
+	switch reason {
+	case metrics.ReasonQueueFull:
+		drops.QueueFull++
+	case metrics.ReasonDuplicate:
+		drops.Duplicate++
+	case metrics.ReasonOutOfOrder:
+		drops.OutOfOrder++
+	case metrics.ReasonUnknownType:
+		drops.UnknownType++
+	default:
+		drops.Invalid++
+	}
+}
+
+// get returns a copy of the counters of a channel, if any message was submitted for it
+func (c *channelStats) get(channel string) (models.ChannelStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.channels[channel]
+	if !ok {
+		return models.ChannelStats{}, false
+	}
+	stats := element.Value.(*models.ChannelStats)
+	clone := *stats
+	if stats.LastProcessedAt != nil {
+		at := *stats.LastProcessedAt
+		clone.LastProcessedAt = &at
+	}
+	return clone, true
+}
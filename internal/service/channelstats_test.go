@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelStatsEvictsLeastRecentlyUsed(t *testing.T) {
+	stats := newChannelStats(2)
+	stats.received("a")
+	stats.received("b")
+	stats.dropped("a", metrics.ReasonDuplicate)
+	stats.received("c")
+
+	_, ok := stats.get("b")
+	assert.False(t, ok, "the least recently used channel is evicted")
+	a, ok := stats.get("a")
+	assert.True(t, ok)
+	assert.Equal(t, int64(1), a.MessagesReceived)
+	assert.Equal(t, int64(1), a.Dropped.Duplicate)
+	_, ok = stats.get("c")
+	assert.True(t, ok)
+
+	stats.forget("a")
+	_, ok = stats.get("a")
+	assert.False(t, ok)
+	stats.received("d")
+	_, ok = stats.get("c")
+	assert.True(t, ok, "forgetting a channel frees its room")
+}
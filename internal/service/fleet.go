@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=fleet.go -destination=mocks/mock_fleet_service.go -package=mocks
+
+// FleetService defines the methods for managing fleets and aggregating their rockets
+type FleetService interface {
+	CreateFleet(ctx context.Context, req models.FleetRequest) (*models.Fleet, error)
+	UpdateFleet(ctx context.Context, id string, req models.FleetRequest) (*models.Fleet, error)
+	DeleteFleet(ctx context.Context, id string) error
+	GetFleet(ctx context.Context, id string) (*models.Fleet, error)
+	ListFleets(ctx context.Context) ([]*models.Fleet, error)
+	ListFleetRockets(ctx context.Context, id string, sortFields []models.SortField) ([]*models.Rocket, error)
+	RemoveMember(ctx context.Context, rocketID string) (int, error)
+}
+
+// fleetService stores fleet definitions and resolves their rockets from the rocket repository
+type fleetService struct {
+	fleets repository.FleetRepository
+	repo   repository.RocketRepository
+}
+
+// NewFleetService creates a new fleet service
+func NewFleetService(fleets repository.FleetRepository, repo repository.RocketRepository) FleetService {
+	return &fleetService{
+		fleets: fleets,
+		repo:   repo,
+	}
+}
+
+// CreateFleet defines a new fleet with a generated ID
+func (s *fleetService) CreateFleet(ctx context.Context, req models.FleetRequest) (*models.Fleet, error) {
+	fleet := &models.Fleet{
+		ID:        uuid.NewString(),
+		CreatedAt: time.Now().UTC(),
+	}
+	applyFleetRequest(fleet, req)
+
+	if err := s.fleets.Save(ctx, fleet); err != nil {
+		return nil, err
+	}
+
+	return s.aggregate(ctx, fleet), nil
+}
+
+// UpdateFleet replaces the name, members and selector of a fleet
+func (s *fleetService) UpdateFleet(ctx context.Context, id string, req models.FleetRequest) (*models.Fleet, error) {
+	fleet, err := s.fleets.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	applyFleetRequest(fleet, req)
+
+	if err := s.fleets.Save(ctx, fleet); err != nil {
+		return nil, err
+	}
+
+	return s.aggregate(ctx, fleet), nil
+}
+
+// DeleteFleet removes a fleet definition. Its rockets are left untouched
+func (s *fleetService) DeleteFleet(ctx context.Context, id string) error {
+	return s.fleets.Delete(ctx, id)
+}
+
+// RemoveMember drops a rocket from the members of every fleet listing it and returns how many fleets were changed
+func (s *fleetService) RemoveMember(ctx context.Context, rocketID string) (int, error) {
+	removed := 0
+	for _, fleet := range s.fleets.FindAll(ctx) {
+		if !slices.Contains(fleet.Members, rocketID) {
+			continue
+		}
+
+		fleet.Members = slices.DeleteFunc(fleet.Members, func(member string) bool {
+			return member == rocketID
+		})
+		if err := s.fleets.Save(ctx, fleet); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+// GetFleet retrieves a fleet with the aggregates of its current rockets
+func (s *fleetService) GetFleet(ctx context.Context, id string) (*models.Fleet, error) {
+	fleet, err := s.fleets.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.aggregate(ctx, fleet), nil
+}
+
+// ListFleets retrieves every fleet with the aggregates of its current rockets, sorted by name
+func (s *fleetService) ListFleets(ctx context.Context) ([]*models.Fleet, error) {
+	fleets := s.fleets.FindAll(ctx)
+	for i, fleet := range fleets {
+		fleets[i] = s.aggregate(ctx, fleet)
+	}
+
+	return fleets, nil
+}
+
+// ListFleetRockets retrieves the non-archived rockets of a fleet, sorted by the given keys
+func (s *fleetService) ListFleetRockets(
+	ctx context.Context,
+	id string,
+	sortFields []models.SortField,
+) ([]*models.Rocket, error) {
+	fleet, err := s.fleets.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	rockets := s.rockets(ctx, fleet)
+	sortRockets(rockets, sortFields)
+
+	return rockets, nil
+}
+
+// rockets resolves the non-archived rockets of a fleet: its listed members and the rockets matching its selector
+func (s *fleetService) rockets(ctx context.Context, fleet *models.Fleet) []*models.Rocket {
+	found := make(map[string]*models.Rocket)
+
+	for _, id := range fleet.Members {
+		rocket, err := s.repo.FindByID(ctx, id)
+		if err != nil || rocket.ArchivedAt != nil {
+			// Members may not have launched yet, or may have been deleted or archived since
+			continue
+		}
+		found[rocket.ID] = rocket
+	}
+
+	if len(fleet.Selector) > 0 {
+		for _, rocket := range s.repo.FindAll(ctx, models.RocketFilter{Labels: fleet.Selector}) {
+			found[rocket.ID] = rocket
+		}
+	}
+
+	return slices.Collect(maps.Values(found))
+}
+
+// aggregate fills in the rocket count, status breakdown and speed statistics of a fleet
+func (s *fleetService) aggregate(ctx context.Context, fleet *models.Fleet) *models.Fleet {
+	stats := newGroupStats()
+	for _, rocket := range s.rockets(ctx, fleet) {
+		stats.add(rocket)
+	}
+
+	fleet.RocketCount = stats.count
+	fleet.ByStatus = stats.byStatus
+	fleet.Speed = stats.speed
+	return fleet
+}
+
+// applyFleetRequest copies the definition of a fleet request into a fleet, dropping duplicate members
+func applyFleetRequest(fleet *models.Fleet, req models.FleetRequest) {
+	members := slices.Clone(req.Members)
+	slices.Sort(members)
+
+	fleet.Name = req.Name
+	fleet.Members = slices.Compact(members)
+	fleet.Selector = maps.Clone(req.Selector)
+}
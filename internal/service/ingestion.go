@@ -0,0 +1,126 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/validation"
+)
+
+// ingestionWindow is the longest period ingestion rates are computed over, kept as one bucket per second
+const ingestionWindow = 15 * time.Minute
+
+// unknownMessageType groups submitted messages of unknown types, so that clients can't grow the breakdown at will
+const unknownMessageType = "unknown"
+
+// ingestionStats counts submitted messages by type, accepted or rejected, over a rolling window
+type ingestionStats struct {
+	mu      sync.Mutex
+	started time.Time
+	types   map[string]*typeIngestion
+}
+
+// typeIngestion counts the messages of a single type
+type typeIngestion struct {
+	accepted, rejected int64
+	buckets            []ingestionBucket // Ring buffer of one bucket per second, indexed by unix time
+}
+
+type ingestionBucket struct {
+	second int64
+	count  int64
+}
+
+func newIngestionStats(now time.Time) *ingestionStats {
+	return &ingestionStats{started: now, types: make(map[string]*typeIngestion)}
+}
+
+// record counts a message submitted at now, accepted for processing or not
+func (s *ingestionStats) record(messageType string, accepted bool, now time.Time) {
+	if !validation.IsKnownMessageType(messageType) {
+		messageType = unknownMessageType
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	t, ok := s.types[messageType]
+	if !ok {
+		t = &typeIngestion{buckets: make([]ingestionBucket, int(ingestionWindow/time.Second))}
+		s.types[messageType] = t
+	}
+
+	if accepted {
+		t.accepted++
+	} else {
+		t.rejected++
+	}
+
+	second := now.Unix()
+	bucket := &t.buckets[second%int64(len(t.buckets))]
+	if bucket.second != second {
+		*bucket = ingestionBucket{second: second}
+	}
+	bucket.count++
+}
+
+// stats computes the rates as of now, overall and by message type
+func (s *ingestionStats) stats(now time.Time) models.IngestionStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := models.IngestionStats{ByType: make([]models.TypeIngestion, 0, len(s.types))}
+	var overall [3]int64
+	for messageType, t := range s.types {
+		counts := t.counts(now)
+		for i := range overall {
+			overall[i] += counts[i]
+		}
+
+		stats.Accepted += t.accepted
+		stats.Rejected += t.rejected
+		stats.ByType = append(stats.ByType, models.TypeIngestion{
+			Type:     messageType,
+			Accepted: t.accepted,
+			Rejected: t.rejected,
+			Rates:    s.rates(counts, now),
+		})
+	}
+	stats.Rates = s.rates(overall, now)
+
+	slices.SortFunc(stats.ByType, func(a, b models.TypeIngestion) int {
+		return strings.Compare(a.Type, b.Type)
+	})
+	return stats
+}
+
+// ingestionRateWindows are the periods rates are computed over
+var ingestionRateWindows = [3]time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute}
+
+// counts sums the messages submitted within each rate window before now
+func (t *typeIngestion) counts(now time.Time) [3]int64 {
+	var counts [3]int64
+	for _, bucket := range t.buckets {
+		age := now.Unix() - bucket.second
+		for i, window := range ingestionRateWindows {
+			if bucket.count > 0 && age >= 0 && age < int64(window/time.Second) {
+				counts[i] += bucket.count
+			}
+		}
+	}
+	return counts
+}
+
+// rates turns window counts into messages per second. Windows longer than the uptime are averaged over the uptime,
+// so that rates are right from the start
+func (s *ingestionStats) rates(counts [3]int64, now time.Time) models.IngestionRates {
+	var rates [3]float64
+	for i, window := range ingestionRateWindows {
+		period := min(window, now.Sub(s.started))
+		rates[i] = float64(counts[i]) / max(period.Seconds(), 1)
+	}
+	return models.IngestionRates{Rate1m: rates[0], Rate5m: rates[1], Rate15m: rates[2]}
+}
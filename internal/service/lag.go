@@ -0,0 +1,67 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// lagWindowSize is the number of recent messages processing lag percentiles are computed over
+const lagWindowSize = 1000
+
+// lagWindow keeps the processing lag of the most recent messages, to compute percentiles on demand
+type lagWindow struct {
+	mu        sync.Mutex
+	samples   []time.Duration // Ring buffer of the latest lags
+	next      int             // Index the next lag is written at once the buffer is full
+	processed int64
+}
+
+func newLagWindow(size int) *lagWindow {
+	return &lagWindow{samples: make([]time.Duration, 0, size)}
+}
+
+// add records the processing lag of a message
+func (w *lagWindow) add(lag time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.processed++
+	if len(w.samples) < cap(w.samples) {
+		w.samples = append(w.samples, lag)
+		return
+	}
+	w.samples[w.next] = lag
+	w.next = (w.next + 1) % len(w.samples)
+}
+
+// stats computes the lag percentiles over the recorded window
+func (w *lagWindow) stats() models.ProcessingStats {
+	w.mu.Lock()
+	samples := slices.Clone(w.samples)
+	processed := w.processed
+	w.mu.Unlock()
+
+	stats := models.ProcessingStats{
+		MessagesProcessed: processed,
+		Samples:           len(samples),
+	}
+	if len(samples) == 0 {
+		return stats
+	}
+
+	slices.Sort(samples)
+	stats.LagP50 = percentile(samples, 50).Seconds()
+	stats.LagP99 = percentile(samples, 99).Seconds()
+	stats.LagMax = samples[len(samples)-1].Seconds()
+	return stats
+}
+
+// percentile returns the nearest-rank percentile p of sorted, non-empty samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
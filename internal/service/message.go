@@ -2,109 +2,407 @@ package service
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-
+	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/logging"
+	"github.com/ahernandez9/rockets/internal/metrics"
 	"github.com/ahernandez9/rockets/internal/models"
 	"github.com/ahernandez9/rockets/internal/pubsub"
 	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/validation"
+
+	"github.com/google/uuid"
 )
 
 //go:generate go run go.uber.org/mock/mockgen -source=message.go -destination=mocks/mock_message_service.go -package=mocks
 
+// ErrProcessingFailed is returned to synchronous publishers when the message could not be applied
+var ErrProcessingFailed = errors.New("message processing failed")
+
 type MessageService interface {
 	Start()
 	Stop()
-	PublishMessage(msg *models.RocketMessage) error
+	PublishMessage(ctx context.Context, msg *models.RocketMessage) error
+	PublishMessageAndWait(ctx context.Context, msg *models.RocketMessage) (*models.Rocket, error)
+	ProcessingStats() models.ProcessingStats
+	// ChannelStats reports how the messages of a channel were processed; false if none was ever submitted
+	ChannelStats(channel string) (models.ChannelStats, bool)
+	// ForgetChannel drops the processing counters of a channel, once its rocket is deleted or its data erased
+	ForgetChannel(channel string)
+	// IngestionStats reports the rates of submitted messages, accepted and rejected, overall and by message type
+	IngestionStats() models.IngestionStats
+	// Running tells whether the processor is consuming messages, between Start and Stop
+	Running() bool
+	// RecordRejected counts a message that failed validation and was never published
+	RecordRejected(msg *models.RocketMessage, err error)
+	// SetAnomalyDetector changes the rules flagging implausible telemetry, from the next processed message on
+	SetAnomalyDetector(d AnomalyDetector)
+}
+
+// processingResult is delivered to callers waiting for a message to be processed
+type processingResult struct {
+	rocket *models.Rocket
+	err    error
 }
 
 // messageService handles async message processing via pub/sub
 type messageService struct {
 	pubsub pubsub.Interface
 	repo   repository.RocketRepository
-	ctx    context.Context
-	cancel context.CancelFunc
+	events repository.EventRepository
+	tracks repository.TrackRepository
+
+	detector atomic.Pointer[AnomalyDetector]
+	metrics  metrics.Recorder
+	reporter errreport.Reporter
+	lags     *lagWindow
+	channels *channelStats
+	ingested *ingestionStats
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running atomic.Bool
+
+	waitersMu sync.Mutex
+	waiters   map[string][]chan processingResult
 }
 
 // NewMessageService creates a new message service
-func NewMessageService(ps pubsub.Interface, r repository.RocketRepository) MessageService {
+func NewMessageService(
+	ps pubsub.Interface,
+	r repository.RocketRepository,
+	e repository.EventRepository,
+	t repository.TrackRepository,
+	d AnomalyDetector,
+	m metrics.Recorder,
+	rep errreport.Reporter,
+) MessageService {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &messageService{
-		pubsub: ps,
-		repo:   r,
-		ctx:    ctx,
-		cancel: cancel,
+	s := &messageService{
+		pubsub:   ps,
+		repo:     r,
+		events:   e,
+		tracks:   t,
+		metrics:  m,
+		reporter: rep,
+		lags:     newLagWindow(lagWindowSize),
+		channels: newChannelStats(maxChannelStats),
+		ingested: newIngestionStats(time.Now()),
+		ctx:      ctx,
+		cancel:   cancel,
+		waiters:  make(map[string][]chan processingResult),
 	}
+	s.SetAnomalyDetector(d)
+	return s
 }
 
 // Start begins processing messages
 func (s *messageService) Start() {
-	log.Println("MessageService: Started message processor")
+	slog.Info("Started message processor")
+	s.running.Store(true)
+	defer s.running.Store(false)
 
 	if err := s.pubsub.Subscribe(s.ctx, s.handleMessage); err != nil {
-		log.Printf("MessageService: Subscriber stopped: %v", err)
+		slog.Error("Message subscriber stopped", "error", err)
 	}
 
-	log.Println("MessageService: Message processor stopped")
+	slog.Info("Message processor stopped")
+}
+
+func (s *messageService) Running() bool {
+	return s.running.Load()
+}
+
+func (s *messageService) SetAnomalyDetector(d AnomalyDetector) {
+	s.detector.Store(&d)
 }
 
 // Stop gracefully stops the message service
 func (s *messageService) Stop() {
-	log.Println("MessageService: Stopping")
+	slog.Info("Stopping message processor")
 	s.cancel()
 	s.pubsub.Close()
 }
 
-// PublishMessage publishes a message for async processing
-func (s *messageService) PublishMessage(msg *models.RocketMessage) error {
-	return s.pubsub.Publish(s.ctx, msg)
+// PublishMessage publishes a message for async processing. ctx only scopes the publication, e.g. its trace,
+// processing is not canceled with it
+func (s *messageService) PublishMessage(ctx context.Context, msg *models.RocketMessage) error {
+	return s.publish(ctx, msg)
 }
 
-// handleMessage processes a single message (callback from subscriber)
-// In a production scenario, would implement retry logic with exponential backoff for consistency
+// publish hands a message over to the processor, counting the messages turned away by a full queue
+func (s *messageService) publish(ctx context.Context, msg *models.RocketMessage) error {
+	msg.Metadata.RequestID = logging.RequestID(ctx)
+	s.channels.received(msg.Metadata.Channel)
+
+	err := s.pubsub.Publish(ctx, msg)
+	s.ingested.record(msg.Metadata.MessageType, err == nil, time.Now())
+	if errors.Is(err, pubsub.ErrQueueFull) {
+		s.drop(msg.Metadata.Channel, metrics.ReasonQueueFull)
+	}
+	return err
+}
+
+// drop counts a message of a channel that was not applied, for one of the metrics.Reason values
+func (s *messageService) drop(channel, reason string) {
+	s.metrics.MessageDropped(reason)
+	s.channels.dropped(channel, reason)
+}
+
+// RecordRejected counts a message that failed validation and was never published
+func (s *messageService) RecordRejected(msg *models.RocketMessage, err error) {
+	s.ingested.record(msg.Metadata.MessageType, false, time.Now())
+
+	reason := metrics.ReasonInvalid
+	if errors.Is(err, validation.ErrInvalidMessageType) {
+		reason = metrics.ReasonUnknownType
+	}
+
+	// Malformed channels get no stats of their own, so that clients can't create entries at will
+	channel := msg.Metadata.Channel
+	if _, err := uuid.Parse(channel); err != nil {
+		s.metrics.MessageDropped(reason)
+		return
+	}
+	s.channels.received(channel)
+	s.drop(channel, reason)
+}
+
+// PublishMessageAndWait publishes a message and blocks until it has been processed or ctx is done.
+// Returns the rocket state after the message was applied (or ignored as a duplicate).
+func (s *messageService) PublishMessageAndWait(ctx context.Context, msg *models.RocketMessage) (*models.Rocket, error) {
+	key := waiterKey(msg)
+	resultChan := make(chan processingResult, 1)
+
+	s.addWaiter(key, resultChan)
+
+	if err := s.publish(ctx, msg); err != nil {
+		s.removeWaiter(key, resultChan)
+		return nil, err
+	}
+
+	select {
+	case result := <-resultChan:
+		return result.rocket, result.err
+	case <-ctx.Done():
+		s.removeWaiter(key, resultChan)
+		return nil, ctx.Err()
+	}
+}
+
+// waiterKey identifies a message within its channel
+func waiterKey(msg *models.RocketMessage) string {
+	return fmt.Sprintf("%s/%d", msg.Metadata.Channel, msg.Metadata.MessageNumber)
+}
+
+func (s *messageService) addWaiter(key string, resultChan chan processingResult) {
+	s.waitersMu.Lock()
+	defer s.waitersMu.Unlock()
+
+	s.waiters[key] = append(s.waiters[key], resultChan)
+}
+
+func (s *messageService) removeWaiter(key string, resultChan chan processingResult) {
+	s.waitersMu.Lock()
+	defer s.waitersMu.Unlock()
+
+	waiters := s.waiters[key]
+	for i, w := range waiters {
+		if w == resultChan {
+			waiters = append(waiters[:i], waiters[i+1:]...)
+			break
+		}
+	}
+
+	if len(waiters) == 0 {
+		delete(s.waiters, key)
+		return
+	}
+	s.waiters[key] = waiters
+}
+
+// notifyWaiters delivers the processing outcome to every caller waiting on the message
+func (s *messageService) notifyWaiters(ctx context.Context, msg *models.RocketMessage, processErr error) {
+	key := waiterKey(msg)
+
+	s.waitersMu.Lock()
+	waiters := s.waiters[key]
+	delete(s.waiters, key)
+	s.waitersMu.Unlock()
+
+	if len(waiters) == 0 {
+		return
+	}
+
+	var result processingResult
+	if processErr != nil {
+		result.err = fmt.Errorf("%w: %v", ErrProcessingFailed, processErr)
+	} else {
+		result.rocket, result.err = s.repo.FindByID(ctx, msg.Metadata.Channel)
+	}
+
+	for _, w := range waiters {
+		w <- result
+	}
+}
+
+// handleMessage processes a single message (callback from subscriber) and wakes up synchronous publishers
 func (s *messageService) handleMessage(ctx context.Context, msg *models.RocketMessage) error {
+	err := s.applyMessage(ctx, msg)
+
+	now := time.Now()
+	lag := now.Sub(msg.Metadata.MessageTime)
+	s.lags.add(lag)
+	s.metrics.MessageProcessed(lag)
+	s.channels.processed(msg.Metadata.Channel, lag, now)
+
+	s.notifyWaiters(ctx, msg, err)
+	return err
+}
+
+// applyMessage processes a message and reports its failure. Panics are recovered, so that a single faulty message
+// can't stop the processor
+func (s *messageService) applyMessage(ctx context.Context, msg *models.RocketMessage) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			slog.ErrorContext(ctx, "Panic processing message", "channel", msg.Metadata.Channel,
+				"messageNumber", msg.Metadata.MessageNumber, "panic", recovered, "stack", logging.Stack(0))
+			s.metrics.PanicRecovered(metrics.SourceProcessing)
+			s.reporter.ReportPanic(ctx, recovered, messageTags(msg))
+			err = fmt.Errorf("panic: %v", recovered)
+		}
+	}()
+
+	if err = s.processMessage(ctx, msg); err != nil {
+		s.reporter.ReportError(ctx, err, messageTags(msg))
+	}
+	return err
+}
+
+// messageTags describes a message in error reports
+func messageTags(msg *models.RocketMessage) map[string]string {
+	tags := map[string]string{
+		"channel":       msg.Metadata.Channel,
+		"messageNumber": strconv.FormatInt(msg.Metadata.MessageNumber, 10),
+		"messageType":   msg.Metadata.MessageType,
+	}
+	if msg.Metadata.RequestID != "" {
+		tags["requestId"] = msg.Metadata.RequestID
+	}
+	return tags
+}
+
+func (s *messageService) ChannelStats(channel string) (models.ChannelStats, bool) {
+	return s.channels.get(channel)
+}
+
+func (s *messageService) ForgetChannel(channel string) {
+	s.channels.forget(channel)
+}
+
+func (s *messageService) IngestionStats() models.IngestionStats {
+	return s.ingested.stats(time.Now())
+}
+
+// ProcessingStats reports how far behind the message time processing runs, over the most recent messages
+func (s *messageService) ProcessingStats() models.ProcessingStats {
+	return s.lags.stats()
+}
+
+// processMessage applies a single message to the rocket state
+// In a production scenario, would implement retry logic with exponential backoff for consistency
+func (s *messageService) processMessage(ctx context.Context, msg *models.RocketMessage) error {
 	channelID := msg.Metadata.Channel
+	ctx = logging.With(ctx, "channel", channelID, "messageNumber", msg.Metadata.MessageNumber,
+		"messageType", msg.Metadata.MessageType)
+	if msg.Metadata.RequestID != "" {
+		ctx = logging.With(ctx, "requestId", msg.Metadata.RequestID)
+	}
 
 	existingRocket, _ := s.repo.FindByID(ctx, channelID)
 
 	// Check for duplicates/out-of-order
 	if existingRocket != nil && msg.Metadata.MessageNumber <= existingRocket.LastMessageNumber {
-		log.Printf("MessageService: Ignoring old/duplicate message: channel=%s, msgNum=%d, lastProcessed=%d",
-			channelID, msg.Metadata.MessageNumber, existingRocket.LastMessageNumber)
+		slog.InfoContext(ctx, "Ignoring old or duplicate message", "lastProcessed", existingRocket.LastMessageNumber)
+
+		reason := metrics.ReasonOutOfOrder
+		if msg.Metadata.MessageNumber == existingRocket.LastMessageNumber {
+			reason = metrics.ReasonDuplicate
+		}
+		s.drop(channelID, reason)
 		return nil
 	}
 
+	var err error
 	switch msg.Metadata.MessageType {
 	case "RocketLaunched":
-		return s.handleRocketLaunched(ctx, channelID, msg)
+		err = s.handleRocketLaunched(ctx, channelID, msg)
 	case "RocketSpeedIncreased", "RocketSpeedDecreased":
-		return s.handleRocketSpeedChanged(ctx, channelID, msg)
+		err = s.handleRocketSpeedChanged(ctx, channelID, msg)
 	case "RocketExploded":
-		return s.handleRocketExploded(ctx, channelID, msg)
+		err = s.handleRocketExploded(ctx, channelID, msg)
+	case "RocketLanded":
+		err = s.handleRocketLanded(ctx, channelID, msg)
+	case "RocketDecommissioned":
+		err = s.handleRocketDecommissioned(ctx, channelID, msg)
 	case "RocketMissionChanged":
-		return s.handleRocketMissionChanged(ctx, channelID, msg)
+		err = s.handleRocketMissionChanged(ctx, channelID, msg)
+	case "RocketFuelUpdated":
+		err = s.handleRocketFuelUpdated(ctx, channelID, msg)
+	case "RocketPositionUpdated":
+		err = s.handleRocketPositionUpdated(ctx, channelID, msg)
+	case "RocketStageSeparated":
+		err = s.handleRocketStageSeparated(ctx, channelID, msg)
+	case "RocketPayloadDeployed":
+		err = s.handleRocketPayloadDeployed(ctx, channelID, msg)
 	default:
+		s.drop(channelID, metrics.ReasonUnknownType)
 		return fmt.Errorf("unknown message type: %s", msg.Metadata.MessageType)
 	}
+	if err != nil {
+		return err
+	}
+
+	var skipped int64
+	if existingRocket != nil {
+		skipped = msg.Metadata.MessageNumber - existingRocket.LastMessageNumber - 1
+	}
+	s.channels.applied(channelID, msg.Metadata.MessageNumber, skipped)
+
+	s.recordEvent(ctx, msg)
+	return nil
 }
 
-// parseMessage helper function to parse and type assert message payload
-func parseMessage[T any](msg *models.RocketMessage) (T, error) {
-	var result T
+// recordEvent appends an applied message to the rocket's history. History is best-effort: failures are only logged
+func (s *messageService) recordEvent(ctx context.Context, msg *models.RocketMessage) {
+	event := &models.RocketEvent{
+		Channel:       msg.Metadata.Channel,
+		Type:          msg.Metadata.MessageType,
+		MessageNumber: msg.Metadata.MessageNumber,
+		Time:          msg.Metadata.MessageTime,
+		Payload:       msg.Message,
+	}
 
-	// msg.Message is interface{}, need to marshal/unmarshal
-	// Type assertion won't work because Gin unmarshals to map[string]interface{}
-	msgBytes, err := json.Marshal(msg.Message)
-	if err != nil {
-		return result, fmt.Errorf("failed to marshal message: %w", err)
+	if err := s.events.Append(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "Failed to record event", "error", err)
 	}
+}
 
-	if err := json.Unmarshal(msgBytes, &result); err != nil {
+// parseMessage decodes the content of a message into the structure of its type
+func parseMessage[T any](msg *models.RocketMessage) (T, error) {
+	var result T
+	if err := jsoncodec.Unmarshal(msg.Message, &result); err != nil {
 		return result, fmt.Errorf("failed to unmarshal message: %w", err)
 	}
-
 	return result, nil
 }
 
@@ -112,6 +410,42 @@ func parseMessage[T any](msg *models.RocketMessage) (T, error) {
 func updateRocketMetadata(rocket *models.Rocket, msg *models.RocketMessage) {
 	rocket.LastMessageNumber = msg.Metadata.MessageNumber
 	rocket.LastUpdated = msg.Metadata.MessageTime
+	if rocket.LaunchTime != nil {
+		rocket.FlightDuration = rocket.LastUpdated.Sub(*rocket.LaunchTime).Seconds()
+	}
+}
+
+// update applies a message to a stored rocket through fn, flagging the implausible transitions it causes
+func (s *messageService) update(
+	ctx context.Context,
+	channelID string,
+	msg *models.RocketMessage,
+	fn func(rocket *models.Rocket) error,
+) (*models.Rocket, error) {
+	var anomalies []models.Anomaly
+	rocket, err := s.repo.Update(ctx, channelID, func(rocket *models.Rocket) error {
+		previous := rocket.Clone()
+		if err := fn(rocket); err != nil {
+			return err
+		}
+
+		// The rocket kept its previous speed until this message
+		rocket.AccumulateDistance(previous.Speed, previous.LastUpdated)
+
+		anomalies = s.detector.Load().Inspect(previous, rocket, msg)
+		rocket.AddAnomalies(anomalies...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, anomaly := range anomalies {
+		s.metrics.AnomalyDetected(string(anomaly.Kind))
+		slog.WarnContext(ctx, "Anomaly detected", "kind", anomaly.Kind, "detail", anomaly.Detail)
+	}
+
+	return rocket, nil
 }
 
 func (s *messageService) handleRocketLaunched(ctx context.Context, channelID string, msg *models.RocketMessage) error {
@@ -120,82 +454,270 @@ func (s *messageService) handleRocketLaunched(ctx context.Context, channelID str
 		return err
 	}
 
-	rocket := &models.Rocket{
-		ID:      channelID,
-		Type:    launchMsg.Type,
-		Speed:   launchMsg.LaunchSpeed,
-		Mission: launchMsg.Mission,
-		Status:  models.StatusActive,
+	launchTime := msg.Metadata.MessageTime
+	launch := func(rocket *models.Rocket) {
+		rocket.Type = launchMsg.Type
+		rocket.Speed = launchMsg.LaunchSpeed
+		rocket.Mission = launchMsg.Mission
+		rocket.Status = models.StatusActive
+		rocket.ExplosionReason = ""
+		rocket.CurrentStage = 1
+		rocket.LaunchTime = &launchTime
+		rocket.RecordSpeed()
+		updateRocketMetadata(rocket, msg)
 	}
-	updateRocketMetadata(rocket, msg)
 
-	log.Printf("MessageService: Rocket launched: %s (type=%s, speed=%d, mission=%s)",
-		channelID, rocket.Type, rocket.Speed, rocket.Mission)
+	slog.InfoContext(ctx, "Rocket launched", "type", launchMsg.Type, "speed", launchMsg.LaunchSpeed, "mission", launchMsg.Mission)
 
+	// A rocket launched again keeps what operators and earlier telemetry gave it: its name, labels, archival and stats
+	_, err = s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		launch(rocket)
+		return nil
+	})
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	rocket := &models.Rocket{ID: channelID}
+	launch(rocket)
 	return s.repo.Save(ctx, rocket)
 }
 
 func (s *messageService) handleRocketSpeedChanged(ctx context.Context, channelID string, msg *models.RocketMessage) error {
-	rocket, err := s.repo.FindByID(ctx, channelID)
+	speedMsg, err := parseMessage[models.RocketSpeedChangedMessage](msg)
 	if err != nil {
-		return fmt.Errorf("rocket not found: %s", channelID)
+		return err
 	}
 
-	speedMsg, err := parseMessage[models.RocketSpeedChangedMessage](msg)
+	rocket, err := s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		// Apply speed change based on message type
+		if msg.Metadata.MessageType == "RocketSpeedIncreased" {
+			rocket.Speed += speedMsg.By
+		} else {
+			rocket.Speed -= speedMsg.By
+		}
+		rocket.RecordSpeed()
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	// Apply speed change based on message type
-	if msg.Metadata.MessageType == "RocketSpeedIncreased" {
-		rocket.Speed += speedMsg.By
-	} else {
-		rocket.Speed -= speedMsg.By
+	slog.InfoContext(ctx, "Speed changed", "by", speedMsg.By, "speed", rocket.Speed)
+
+	return nil
+}
+
+func (s *messageService) handleRocketExploded(ctx context.Context, channelID string, msg *models.RocketMessage) error {
+	explodedMsg, err := parseMessage[models.RocketExplodedMessage](msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		if err := transition(rocket, models.StatusExploded); err != nil {
+			return err
+		}
+		rocket.ExplosionReason = explodedMsg.Reason
+		rocket.Speed = 0
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
+	if err != nil {
+		return err
 	}
-	updateRocketMetadata(rocket, msg)
 
-	log.Printf("MessageService: Speed changed: %s (type=%s, by=%d, new speed=%d)",
-		channelID, msg.Metadata.MessageType, speedMsg.By, rocket.Speed)
+	slog.InfoContext(ctx, "Rocket exploded", "reason", explodedMsg.Reason)
 
-	return s.repo.Save(ctx, rocket)
+	return nil
 }
 
-func (s *messageService) handleRocketExploded(ctx context.Context, channelID string, msg *models.RocketMessage) error {
-	rocket, err := s.repo.FindByID(ctx, channelID)
+func (s *messageService) handleRocketLanded(ctx context.Context, channelID string, msg *models.RocketMessage) error {
+	_, err := s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		if err := transition(rocket, models.StatusLanded); err != nil {
+			return err
+		}
+		rocket.Speed = 0
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("rocket not found: %s", channelID)
+		return err
 	}
 
-	explodedMsg, err := parseMessage[models.RocketExplodedMessage](msg)
+	slog.InfoContext(ctx, "Rocket landed")
+
+	return nil
+}
+
+func (s *messageService) handleRocketDecommissioned(ctx context.Context, channelID string, msg *models.RocketMessage) error {
+	_, err := s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		if err := transition(rocket, models.StatusDecommissioned); err != nil {
+			return err
+		}
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	rocket.Status = models.StatusExploded
-	rocket.ExplosionReason = explodedMsg.Reason
-	rocket.Speed = 0
-	updateRocketMetadata(rocket, msg)
+	slog.InfoContext(ctx, "Rocket decommissioned")
 
-	log.Printf("MessageService: Rocket exploded: %s (reason=%s)", channelID, explodedMsg.Reason)
+	return nil
+}
 
-	return s.repo.Save(ctx, rocket)
+// transition moves a rocket to the next status, rejecting moves the lifecycle doesn't allow
+func transition(rocket *models.Rocket, next models.RocketStatus) error {
+	if !rocket.Status.CanTransitionTo(next) {
+		return fmt.Errorf("rocket cannot go from %s to %s", rocket.Status, next)
+	}
+	rocket.Status = next
+	return nil
 }
 
 func (s *messageService) handleRocketMissionChanged(ctx context.Context, channelID string, msg *models.RocketMessage) error {
-	rocket, err := s.repo.FindByID(ctx, channelID)
+	missionMsg, err := parseMessage[models.RocketMissionChangedMessage](msg)
 	if err != nil {
-		return fmt.Errorf("rocket not found: %s", channelID)
+		return err
 	}
 
-	missionMsg, err := parseMessage[models.RocketMissionChangedMessage](msg)
+	_, err = s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		rocket.Mission = missionMsg.NewMission
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	rocket.Mission = missionMsg.NewMission
-	updateRocketMetadata(rocket, msg)
+	slog.InfoContext(ctx, "Mission changed", "mission", missionMsg.NewMission)
 
-	log.Printf("MessageService: Mission changed: %s (new mission=%s)", channelID, missionMsg.NewMission)
+	return nil
+}
 
-	return s.repo.Save(ctx, rocket)
+func (s *messageService) handleRocketFuelUpdated(ctx context.Context, channelID string, msg *models.RocketMessage) error {
+	fuelMsg, err := parseMessage[models.RocketFuelUpdatedMessage](msg)
+	if err != nil {
+		return err
+	}
+	if fuelMsg.FuelLevel == nil {
+		return fmt.Errorf("fuelLevel is required")
+	}
+
+	rocket, err := s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		level := *fuelMsg.FuelLevel
+		rocket.FuelLevel = &level
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if rocket.IsLowOnFuel() {
+		slog.WarnContext(ctx, "Rocket low on fuel", "fuelLevel", *rocket.FuelLevel)
+	} else {
+		slog.InfoContext(ctx, "Fuel updated", "fuelLevel", *rocket.FuelLevel)
+	}
+
+	return nil
+}
+
+func (s *messageService) handleRocketPositionUpdated(ctx context.Context, channelID string, msg *models.RocketMessage) error {
+	positionMsg, err := parseMessage[models.RocketPositionUpdatedMessage](msg)
+	if err != nil {
+		return err
+	}
+	if positionMsg.Latitude == nil || positionMsg.Longitude == nil || positionMsg.Altitude == nil {
+		return fmt.Errorf("latitude, longitude and altitude are required")
+	}
+
+	position := models.Position{
+		Latitude:  *positionMsg.Latitude,
+		Longitude: *positionMsg.Longitude,
+		Altitude:  *positionMsg.Altitude,
+	}
+
+	_, err = s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		rocket.Position = &position
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// The trail is best-effort, like the event history: the rocket state is already updated
+	point := models.TrackPoint{
+		Position:      position,
+		MessageNumber: msg.Metadata.MessageNumber,
+		Time:          msg.Metadata.MessageTime,
+	}
+	if err := s.tracks.Append(ctx, channelID, point); err != nil {
+		slog.ErrorContext(ctx, "Failed to record position", "error", err)
+	}
+
+	slog.InfoContext(ctx, "Position updated",
+		"latitude", position.Latitude, "longitude", position.Longitude, "altitude", position.Altitude)
+
+	return nil
+}
+
+func (s *messageService) handleRocketStageSeparated(ctx context.Context, channelID string, msg *models.RocketMessage) error {
+	stageMsg, err := parseMessage[models.RocketStageSeparatedMessage](msg)
+	if err != nil {
+		return err
+	}
+
+	rocket, err := s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		if stageMsg.Stage < rocket.CurrentStage {
+			return fmt.Errorf("stage %d already separated, rocket is on stage %d", stageMsg.Stage, rocket.CurrentStage)
+		}
+		rocket.CurrentStage = stageMsg.Stage + 1
+		rocket.Stages = append(rocket.Stages, models.StageSeparation{
+			Stage:         stageMsg.Stage,
+			MessageNumber: msg.Metadata.MessageNumber,
+			Time:          msg.Metadata.MessageTime,
+		})
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Stage separated", "stage", stageMsg.Stage, "currentStage", rocket.CurrentStage)
+
+	return nil
+}
+
+func (s *messageService) handleRocketPayloadDeployed(ctx context.Context, channelID string, msg *models.RocketMessage) error {
+	payloadMsg, err := parseMessage[models.RocketPayloadDeployedMessage](msg)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.update(ctx, channelID, msg, func(rocket *models.Rocket) error {
+		for _, payload := range rocket.Payloads {
+			if payload.Name == payloadMsg.Name {
+				return fmt.Errorf("payload %s already deployed", payloadMsg.Name)
+			}
+		}
+		rocket.Payloads = append(rocket.Payloads, models.Payload{
+			Name:          payloadMsg.Name,
+			MessageNumber: msg.Metadata.MessageNumber,
+			DeployedAt:    msg.Metadata.MessageTime,
+		})
+		updateRocketMetadata(rocket, msg)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "Payload deployed", "payload", payloadMsg.Name)
+
+	return nil
 }
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/errreport"
+	"github.com/ahernandez9/rockets/internal/metrics"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+	"github.com/ahernandez9/rockets/internal/validation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testChannel = "193270a9-c9cf-404a-8f83-838e71d9ae67"
+
+var launchedAt = time.Date(2022, 2, 2, 19, 39, 5, 0, time.UTC)
+
+// message builds a message of the test channel, number seconds after launchedAt
+func message(t *testing.T, number int64, messageType string, content any) *models.RocketMessage {
+	t.Helper()
+	encoded, err := json.Marshal(content)
+	require.NoError(t, err)
+	return &models.RocketMessage{
+		Metadata: models.MessageMetadata{
+			Channel:       testChannel,
+			MessageNumber: number,
+			MessageTime:   launchedAt.Add(time.Duration(number) * time.Second),
+			MessageType:   messageType,
+		},
+		Message: encoded,
+	}
+}
+
+func launched(t *testing.T, number int64) *models.RocketMessage {
+	return message(t, number, "RocketLaunched",
+		models.RocketLaunchedMessage{Type: "Falcon-9", LaunchSpeed: 500, Mission: "ARTEMIS"})
+}
+
+// newTestMessageService returns a message service over in-memory repositories, whose messages are applied by calling
+// processMessage
+func newTestMessageService() (*messageService, *inmemory.RocketRepository, *inmemory.EventRepository) {
+	rockets := inmemory.NewInMemoryRepository()
+	events := inmemory.NewInMemoryEventRepository(100)
+	s := NewMessageService(nil, rockets, events, inmemory.NewInMemoryTrackRepository(100), AnomalyDetector{},
+		metrics.Nop{}, errreport.Nop{})
+	return s.(*messageService), rockets, events
+}
+
+func TestProcessMessageTypes(t *testing.T) {
+	float := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		messageType string
+		before      []string // Types of the messages applied first, numbered from 1
+		content     any
+		check       func(t *testing.T, rocket *models.Rocket)
+	}{
+		{
+			messageType: "RocketLaunched",
+			content:     models.RocketLaunchedMessage{Type: "Falcon-9", LaunchSpeed: 500, Mission: "ARTEMIS"},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, "Falcon-9", rocket.Type)
+				assert.Equal(t, 500, rocket.Speed)
+				assert.Equal(t, "ARTEMIS", rocket.Mission)
+				assert.Equal(t, models.StatusActive, rocket.Status)
+				assert.Equal(t, 1, rocket.CurrentStage)
+				require.NotNil(t, rocket.LaunchTime)
+				assert.Equal(t, launchedAt.Add(time.Second), *rocket.LaunchTime)
+			},
+		},
+		{
+			messageType: "RocketSpeedIncreased",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketSpeedChangedMessage{By: 3000},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, 3500, rocket.Speed)
+				assert.Equal(t, 3500, rocket.MaxSpeed)
+			},
+		},
+		{
+			messageType: "RocketSpeedDecreased",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketSpeedChangedMessage{By: 200},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, 300, rocket.Speed)
+				assert.Equal(t, 500, rocket.MaxSpeed)
+			},
+		},
+		{
+			messageType: "RocketExploded",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketExplodedMessage{Reason: "PRESSURE_VESSEL_FAILURE"},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, models.StatusExploded, rocket.Status)
+				assert.Equal(t, "PRESSURE_VESSEL_FAILURE", rocket.ExplosionReason)
+				assert.Zero(t, rocket.Speed)
+			},
+		},
+		{
+			messageType: "RocketLanded",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketLandedMessage{},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, models.StatusLanded, rocket.Status)
+				assert.Zero(t, rocket.Speed)
+			},
+		},
+		{
+			messageType: "RocketDecommissioned",
+			before:      []string{"RocketLaunched", "RocketLanded"},
+			content:     models.RocketDecommissionedMessage{},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, models.StatusDecommissioned, rocket.Status)
+			},
+		},
+		{
+			messageType: "RocketMissionChanged",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketMissionChangedMessage{NewMission: "SHUTTLE_MIR"},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, "SHUTTLE_MIR", rocket.Mission)
+			},
+		},
+		{
+			messageType: "RocketFuelUpdated",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketFuelUpdatedMessage{FuelLevel: float(87.5)},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				require.NotNil(t, rocket.FuelLevel)
+				assert.Equal(t, 87.5, *rocket.FuelLevel)
+			},
+		},
+		{
+			messageType: "RocketPositionUpdated",
+			before:      []string{"RocketLaunched"},
+			content: models.RocketPositionUpdatedMessage{
+				Latitude: float(28.5721), Longitude: float(-80.648), Altitude: float(12500),
+			},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				require.NotNil(t, rocket.Position)
+				assert.Equal(t, models.Position{Latitude: 28.5721, Longitude: -80.648, Altitude: 12500}, *rocket.Position)
+			},
+		},
+		{
+			messageType: "RocketStageSeparated",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketStageSeparatedMessage{Stage: 1},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				assert.Equal(t, 2, rocket.CurrentStage)
+				require.Len(t, rocket.Stages, 1)
+				assert.Equal(t, 1, rocket.Stages[0].Stage)
+			},
+		},
+		{
+			messageType: "RocketPayloadDeployed",
+			before:      []string{"RocketLaunched"},
+			content:     models.RocketPayloadDeployedMessage{Name: "STARLINK-1234"},
+			check: func(t *testing.T, rocket *models.Rocket) {
+				require.Len(t, rocket.Payloads, 1)
+				assert.Equal(t, "STARLINK-1234", rocket.Payloads[0].Name)
+			},
+		},
+	}
+
+	contents := map[string]any{}
+	for _, tt := range tests {
+		contents[tt.messageType] = tt.content
+	}
+	for _, messageType := range validation.MessageTypes() {
+		assert.Contains(t, contents, messageType, "every known message type is tested")
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.messageType, func(t *testing.T) {
+			s, rockets, events := newTestMessageService()
+			ctx := context.Background()
+
+			for i, messageType := range tt.before {
+				require.NoError(t, s.processMessage(ctx, message(t, int64(i+1), messageType, contents[messageType])))
+			}
+			number := int64(len(tt.before) + 1)
+			require.NoError(t, s.processMessage(ctx, message(t, number, tt.messageType, tt.content)))
+
+			rocket, err := rockets.FindByID(ctx, testChannel)
+			require.NoError(t, err)
+			tt.check(t, rocket)
+			assert.Equal(t, number, rocket.LastMessageNumber)
+			assert.Equal(t, launchedAt.Add(time.Duration(number)*time.Second), rocket.LastUpdated)
+
+			history := events.FindByChannel(ctx, testChannel)
+			require.Len(t, history, int(number))
+			assert.Equal(t, tt.messageType, history[len(history)-1].Type)
+		})
+	}
+}
+
+func TestProcessMessageOrdering(t *testing.T) {
+	speedUp := func(number int64) *models.RocketMessage {
+		return message(t, number, "RocketSpeedIncreased", models.RocketSpeedChangedMessage{By: 100})
+	}
+
+	tests := []struct {
+		name              string
+		messages          []*models.RocketMessage
+		expectedSpeed     int
+		expectedLast      int64
+		expectedDropped   models.DropCounts
+		expectedMissing   int64
+		expectedEvents    int
+		expectedErrorLast bool
+	}{
+		{
+			name:           "messages in order",
+			messages:       []*models.RocketMessage{launched(t, 1), speedUp(2), speedUp(3)},
+			expectedSpeed:  700,
+			expectedLast:   3,
+			expectedEvents: 3,
+		},
+		{
+			name:            "duplicate ignored",
+			messages:        []*models.RocketMessage{launched(t, 1), speedUp(2), speedUp(2)},
+			expectedSpeed:   600,
+			expectedLast:    2,
+			expectedDropped: models.DropCounts{Duplicate: 1},
+			expectedEvents:  2,
+		},
+		{
+			name:            "older message ignored once a later one is applied",
+			messages:        []*models.RocketMessage{launched(t, 1), speedUp(3), speedUp(2)},
+			expectedSpeed:   600,
+			expectedLast:    3,
+			expectedDropped: models.DropCounts{OutOfOrder: 1},
+			expectedMissing: 1,
+			expectedEvents:  2,
+		},
+		{
+			name:              "unknown type rejected",
+			messages:          []*models.RocketMessage{launched(t, 1), message(t, 2, "RocketRefueled", struct{}{})},
+			expectedSpeed:     500,
+			expectedLast:      1,
+			expectedDropped:   models.DropCounts{UnknownType: 1},
+			expectedEvents:    1,
+			expectedErrorLast: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, rockets, events := newTestMessageService()
+			ctx := context.Background()
+
+			for i, msg := range tt.messages {
+				err := s.processMessage(ctx, msg)
+				if tt.expectedErrorLast && i == len(tt.messages)-1 {
+					assert.Error(t, err)
+					continue
+				}
+				require.NoError(t, err)
+			}
+
+			rocket, err := rockets.FindByID(ctx, testChannel)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSpeed, rocket.Speed)
+			assert.Equal(t, tt.expectedLast, rocket.LastMessageNumber)
+			assert.Len(t, events.FindByChannel(ctx, testChannel), tt.expectedEvents)
+
+			stats, ok := s.ChannelStats(testChannel)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedDropped, stats.Dropped)
+			assert.Equal(t, tt.expectedLast, stats.LastMessageNumber)
+			assert.Equal(t, tt.expectedMissing, stats.MissingMessages)
+		})
+	}
+}
+
+func TestProcessMessageRelaunch(t *testing.T) {
+	s, rockets, _ := newTestMessageService()
+	ctx := context.Background()
+
+	require.NoError(t, s.processMessage(ctx, launched(t, 1)))
+	require.NoError(t, s.processMessage(ctx,
+		message(t, 2, "RocketSpeedIncreased", models.RocketSpeedChangedMessage{By: 3000})))
+	require.NoError(t, s.processMessage(ctx,
+		message(t, 3, "RocketExploded", models.RocketExplodedMessage{Reason: "PRESSURE_VESSEL_FAILURE"})))
+	archivedAt := time.Date(2022, 3, 1, 10, 0, 0, 0, time.UTC)
+	_, err := rockets.Update(ctx, testChannel, func(rocket *models.Rocket) error {
+		rocket.Name = "Odyssey"
+		rocket.Labels = models.Labels{"team": "blue"}
+		rocket.ArchivedAt = &archivedAt
+		return nil
+	})
+	require.NoError(t, err)
+	before, err := rockets.FindByID(ctx, testChannel)
+	require.NoError(t, err)
+
+	require.NoError(t, s.processMessage(ctx, message(t, 4, "RocketLaunched",
+		models.RocketLaunchedMessage{Type: "Falcon-Heavy", LaunchSpeed: 800, Mission: "APOLLO"})))
+
+	rocket, err := rockets.FindByID(ctx, testChannel)
+	require.NoError(t, err)
+	assert.Equal(t, "Falcon-Heavy", rocket.Type)
+	assert.Equal(t, "APOLLO", rocket.Mission)
+	assert.Equal(t, 800, rocket.Speed)
+	assert.Equal(t, models.StatusActive, rocket.Status)
+	assert.Empty(t, rocket.ExplosionReason)
+	assert.Equal(t, int64(4), rocket.LastMessageNumber)
+
+	assert.Equal(t, "Odyssey", rocket.Name, "operators' changes are kept")
+	assert.Equal(t, models.Labels{"team": "blue"}, rocket.Labels)
+	require.NotNil(t, rocket.ArchivedAt)
+	assert.Equal(t, archivedAt, *rocket.ArchivedAt)
+	assert.Equal(t, 3500, rocket.MaxSpeed, "stats of earlier flights are kept")
+	assert.Equal(t, before.SpeedSamples+1, rocket.SpeedSamples)
+	assert.NotZero(t, rocket.EstimatedDistance)
+	assert.Equal(t, before.EstimatedDistance, rocket.EstimatedDistance, "the exploded rocket stood still until relaunched")
+}
@@ -0,0 +1,72 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: backup.go
+//
+// Generated by this command:
+//
+//	mockgen -source=backup.go -destination=mocks/mock_backup_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ahernandez9/rockets/internal/models"
+	service "github.com/ahernandez9/rockets/internal/service"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackupService is a mock of BackupService interface.
+type MockBackupService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupServiceMockRecorder is the mock recorder for MockBackupService.
+type MockBackupServiceMockRecorder struct {
+	mock *MockBackupService
+}
+
+// NewMockBackupService creates a new mock instance.
+func NewMockBackupService(ctrl *gomock.Controller) *MockBackupService {
+	mock := &MockBackupService{ctrl: ctrl}
+	mock.recorder = &MockBackupServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupService) EXPECT() *MockBackupServiceMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method.
+func (m *MockBackupService) Export(ctx context.Context, opts service.ExportOptions, emit func(*models.BackupRecord) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, opts, emit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockBackupServiceMockRecorder) Export(ctx, opts, emit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockBackupService)(nil).Export), ctx, opts, emit)
+}
+
+// Import mocks base method.
+func (m *MockBackupService) Import(ctx context.Context, record *models.BackupRecord, opts service.ImportOptions) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Import", ctx, record, opts)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Import indicates an expected call of Import.
+func (mr *MockBackupServiceMockRecorder) Import(ctx, record, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Import", reflect.TypeOf((*MockBackupService)(nil).Import), ctx, record, opts)
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: fleet.go
+//
+// Generated by this command:
+//
+//	mockgen -source=fleet.go -destination=mocks/mock_fleet_service.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	models "github.com/ahernandez9/rockets/internal/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFleetService is a mock of FleetService interface.
+type MockFleetService struct {
+	ctrl     *gomock.Controller
+	recorder *MockFleetServiceMockRecorder
+	isgomock struct{}
+}
+
+// MockFleetServiceMockRecorder is the mock recorder for MockFleetService.
+type MockFleetServiceMockRecorder struct {
+	mock *MockFleetService
+}
+
+// NewMockFleetService creates a new mock instance.
+func NewMockFleetService(ctrl *gomock.Controller) *MockFleetService {
+	mock := &MockFleetService{ctrl: ctrl}
+	mock.recorder = &MockFleetServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFleetService) EXPECT() *MockFleetServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateFleet mocks base method.
+func (m *MockFleetService) CreateFleet(ctx context.Context, req models.FleetRequest) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFleet", ctx, req)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFleet indicates an expected call of CreateFleet.
+func (mr *MockFleetServiceMockRecorder) CreateFleet(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFleet", reflect.TypeOf((*MockFleetService)(nil).CreateFleet), ctx, req)
+}
+
+// DeleteFleet mocks base method.
+func (m *MockFleetService) DeleteFleet(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFleet", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFleet indicates an expected call of DeleteFleet.
+func (mr *MockFleetServiceMockRecorder) DeleteFleet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFleet", reflect.TypeOf((*MockFleetService)(nil).DeleteFleet), ctx, id)
+}
+
+// GetFleet mocks base method.
+func (m *MockFleetService) GetFleet(ctx context.Context, id string) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFleet", ctx, id)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFleet indicates an expected call of GetFleet.
+func (mr *MockFleetServiceMockRecorder) GetFleet(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFleet", reflect.TypeOf((*MockFleetService)(nil).GetFleet), ctx, id)
+}
+
+// ListFleetRockets mocks base method.
+func (m *MockFleetService) ListFleetRockets(ctx context.Context, id string, sortFields []models.SortField) ([]*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFleetRockets", ctx, id, sortFields)
+	ret0, _ := ret[0].([]*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFleetRockets indicates an expected call of ListFleetRockets.
+func (mr *MockFleetServiceMockRecorder) ListFleetRockets(ctx, id, sortFields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFleetRockets", reflect.TypeOf((*MockFleetService)(nil).ListFleetRockets), ctx, id, sortFields)
+}
+
+// ListFleets mocks base method.
+func (m *MockFleetService) ListFleets(ctx context.Context) ([]*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFleets", ctx)
+	ret0, _ := ret[0].([]*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFleets indicates an expected call of ListFleets.
+func (mr *MockFleetServiceMockRecorder) ListFleets(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFleets", reflect.TypeOf((*MockFleetService)(nil).ListFleets), ctx)
+}
+
+// RemoveMember mocks base method.
+func (m *MockFleetService) RemoveMember(ctx context.Context, rocketID string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMember", ctx, rocketID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMember indicates an expected call of RemoveMember.
+func (mr *MockFleetServiceMockRecorder) RemoveMember(ctx, rocketID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMember", reflect.TypeOf((*MockFleetService)(nil).RemoveMember), ctx, rocketID)
+}
+
+// UpdateFleet mocks base method.
+func (m *MockFleetService) UpdateFleet(ctx context.Context, id string, req models.FleetRequest) (*models.Fleet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFleet", ctx, id, req)
+	ret0, _ := ret[0].(*models.Fleet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFleet indicates an expected call of UpdateFleet.
+func (mr *MockFleetServiceMockRecorder) UpdateFleet(ctx, id, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFleet", reflect.TypeOf((*MockFleetService)(nil).UpdateFleet), ctx, id, req)
+}
@@ -10,9 +10,11 @@
 package mocks
 
 import (
+	context "context"
 	reflect "reflect"
 
 	models "github.com/ahernandez9/rockets/internal/models"
+	service "github.com/ahernandez9/rockets/internal/service"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -40,18 +42,126 @@ func (m *MockMessageService) EXPECT() *MockMessageServiceMockRecorder {
 	return m.recorder
 }
 
+// ChannelStats mocks base method.
+func (m *MockMessageService) ChannelStats(channel string) (models.ChannelStats, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChannelStats", channel)
+	ret0, _ := ret[0].(models.ChannelStats)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// ChannelStats indicates an expected call of ChannelStats.
+func (mr *MockMessageServiceMockRecorder) ChannelStats(channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChannelStats", reflect.TypeOf((*MockMessageService)(nil).ChannelStats), channel)
+}
+
+// ForgetChannel mocks base method.
+func (m *MockMessageService) ForgetChannel(channel string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ForgetChannel", channel)
+}
+
+// ForgetChannel indicates an expected call of ForgetChannel.
+func (mr *MockMessageServiceMockRecorder) ForgetChannel(channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgetChannel", reflect.TypeOf((*MockMessageService)(nil).ForgetChannel), channel)
+}
+
+// IngestionStats mocks base method.
+func (m *MockMessageService) IngestionStats() models.IngestionStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IngestionStats")
+	ret0, _ := ret[0].(models.IngestionStats)
+	return ret0
+}
+
+// IngestionStats indicates an expected call of IngestionStats.
+func (mr *MockMessageServiceMockRecorder) IngestionStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestionStats", reflect.TypeOf((*MockMessageService)(nil).IngestionStats))
+}
+
+// ProcessingStats mocks base method.
+func (m *MockMessageService) ProcessingStats() models.ProcessingStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessingStats")
+	ret0, _ := ret[0].(models.ProcessingStats)
+	return ret0
+}
+
+// ProcessingStats indicates an expected call of ProcessingStats.
+func (mr *MockMessageServiceMockRecorder) ProcessingStats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessingStats", reflect.TypeOf((*MockMessageService)(nil).ProcessingStats))
+}
+
 // PublishMessage mocks base method.
-func (m *MockMessageService) PublishMessage(msg *models.RocketMessage) error {
+func (m *MockMessageService) PublishMessage(ctx context.Context, msg *models.RocketMessage) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "PublishMessage", msg)
+	ret := m.ctrl.Call(m, "PublishMessage", ctx, msg)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // PublishMessage indicates an expected call of PublishMessage.
-func (mr *MockMessageServiceMockRecorder) PublishMessage(msg any) *gomock.Call {
+func (mr *MockMessageServiceMockRecorder) PublishMessage(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockMessageService)(nil).PublishMessage), ctx, msg)
+}
+
+// PublishMessageAndWait mocks base method.
+func (m *MockMessageService) PublishMessageAndWait(ctx context.Context, msg *models.RocketMessage) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishMessageAndWait", ctx, msg)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishMessageAndWait indicates an expected call of PublishMessageAndWait.
+func (mr *MockMessageServiceMockRecorder) PublishMessageAndWait(ctx, msg any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessageAndWait", reflect.TypeOf((*MockMessageService)(nil).PublishMessageAndWait), ctx, msg)
+}
+
+// RecordRejected mocks base method.
+func (m *MockMessageService) RecordRejected(msg *models.RocketMessage, err error) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RecordRejected", msg, err)
+}
+
+// RecordRejected indicates an expected call of RecordRejected.
+func (mr *MockMessageServiceMockRecorder) RecordRejected(msg, err any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordRejected", reflect.TypeOf((*MockMessageService)(nil).RecordRejected), msg, err)
+}
+
+// Running mocks base method.
+func (m *MockMessageService) Running() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Running")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Running indicates an expected call of Running.
+func (mr *MockMessageServiceMockRecorder) Running() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Running", reflect.TypeOf((*MockMessageService)(nil).Running))
+}
+
+// SetAnomalyDetector mocks base method.
+func (m *MockMessageService) SetAnomalyDetector(d service.AnomalyDetector) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetAnomalyDetector", d)
+}
+
+// SetAnomalyDetector indicates an expected call of SetAnomalyDetector.
+func (mr *MockMessageServiceMockRecorder) SetAnomalyDetector(d any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishMessage", reflect.TypeOf((*MockMessageService)(nil).PublishMessage), msg)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAnomalyDetector", reflect.TypeOf((*MockMessageService)(nil).SetAnomalyDetector), d)
 }
 
 // Start mocks base method.
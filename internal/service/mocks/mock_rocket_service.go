@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	models "github.com/ahernandez9/rockets/internal/models"
 	gomock "go.uber.org/mock/gomock"
@@ -41,6 +42,65 @@ func (m *MockRocketService) EXPECT() *MockRocketServiceMockRecorder {
 	return m.recorder
 }
 
+// ArchiveRocket mocks base method.
+func (m *MockRocketService) ArchiveRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveRocket", ctx, id)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveRocket indicates an expected call of ArchiveRocket.
+func (mr *MockRocketServiceMockRecorder) ArchiveRocket(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveRocket", reflect.TypeOf((*MockRocketService)(nil).ArchiveRocket), ctx, id)
+}
+
+// CorrectRocket mocks base method.
+func (m *MockRocketService) CorrectRocket(ctx context.Context, id string, correction models.RocketCorrection, actor string) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CorrectRocket", ctx, id, correction, actor)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CorrectRocket indicates an expected call of CorrectRocket.
+func (mr *MockRocketServiceMockRecorder) CorrectRocket(ctx, id, correction, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CorrectRocket", reflect.TypeOf((*MockRocketService)(nil).CorrectRocket), ctx, id, correction, actor)
+}
+
+// DeleteRocket mocks base method.
+func (m *MockRocketService) DeleteRocket(ctx context.Context, id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRocket", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRocket indicates an expected call of DeleteRocket.
+func (mr *MockRocketServiceMockRecorder) DeleteRocket(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRocket", reflect.TypeOf((*MockRocketService)(nil).DeleteRocket), ctx, id)
+}
+
+// EraseChannel mocks base method.
+func (m *MockRocketService) EraseChannel(ctx context.Context, id string) (*models.ErasureReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EraseChannel", ctx, id)
+	ret0, _ := ret[0].(*models.ErasureReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EraseChannel indicates an expected call of EraseChannel.
+func (mr *MockRocketServiceMockRecorder) EraseChannel(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EraseChannel", reflect.TypeOf((*MockRocketService)(nil).EraseChannel), ctx, id)
+}
+
 // GetCount mocks base method.
 func (m *MockRocketService) GetCount(ctx context.Context) int {
 	m.ctrl.T.Helper()
@@ -55,6 +115,21 @@ func (mr *MockRocketServiceMockRecorder) GetCount(ctx any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCount", reflect.TypeOf((*MockRocketService)(nil).GetCount), ctx)
 }
 
+// GetMission mocks base method.
+func (m *MockRocketService) GetMission(ctx context.Context, name string) (*models.Mission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMission", ctx, name)
+	ret0, _ := ret[0].(*models.Mission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMission indicates an expected call of GetMission.
+func (mr *MockRocketServiceMockRecorder) GetMission(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMission", reflect.TypeOf((*MockRocketService)(nil).GetMission), ctx, name)
+}
+
 // GetRocket mocks base method.
 func (m *MockRocketService) GetRocket(ctx context.Context, id string) (*models.Rocket, error) {
 	m.ctrl.T.Helper()
@@ -70,19 +145,243 @@ func (mr *MockRocketServiceMockRecorder) GetRocket(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRocket", reflect.TypeOf((*MockRocketService)(nil).GetRocket), ctx, id)
 }
 
+// GetRocketByName mocks base method.
+func (m *MockRocketService) GetRocketByName(ctx context.Context, name string) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRocketByName", ctx, name)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRocketByName indicates an expected call of GetRocketByName.
+func (mr *MockRocketServiceMockRecorder) GetRocketByName(ctx, name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRocketByName", reflect.TypeOf((*MockRocketService)(nil).GetRocketByName), ctx, name)
+}
+
+// GetRocketEvents mocks base method.
+func (m *MockRocketService) GetRocketEvents(ctx context.Context, id string) ([]*models.RocketEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRocketEvents", ctx, id)
+	ret0, _ := ret[0].([]*models.RocketEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRocketEvents indicates an expected call of GetRocketEvents.
+func (mr *MockRocketServiceMockRecorder) GetRocketEvents(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRocketEvents", reflect.TypeOf((*MockRocketService)(nil).GetRocketEvents), ctx, id)
+}
+
+// GetRocketTrack mocks base method.
+func (m *MockRocketService) GetRocketTrack(ctx context.Context, id string) ([]models.TrackPoint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRocketTrack", ctx, id)
+	ret0, _ := ret[0].([]models.TrackPoint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRocketTrack indicates an expected call of GetRocketTrack.
+func (mr *MockRocketServiceMockRecorder) GetRocketTrack(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRocketTrack", reflect.TypeOf((*MockRocketService)(nil).GetRocketTrack), ctx, id)
+}
+
+// GetRockets mocks base method.
+func (m *MockRocketService) GetRockets(ctx context.Context, ids []string) map[string]*models.Rocket {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRockets", ctx, ids)
+	ret0, _ := ret[0].(map[string]*models.Rocket)
+	return ret0
+}
+
+// GetRockets indicates an expected call of GetRockets.
+func (mr *MockRocketServiceMockRecorder) GetRockets(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRockets", reflect.TypeOf((*MockRocketService)(nil).GetRockets), ctx, ids)
+}
+
+// LastModified mocks base method.
+func (m *MockRocketService) LastModified(ctx context.Context) time.Time {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastModified", ctx)
+	ret0, _ := ret[0].(time.Time)
+	return ret0
+}
+
+// LastModified indicates an expected call of LastModified.
+func (mr *MockRocketServiceMockRecorder) LastModified(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastModified", reflect.TypeOf((*MockRocketService)(nil).LastModified), ctx)
+}
+
+// ListMissionRockets mocks base method.
+func (m *MockRocketService) ListMissionRockets(ctx context.Context, name string, sortFields []models.SortField) ([]*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMissionRockets", ctx, name, sortFields)
+	ret0, _ := ret[0].([]*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMissionRockets indicates an expected call of ListMissionRockets.
+func (mr *MockRocketServiceMockRecorder) ListMissionRockets(ctx, name, sortFields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMissionRockets", reflect.TypeOf((*MockRocketService)(nil).ListMissionRockets), ctx, name, sortFields)
+}
+
+// ListMissions mocks base method.
+func (m *MockRocketService) ListMissions(ctx context.Context) ([]*models.Mission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListMissions", ctx)
+	ret0, _ := ret[0].([]*models.Mission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListMissions indicates an expected call of ListMissions.
+func (mr *MockRocketServiceMockRecorder) ListMissions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMissions", reflect.TypeOf((*MockRocketService)(nil).ListMissions), ctx)
+}
+
+// ListRocketTypes mocks base method.
+func (m *MockRocketService) ListRocketTypes(ctx context.Context) ([]*models.RocketType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRocketTypes", ctx)
+	ret0, _ := ret[0].([]*models.RocketType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRocketTypes indicates an expected call of ListRocketTypes.
+func (mr *MockRocketServiceMockRecorder) ListRocketTypes(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRocketTypes", reflect.TypeOf((*MockRocketService)(nil).ListRocketTypes), ctx)
+}
+
 // ListRockets mocks base method.
-func (m *MockRocketService) ListRockets(ctx context.Context, sortBy string) ([]*models.Rocket, error) {
+func (m *MockRocketService) ListRockets(ctx context.Context, filter models.RocketFilter, sortFields []models.SortField) ([]*models.Rocket, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListRockets", ctx, sortBy)
+	ret := m.ctrl.Call(m, "ListRockets", ctx, filter, sortFields)
 	ret0, _ := ret[0].([]*models.Rocket)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // ListRockets indicates an expected call of ListRockets.
-func (mr *MockRocketServiceMockRecorder) ListRockets(ctx, sortBy any) *gomock.Call {
+func (mr *MockRocketServiceMockRecorder) ListRockets(ctx, filter, sortFields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRockets", reflect.TypeOf((*MockRocketService)(nil).ListRockets), ctx, filter, sortFields)
+}
+
+// PurgeRocket mocks base method.
+func (m *MockRocketService) PurgeRocket(ctx context.Context, id string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRocket", ctx, id)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRocket indicates an expected call of PurgeRocket.
+func (mr *MockRocketServiceMockRecorder) PurgeRocket(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRocket", reflect.TypeOf((*MockRocketService)(nil).PurgeRocket), ctx, id)
+}
+
+// RenameRocket mocks base method.
+func (m *MockRocketService) RenameRocket(ctx context.Context, id, name, actor string) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameRocket", ctx, id, name, actor)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RenameRocket indicates an expected call of RenameRocket.
+func (mr *MockRocketServiceMockRecorder) RenameRocket(ctx, id, name, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameRocket", reflect.TypeOf((*MockRocketService)(nil).RenameRocket), ctx, id, name, actor)
+}
+
+// Reset mocks base method.
+func (m *MockRocketService) Reset(ctx context.Context) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockRocketServiceMockRecorder) Reset(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockRocketService)(nil).Reset), ctx)
+}
+
+// SetRocketLabels mocks base method.
+func (m *MockRocketService) SetRocketLabels(ctx context.Context, id string, labels models.Labels, actor string) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRocketLabels", ctx, id, labels, actor)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRocketLabels indicates an expected call of SetRocketLabels.
+func (mr *MockRocketServiceMockRecorder) SetRocketLabels(ctx, id, labels, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRocketLabels", reflect.TypeOf((*MockRocketService)(nil).SetRocketLabels), ctx, id, labels, actor)
+}
+
+// Summarize mocks base method.
+func (m *MockRocketService) Summarize(ctx context.Context, groupBy string) ([]*models.GroupSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Summarize", ctx, groupBy)
+	ret0, _ := ret[0].([]*models.GroupSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Summarize indicates an expected call of Summarize.
+func (mr *MockRocketServiceMockRecorder) Summarize(ctx, groupBy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Summarize", reflect.TypeOf((*MockRocketService)(nil).Summarize), ctx, groupBy)
+}
+
+// TopRockets mocks base method.
+func (m *MockRocketService) TopRockets(ctx context.Context, by string, limit int) ([]*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopRockets", ctx, by, limit)
+	ret0, _ := ret[0].([]*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopRockets indicates an expected call of TopRockets.
+func (mr *MockRocketServiceMockRecorder) TopRockets(ctx, by, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopRockets", reflect.TypeOf((*MockRocketService)(nil).TopRockets), ctx, by, limit)
+}
+
+// UnarchiveRocket mocks base method.
+func (m *MockRocketService) UnarchiveRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveRocket", ctx, id)
+	ret0, _ := ret[0].(*models.Rocket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnarchiveRocket indicates an expected call of UnarchiveRocket.
+func (mr *MockRocketServiceMockRecorder) UnarchiveRocket(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRockets", reflect.TypeOf((*MockRocketService)(nil).ListRockets), ctx, sortBy)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveRocket", reflect.TypeOf((*MockRocketService)(nil).UnarchiveRocket), ctx, id)
 }
 
 // UpdateRocket mocks base method.
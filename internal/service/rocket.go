@@ -1,8 +1,15 @@
 package service
 
 import (
+	"cmp"
 	"context"
-	"sort"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
 
 	"github.com/ahernandez9/rockets/internal/models"
 	"github.com/ahernandez9/rockets/internal/repository"
@@ -13,20 +20,54 @@ import (
 // RocketService defines the methods for rocket service (mostly to ease mocking in tests)
 type RocketService interface {
 	GetRocket(ctx context.Context, id string) (*models.Rocket, error)
-	ListRockets(ctx context.Context, sortBy string) ([]*models.Rocket, error)
+	GetRockets(ctx context.Context, ids []string) map[string]*models.Rocket
+	GetRocketByName(ctx context.Context, name string) (*models.Rocket, error)
+	ListRockets(ctx context.Context, filter models.RocketFilter, sortFields []models.SortField) ([]*models.Rocket, error)
 	UpdateRocket(ctx context.Context, rocket *models.Rocket) error
 	GetCount(ctx context.Context) int
+	LastModified(ctx context.Context) time.Time
+	DeleteRocket(ctx context.Context, id string) error
+	PurgeRocket(ctx context.Context, id string) (int, error)
+	EraseChannel(ctx context.Context, id string) (*models.ErasureReport, error)
+	Reset(ctx context.Context) (int, int, error)
+	ArchiveRocket(ctx context.Context, id string) (*models.Rocket, error)
+	UnarchiveRocket(ctx context.Context, id string) (*models.Rocket, error)
+	CorrectRocket(ctx context.Context, id string, correction models.RocketCorrection, actor string) (*models.Rocket, error)
+	SetRocketLabels(ctx context.Context, id string, labels models.Labels, actor string) (*models.Rocket, error)
+	RenameRocket(ctx context.Context, id, name, actor string) (*models.Rocket, error)
+	GetRocketEvents(ctx context.Context, id string) ([]*models.RocketEvent, error)
+	GetRocketTrack(ctx context.Context, id string) ([]models.TrackPoint, error)
+	ListMissions(ctx context.Context) ([]*models.Mission, error)
+	GetMission(ctx context.Context, name string) (*models.Mission, error)
+	ListMissionRockets(ctx context.Context, name string, sortFields []models.SortField) ([]*models.Rocket, error)
+	ListRocketTypes(ctx context.Context) ([]*models.RocketType, error)
+	Summarize(ctx context.Context, groupBy string) ([]*models.GroupSummary, error)
+	TopRockets(ctx context.Context, by string, limit int) ([]*models.Rocket, error)
 }
 
 // RocketService handles rocket business logic and repository operations
 type rocketService struct {
-	repo repository.RocketRepository
+	repo     repository.RocketRepository
+	events   repository.EventRepository
+	tracks   repository.TrackRepository
+	missions repository.MissionRepository
+	types    repository.RocketTypeRepository
 }
 
 // NewRocketService creates a new rocket service
-func NewRocketService(repo repository.RocketRepository) RocketService {
+func NewRocketService(
+	repo repository.RocketRepository,
+	events repository.EventRepository,
+	tracks repository.TrackRepository,
+	missions repository.MissionRepository,
+	types repository.RocketTypeRepository,
+) RocketService {
 	return &rocketService{
-		repo: repo,
+		repo:     repo,
+		events:   events,
+		tracks:   tracks,
+		missions: missions,
+		types:    types,
 	}
 }
 
@@ -35,27 +76,45 @@ func (s *rocketService) GetRocket(ctx context.Context, id string) (*models.Rocke
 	return s.repo.FindByID(ctx, id)
 }
 
-// ListRockets retrieves all rockets with optional sorting
-func (s *rocketService) ListRockets(ctx context.Context, sortBy string) ([]*models.Rocket, error) {
-	rockets := s.repo.FindAll(ctx)
+// GetRocketByName retrieves a rocket by its display name, ignoring case
+func (s *rocketService) GetRocketByName(ctx context.Context, name string) (*models.Rocket, error) {
+	return s.repo.FindByName(ctx, name)
+}
 
-	switch sortBy {
-	case "type":
-		sort.Slice(rockets, func(i, j int) bool {
-			return rockets[i].Type < rockets[j].Type
-		})
-	case "speed":
-		sort.Slice(rockets, func(i, j int) bool {
-			return rockets[i].Speed > rockets[j].Speed
-		})
-	case "mission":
-		sort.Slice(rockets, func(i, j int) bool {
-			return rockets[i].Mission < rockets[j].Mission
-		})
-	case "status":
-		sort.Slice(rockets, func(i, j int) bool {
-			return rockets[i].Status < rockets[j].Status
-		})
+// GetRockets retrieves several rockets by ID, keyed by ID. IDs with no rocket are left out of the result
+func (s *rocketService) GetRockets(ctx context.Context, ids []string) map[string]*models.Rocket {
+	rockets := make(map[string]*models.Rocket, len(ids))
+	for _, id := range ids {
+		if rocket, err := s.repo.FindByID(ctx, id); err == nil {
+			rockets[id] = rocket
+		}
+	}
+	return rockets
+}
+
+// ListRockets retrieves all rockets matching the filter, ordered by the given sort keys
+func (s *rocketService) ListRockets(
+	ctx context.Context,
+	filter models.RocketFilter,
+	sortFields []models.SortField,
+) ([]*models.Rocket, error) {
+	rockets := s.repo.FindAll(ctx, filter)
+	sortRockets(rockets, sortFields)
+
+	return rockets, nil
+}
+
+// TopRockets returns up to limit active rockets with the highest value of the by field
+func (s *rocketService) TopRockets(ctx context.Context, by string, limit int) ([]*models.Rocket, error) {
+	if !slices.Contains(rankableFields, by) {
+		return nil, fmt.Errorf("unknown ranking field: %s", by)
+	}
+
+	rockets := s.repo.FindAll(ctx, models.RocketFilter{Status: string(models.StatusActive)})
+	sortRockets(rockets, []models.SortField{{Field: by, Descending: true}})
+
+	if len(rockets) > limit {
+		rockets = rockets[:limit]
 	}
 
 	return rockets, nil
@@ -70,3 +129,267 @@ func (s *rocketService) UpdateRocket(ctx context.Context, rocket *models.Rocket)
 func (s *rocketService) GetCount(ctx context.Context) int {
 	return s.repo.GetCount(ctx)
 }
+
+// LastModified returns the server time of the latest change to any rocket, deletions and purges included
+func (s *rocketService) LastModified(ctx context.Context) time.Time {
+	return s.repo.LastModified(ctx)
+}
+
+// DeleteRocket removes a rocket
+func (s *rocketService) DeleteRocket(ctx context.Context, id string) error {
+	return s.repo.Delete(ctx, id)
+}
+
+// PurgeRocket removes a rocket together with its recorded history and position trail
+// and returns how many events were removed
+func (s *rocketService) PurgeRocket(ctx context.Context, id string) (int, error) {
+	if err := s.repo.Delete(ctx, id); err != nil {
+		return 0, err
+	}
+	s.tracks.DeleteByChannel(ctx, id)
+	return s.events.DeleteByChannel(ctx, id), nil
+}
+
+// EraseChannel removes everything stored about a channel: its rocket, history and position trail.
+// Unlike PurgeRocket it succeeds when the rocket is already gone, e.g. deleted while keeping its history
+func (s *rocketService) EraseChannel(ctx context.Context, id string) (*models.ErasureReport, error) {
+	report := &models.ErasureReport{Channel: id}
+
+	err := s.repo.Delete(ctx, id)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, err
+	}
+	report.RocketDeleted = err == nil
+
+	report.EventsDeleted = s.events.DeleteByChannel(ctx, id)
+	report.TrackPointsDeleted = s.tracks.DeleteByChannel(ctx, id)
+	report.ErasedAt = time.Now().UTC()
+
+	return report, nil
+}
+
+// Reset removes every rocket, archived ones included, the whole event history and every position trail.
+// It returns how many rockets and events were removed
+func (s *rocketService) Reset(ctx context.Context) (int, int, error) {
+	rockets := 0
+	for _, rocket := range s.repo.FindAll(ctx, models.RocketFilter{IncludeArchived: true}) {
+		// Deleting one by one lets live subscribers see every removal
+		if err := s.repo.Delete(ctx, rocket.ID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				continue
+			}
+			return rockets, 0, err
+		}
+		rockets++
+	}
+
+	s.tracks.DeleteAll(ctx)
+	return rockets, s.events.DeleteAll(ctx), nil
+}
+
+// ArchiveRocket hides a rocket from default listings without deleting it. Archiving twice keeps the original timestamp
+func (s *rocketService) ArchiveRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	return s.repo.Update(ctx, id, func(rocket *models.Rocket) error {
+		if rocket.ArchivedAt == nil {
+			now := time.Now().UTC()
+			rocket.ArchivedAt = &now
+		}
+		return nil
+	})
+}
+
+// UnarchiveRocket makes an archived rocket visible in default listings again
+func (s *rocketService) UnarchiveRocket(ctx context.Context, id string) (*models.Rocket, error) {
+	return s.repo.Update(ctx, id, func(rocket *models.Rocket) error {
+		rocket.ArchivedAt = nil
+		return nil
+	})
+}
+
+// CorrectRocket overwrites rocket fields by hand and records a ManualCorrection event describing the change
+func (s *rocketService) CorrectRocket(
+	ctx context.Context,
+	id string,
+	correction models.RocketCorrection,
+	actor string,
+) (*models.Rocket, error) {
+	payload := models.ManualCorrectionPayload{
+		Changes: make(map[string]models.FieldChange),
+		Reason:  correction.Reason,
+	}
+
+	rocket, err := s.repo.Update(ctx, id, func(rocket *models.Rocket) error {
+		if correction.Mission != nil && *correction.Mission != rocket.Mission {
+			payload.Changes["mission"] = models.FieldChange{From: rocket.Mission, To: *correction.Mission}
+			rocket.Mission = *correction.Mission
+		}
+		if correction.Speed != nil && *correction.Speed != rocket.Speed {
+			payload.Changes["speed"] = models.FieldChange{From: rocket.Speed, To: *correction.Speed}
+			rocket.Speed = *correction.Speed
+		}
+		if correction.Status != nil && *correction.Status != rocket.Status {
+			payload.Changes["status"] = models.FieldChange{From: rocket.Status, To: *correction.Status}
+			rocket.Status = *correction.Status
+			if rocket.Status != models.StatusExploded {
+				rocket.ExplosionReason = ""
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload.Changes) > 0 {
+		s.recordOperatorEvent(ctx, id, models.EventManualCorrection, actor, payload)
+	}
+	return rocket, nil
+}
+
+// recordOperatorEvent appends an event describing a change an operator made to a rocket. The change is already saved,
+// so the event is best-effort like the history of telemetry: a failure is logged rather than reported as a failure of
+// the change, which the audit log records anyway
+func (s *rocketService) recordOperatorEvent(ctx context.Context, id, eventType, actor string, payload any) {
+	encoded, err := json.Marshal(payload)
+	if err == nil {
+		err = s.events.Append(ctx, &models.RocketEvent{
+			Channel: id,
+			Type:    eventType,
+			Time:    time.Now().UTC(),
+			Payload: encoded,
+			Actor:   actor,
+		})
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to record event", "channel", id, "type", eventType, "error", err)
+	}
+}
+
+// SetRocketLabels replaces the labels of a rocket. Changes are recorded as a LabelsUpdated event
+func (s *rocketService) SetRocketLabels(
+	ctx context.Context,
+	id string,
+	labels models.Labels,
+	actor string,
+) (*models.Rocket, error) {
+	var previous models.Labels
+	rocket, err := s.repo.Update(ctx, id, func(rocket *models.Rocket) error {
+		previous = rocket.Labels
+		rocket.Labels = maps.Clone(labels)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if !maps.Equal(previous, labels) {
+		s.recordOperatorEvent(ctx, id, models.EventLabelsUpdated, actor, models.LabelsUpdatedPayload{From: previous, To: labels})
+	}
+	return rocket, nil
+}
+
+// RenameRocket assigns a display name to a rocket. Names are unique across rockets, ignoring case,
+// and renames are recorded as a Renamed event
+func (s *rocketService) RenameRocket(ctx context.Context, id, name, actor string) (*models.Rocket, error) {
+	var previous string
+	rocket, err := s.repo.Update(ctx, id, func(rocket *models.Rocket) error {
+		previous = rocket.Name
+		rocket.Name = name
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if previous != name {
+		s.recordOperatorEvent(ctx, id, models.EventRenamed, actor, models.RenamedPayload{From: previous, To: name})
+	}
+	return rocket, nil
+}
+
+// GetRocketEvents retrieves the recorded history of a rocket, oldest first
+func (s *rocketService) GetRocketEvents(ctx context.Context, id string) ([]*models.RocketEvent, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.events.FindByChannel(ctx, id), nil
+}
+
+// GetRocketTrack retrieves the recent positions of a rocket, oldest first
+func (s *rocketService) GetRocketTrack(ctx context.Context, id string) ([]models.TrackPoint, error) {
+	if _, err := s.repo.FindByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.tracks.FindByChannel(ctx, id), nil
+}
+
+// ListMissions retrieves every mission with its rocket counts, status breakdown and speed statistics.
+// Archived rockets are not taken into account
+func (s *rocketService) ListMissions(ctx context.Context) ([]*models.Mission, error) {
+	return s.missions.FindAll(ctx), nil
+}
+
+// GetMission retrieves a mission by its exact name
+func (s *rocketService) GetMission(ctx context.Context, name string) (*models.Mission, error) {
+	return s.missions.FindByName(ctx, name)
+}
+
+// ListRocketTypes retrieves the catalog of observed rocket types
+func (s *rocketService) ListRocketTypes(ctx context.Context) ([]*models.RocketType, error) {
+	return s.types.FindAll(ctx), nil
+}
+
+// ListMissionRockets retrieves the non-archived rockets assigned to a mission, ordered by the given sort keys
+func (s *rocketService) ListMissionRockets(
+	ctx context.Context,
+	name string,
+	sortFields []models.SortField,
+) ([]*models.Rocket, error) {
+	if _, err := s.missions.FindByName(ctx, name); err != nil {
+		return nil, err
+	}
+
+	// The mission filter is case-insensitive, keep only the exact mission
+	rockets := slices.DeleteFunc(s.repo.FindAll(ctx, models.RocketFilter{Mission: name}), func(rocket *models.Rocket) bool {
+		return rocket.Mission != name
+	})
+	sortRockets(rockets, sortFields)
+
+	return rockets, nil
+}
+
+// Summarize aggregates rocket counts, status breakdown and speed statistics per value of the groupBy field.
+// Archived rockets are not taken into account
+func (s *rocketService) Summarize(ctx context.Context, groupBy string) ([]*models.GroupSummary, error) {
+	keyOf, ok := groupKeys[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown grouping field: %s", groupBy)
+	}
+
+	groups := make(map[string]*groupStats)
+	for _, rocket := range s.repo.FindAll(ctx, models.RocketFilter{}) {
+		key := keyOf(rocket)
+		if groups[key] == nil {
+			groups[key] = newGroupStats()
+		}
+		groups[key].add(rocket)
+	}
+
+	summaries := make([]*models.GroupSummary, 0, len(groups))
+	for key, stats := range groups {
+		summaries = append(summaries, &models.GroupSummary{
+			Key:         key,
+			RocketCount: stats.count,
+			ByStatus:    stats.byStatus,
+			Speed:       stats.speed,
+		})
+	}
+
+	slices.SortFunc(summaries, func(a, b *models.GroupSummary) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+
+	return summaries, nil
+}
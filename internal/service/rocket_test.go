@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+	"github.com/ahernandez9/rockets/internal/repository/mocks"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const otherChannel = "7e2b6f1c-5a55-4c1b-9a33-0a7f7ad5c2d1"
+
+func TestEraseChannel(t *testing.T) {
+	tests := []struct {
+		name           string
+		launched       bool // The rocket of the channel is stored
+		expectedReport models.ErasureReport
+	}{
+		{
+			name:     "channel of a stored rocket",
+			launched: true,
+			expectedReport: models.ErasureReport{
+				Channel: testChannel, RocketDeleted: true, EventsDeleted: 2, TrackPointsDeleted: 1,
+			},
+		},
+		{
+			name:           "history left by a deleted rocket",
+			expectedReport: models.ErasureReport{Channel: testChannel, EventsDeleted: 2, TrackPointsDeleted: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rockets := inmemory.NewInMemoryRepository()
+			events := inmemory.NewInMemoryEventRepository(100)
+			tracks := inmemory.NewInMemoryTrackRepository(100)
+			s := NewRocketService(rockets, events, tracks, inmemory.NewMissionProjection(), inmemory.NewTypeCatalog())
+
+			for _, channel := range []string{testChannel, otherChannel} {
+				require.NoError(t, rockets.Save(ctx, &models.Rocket{ID: channel, Status: models.StatusActive}))
+				for number := range int64(2) {
+					require.NoError(t, events.Append(ctx, &models.RocketEvent{Channel: channel, Type: "RocketLaunched",
+						MessageNumber: number + 1, Time: launchedAt}))
+				}
+				require.NoError(t, tracks.Append(ctx, channel, models.TrackPoint{MessageNumber: 2, Time: launchedAt}))
+			}
+			if !tt.launched {
+				require.NoError(t, rockets.Delete(ctx, testChannel))
+			}
+
+			modified := rockets.LastModified(ctx)
+			report, err := s.EraseChannel(ctx, testChannel)
+			require.NoError(t, err)
+			if tt.launched {
+				assert.True(t, rockets.LastModified(ctx).After(modified), "deletions change the lists")
+			}
+
+			assert.NotZero(t, report.ErasedAt)
+			report.ErasedAt = tt.expectedReport.ErasedAt
+			assert.Equal(t, tt.expectedReport, *report)
+
+			_, err = rockets.FindByID(ctx, testChannel)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+			assert.Empty(t, events.FindByChannel(ctx, testChannel))
+			assert.Empty(t, tracks.FindByChannel(ctx, testChannel))
+
+			_, err = rockets.FindByID(ctx, otherChannel)
+			assert.NoError(t, err, "other channels are left alone")
+			assert.Len(t, events.FindByChannel(ctx, otherChannel), 2)
+			assert.Len(t, tracks.FindByChannel(ctx, otherChannel), 1)
+		})
+	}
+}
+
+func TestCorrectRocket(t *testing.T) {
+	mission, speed, exploded, active := "SHUTTLE_MIR", 1200, models.StatusExploded, models.StatusActive
+
+	tests := []struct {
+		name            string
+		correction      models.RocketCorrection
+		appendErr       error // Returned when recording the ManualCorrection event
+		expectedRocket  models.Rocket
+		expectedChanges []string // Fields the event records, none when no event is recorded
+	}{
+		{
+			name:            "changed fields recorded",
+			correction:      models.RocketCorrection{Mission: &mission, Speed: &speed, Reason: "wrong sensor"},
+			expectedRocket:  models.Rocket{Mission: "SHUTTLE_MIR", Speed: 1200, Status: models.StatusExploded},
+			expectedChanges: []string{"mission", "speed"},
+		},
+		{
+			name:           "unchanged fields not recorded",
+			correction:     models.RocketCorrection{Status: &exploded},
+			expectedRocket: models.Rocket{Mission: "ARTEMIS", Speed: 0, Status: models.StatusExploded},
+		},
+		{
+			name:            "explosion reason cleared with the status",
+			correction:      models.RocketCorrection{Status: &active},
+			expectedRocket:  models.Rocket{Mission: "ARTEMIS", Speed: 0, Status: models.StatusActive},
+			expectedChanges: []string{"status"},
+		},
+		{
+			name:            "correction kept when the event can't be recorded",
+			correction:      models.RocketCorrection{Speed: &speed},
+			appendErr:       errors.New("history unavailable"),
+			expectedRocket:  models.Rocket{Mission: "ARTEMIS", Speed: 1200, Status: models.StatusExploded},
+			expectedChanges: []string{"speed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rockets := inmemory.NewInMemoryRepository()
+			require.NoError(t, rockets.Save(ctx, &models.Rocket{ID: testChannel, Mission: "ARTEMIS",
+				Status: models.StatusExploded, ExplosionReason: "PRESSURE_VESSEL_FAILURE"}))
+
+			events := mocks.NewMockEventRepository(gomock.NewController(t))
+			var recorded *models.RocketEvent
+			if tt.expectedChanges != nil {
+				events.EXPECT().Append(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, event *models.RocketEvent) error {
+						recorded = event
+						return tt.appendErr
+					})
+			}
+			s := NewRocketService(rockets, events, inmemory.NewInMemoryTrackRepository(100),
+				inmemory.NewMissionProjection(), inmemory.NewTypeCatalog())
+
+			before, err := rockets.FindByID(ctx, testChannel)
+			require.NoError(t, err)
+
+			rocket, err := s.CorrectRocket(ctx, testChannel, tt.correction, "admin")
+			require.NoError(t, err)
+
+			stored, err := rockets.FindByID(ctx, testChannel)
+			require.NoError(t, err)
+			assert.Greater(t, stored.Version, before.Version, "corrections change the version, and so the ETag")
+			for _, got := range []*models.Rocket{rocket, stored} {
+				assert.Equal(t, tt.expectedRocket.Mission, got.Mission)
+				assert.Equal(t, tt.expectedRocket.Speed, got.Speed)
+				assert.Equal(t, tt.expectedRocket.Status, got.Status)
+				if got.Status != models.StatusExploded {
+					assert.Empty(t, got.ExplosionReason)
+				}
+			}
+
+			if tt.expectedChanges == nil {
+				return
+			}
+			require.NotNil(t, recorded)
+			assert.Equal(t, models.EventManualCorrection, recorded.Type)
+			assert.Equal(t, "admin", recorded.Actor)
+			var payload models.ManualCorrectionPayload
+			require.NoError(t, json.Unmarshal(recorded.Payload, &payload))
+			assert.Equal(t, tt.expectedChanges, slices.Sorted(maps.Keys(payload.Changes)))
+			assert.Equal(t, tt.correction.Reason, payload.Reason)
+		})
+	}
+
+	t.Run("unknown rocket", func(t *testing.T) {
+		s := NewRocketService(inmemory.NewInMemoryRepository(), mocks.NewMockEventRepository(gomock.NewController(t)),
+			inmemory.NewInMemoryTrackRepository(100), inmemory.NewMissionProjection(), inmemory.NewTypeCatalog())
+
+		_, err := s.CorrectRocket(context.Background(), testChannel, models.RocketCorrection{Speed: &speed}, "admin")
+		assert.ErrorIs(t, err, repository.ErrNotFound)
+	})
+}
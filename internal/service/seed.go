@@ -0,0 +1,105 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/validation"
+)
+
+// maxSeedLineSize bounds a line of a seed file in JSON Lines, as imports do
+const maxSeedLineSize = 1 << 20
+
+// SeedStats counts the records loaded from a seed file
+type SeedStats struct {
+	Rockets int
+	Events  int
+}
+
+// Seed loads an initial fleet into the repository, for demos, tests and reproducible bug reports. The seed is either
+// a JSON array of rockets, or a dump in JSON Lines as exported by GET /admin/state/export, events included. Seeding
+// stops at the first invalid record, naming it, so that a broken seed file is noticed at startup
+func Seed(ctx context.Context, backup BackupService, r io.Reader) (SeedStats, error) {
+	var stats SeedStats
+	load := func(record *models.BackupRecord) error {
+		if err := validation.ValidateBackupRecord(record); err != nil {
+			return err
+		}
+		// Rockets seeded without their last update are as fresh as the instance, rather than reaped at once
+		if record.Kind == models.RecordKindRocket && record.Rocket.LastUpdated.IsZero() {
+			record.Rocket.LastUpdated = time.Now()
+		}
+		if _, err := backup.Import(ctx, record, ImportOptions{}); err != nil {
+			return err
+		}
+		if record.Kind == models.RecordKindRocket {
+			stats.Rockets++
+		} else {
+			stats.Events++
+		}
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+	first, err := peekNonSpace(reader)
+	if err == io.EOF {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, err
+	}
+
+	if first == '[' {
+		var rockets []*models.Rocket
+		if err := json.NewDecoder(reader).Decode(&rockets); err != nil {
+			return stats, fmt.Errorf("invalid seed: %w", err)
+		}
+		for i, rocket := range rockets {
+			if rocket == nil {
+				return stats, fmt.Errorf("rocket %d: null", i+1)
+			}
+			if err := load(&models.BackupRecord{Kind: models.RecordKindRocket, Rocket: rocket}); err != nil {
+				return stats, fmt.Errorf("rocket %d: %w", i+1, err)
+			}
+		}
+		return stats, nil
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxSeedLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var record models.BackupRecord
+		if err := json.Unmarshal(raw, &record); err != nil {
+			return stats, fmt.Errorf("line %d: invalid JSON: %w", line, err)
+		}
+		if err := load(&record); err != nil {
+			return stats, fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return stats, scanner.Err()
+}
+
+// peekNonSpace skips leading white space and returns the next byte without consuming it
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, r.UnreadByte()
+	}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/models"
+)
+
+// rocketComparators compare two rockets by a single field in ascending order
+var rocketComparators = map[string]func(a, b *models.Rocket) int{
+	"id": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.ID, b.ID)
+	},
+	"name": func(a, b *models.Rocket) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	},
+	"type": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.Type, b.Type)
+	},
+	"speed": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.Speed, b.Speed)
+	},
+	"maxSpeed": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.MaxSpeed, b.MaxSpeed)
+	},
+	"averageSpeed": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.AverageSpeed, b.AverageSpeed)
+	},
+	"estimatedDistance": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.EstimatedDistance, b.EstimatedDistance)
+	},
+	"mission": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.Mission, b.Mission)
+	},
+	"status": func(a, b *models.Rocket) int {
+		return cmp.Compare(a.Status, b.Status)
+	},
+}
+
+// descendingFields sort in descending order unless prefixed with '+', speed as it always has
+var descendingFields = map[string]bool{"speed": true}
+
+// rankableFields are the numeric fields rockets can be ranked by in top-N queries
+var rankableFields = []string{"speed", "maxSpeed", "averageSpeed"}
+
+// RankableFields returns the names of the fields top-N queries can rank rockets by
+func RankableFields() []string {
+	return slices.Clone(rankableFields)
+}
+
+// SortableFields returns the names of the fields rockets can be sorted by
+func SortableFields() []string {
+	fields := make([]string, 0, len(rocketComparators))
+	for field := range rocketComparators {
+		fields = append(fields, field)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// ParseSort parses a sort specification such as "status,-speed" into sort keys.
+// A leading '-' sorts the field in descending order and a leading '+' in ascending order. Without either, speed sorts
+// in descending order and the other fields in ascending order
+func ParseSort(spec string) ([]models.SortField, error) {
+	parts := strings.Split(spec, ",")
+	fields := make([]models.SortField, 0, len(parts))
+
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+
+		field := models.SortField{Field: part, Descending: descendingFields[part]}
+		switch {
+		case strings.HasPrefix(part, "-"):
+			field = models.SortField{Field: part[1:], Descending: true}
+		case strings.HasPrefix(part, "+"):
+			field = models.SortField{Field: part[1:]}
+		}
+
+		if _, ok := rocketComparators[field.Field]; !ok {
+			return nil, fmt.Errorf("unknown sort field %q", part)
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+// sortRockets sorts rockets in place by each key in turn; ties keep their original order
+func sortRockets(rockets []*models.Rocket, sortFields []models.SortField) {
+	slices.SortStableFunc(rockets, func(a, b *models.Rocket) int {
+		for _, field := range sortFields {
+			result := rocketComparators[field.Field](a, b)
+			if field.Descending {
+				result = -result
+			}
+			if result != 0 {
+				return result
+			}
+		}
+		return 0
+	})
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+//go:generate go run go.uber.org/mock/mockgen -source=webhook.go -destination=mocks/mock_webhook_service.go -package=mocks
+
+// WebhookService defines the methods for managing webhook subscriptions
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, req models.WebhookRequest) (*models.Webhook, error)
+	GetWebhook(ctx context.Context, id string) (*models.Webhook, error)
+	ListWebhooks(ctx context.Context) ([]*models.Webhook, error)
+	DeleteWebhook(ctx context.Context, id string) error
+}
+
+// webhookService stores webhook subscriptions; deliveries are made by the webhook package
+type webhookService struct {
+	webhooks repository.WebhookRepository
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(webhooks repository.WebhookRepository) WebhookService {
+	return &webhookService{webhooks: webhooks}
+}
+
+// CreateWebhook registers a webhook, generating its secret when none is given.
+// The secret is only part of the returned webhook, later reads leave it out
+func (s *webhookService) CreateWebhook(ctx context.Context, req models.WebhookRequest) (*models.Webhook, error) {
+	webhook := &models.Webhook{
+		ID:        uuid.NewString(),
+		URL:       req.URL,
+		Events:    slices.Compact(slices.Sorted(slices.Values(req.Events))),
+		Secret:    req.Secret,
+		CreatedAt: time.Now().UTC(),
+	}
+	if len(webhook.Events) == 0 {
+		webhook.Events = slices.Clone(models.WebhookEvents)
+	}
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	if err := s.webhooks.Save(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// GetWebhook retrieves a webhook, without its secret
+func (s *webhookService) GetWebhook(ctx context.Context, id string) (*models.Webhook, error) {
+	webhook, err := s.webhooks.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = ""
+	return webhook, nil
+}
+
+// ListWebhooks retrieves every webhook, without their secrets, oldest first
+func (s *webhookService) ListWebhooks(ctx context.Context) ([]*models.Webhook, error) {
+	webhooks := s.webhooks.FindAll(ctx)
+	for _, webhook := range webhooks {
+		webhook.Secret = ""
+	}
+
+	return webhooks, nil
+}
+
+// DeleteWebhook unregisters a webhook. Deliveries already queued are still attempted
+func (s *webhookService) DeleteWebhook(ctx context.Context, id string) error {
+	return s.webhooks.Delete(ctx, id)
+}
@@ -0,0 +1,169 @@
+// Package simulator generates the telemetry of fake rockets in development, so that frontend and integration
+// developers get a lively API without running a producer. Each rocket launches, accelerates, burns fuel, climbs,
+// separates its stages and deploys its payload, then lands and is decommissioned or explodes, and is replaced by a new
+// rocket on another channel.
+package simulator
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var (
+	rocketTypes = []string{"Falcon-9", "Falcon-Heavy", "Starship", "Ariane-5", "Soyuz", "Electron"}
+	missions    = []string{"ARTEMIS", "APOLLO", "GEMINI", "SHUTTLE_MIR", "STARLINK", "HUBBLE_SERVICING"}
+	reasons     = []string{"PRESSURE_VESSEL_FAILURE", "ENGINE_FAILURE", "GUIDANCE_FAILURE", "STRUCTURAL_FAILURE"}
+	launchSites = []models.Position{{Latitude: 28.5721, Longitude: -80.648}, {Latitude: 5.2394, Longitude: -52.7683},
+		{Latitude: 45.965, Longitude: 63.305}, {Latitude: -39.2615, Longitude: 177.8649}}
+)
+
+// Options configures the simulated fleet
+type Options struct {
+	Rockets  int           // Rockets flying at once
+	Interval time.Duration // Delay between two messages of a rocket
+}
+
+// Publisher submits a message for processing, as service.MessageService.PublishMessage does
+type Publisher func(ctx context.Context, msg *models.RocketMessage) error
+
+// Simulator flies the fake rockets
+type Simulator struct {
+	publish Publisher
+	opts    Options
+	flights []*flight
+}
+
+// New creates a simulator publishing the messages of its rockets with publish
+func New(publish Publisher, opts Options) *Simulator {
+	s := &Simulator{publish: publish, opts: opts}
+	for range opts.Rockets {
+		s.flights = append(s.flights, newFlight())
+	}
+	return s
+}
+
+// Run publishes a message of every rocket each interval until ctx is done
+func (s *Simulator) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.Step(ctx, now)
+		}
+	}
+}
+
+// Step publishes the next message of every rocket, as of now, and replaces the rockets whose flight ended
+func (s *Simulator) Step(ctx context.Context, now time.Time) {
+	for i, f := range s.flights {
+		msg := f.next(now)
+		if err := s.publish(ctx, msg); err != nil {
+			slog.DebugContext(ctx, "Simulated message not published", "channel", msg.Metadata.Channel, "error", err)
+		}
+		if f.ended {
+			s.flights[i] = newFlight()
+		}
+	}
+}
+
+// flight is the state of a fake rocket between two of its messages
+type flight struct {
+	channel  string
+	number   int64
+	speed    int
+	fuel     float64
+	stage    int
+	position models.Position
+	deployed bool // The payload was deployed
+	landed   bool // Decommissioned by the next message
+	ended    bool
+}
+
+func newFlight() *flight {
+	return &flight{channel: uuid.NewString(), fuel: 100, position: pick(launchSites)}
+}
+
+// next returns the next message of the flight and advances its state
+func (f *flight) next(now time.Time) *models.RocketMessage {
+	f.number++
+	msg := &models.RocketMessage{Metadata: models.MessageMetadata{
+		Channel:       f.channel,
+		MessageNumber: f.number,
+		MessageTime:   now,
+	}}
+
+	var content any
+	roll := rand.Float64()
+	switch {
+	case f.number == 1:
+		f.speed = 300 + rand.IntN(500)
+		msg.Metadata.MessageType = "RocketLaunched"
+		content = models.RocketLaunchedMessage{Type: pick(rocketTypes), LaunchSpeed: f.speed, Mission: pick(missions)}
+	case f.landed:
+		f.ended = true
+		msg.Metadata.MessageType = "RocketDecommissioned"
+		content = models.RocketDecommissionedMessage{}
+	case roll < 0.01:
+		f.ended = true
+		msg.Metadata.MessageType = "RocketExploded"
+		content = models.RocketExplodedMessage{Reason: pick(reasons)}
+	case f.fuel <= 0:
+		// Out of fuel, the rocket comes back on its last stage
+		f.speed, f.position.Altitude, f.landed = 0, 0, true
+		msg.Metadata.MessageType = "RocketLanded"
+		content = models.RocketLandedMessage{}
+	case f.stage < 2 && f.fuel < float64(70-30*f.stage):
+		f.stage++
+		msg.Metadata.MessageType = "RocketStageSeparated"
+		content = models.RocketStageSeparatedMessage{Stage: f.stage}
+	case !f.deployed && f.stage == 2:
+		f.deployed = true
+		msg.Metadata.MessageType = "RocketPayloadDeployed"
+		content = models.RocketPayloadDeployedMessage{Name: "SAT-" + f.channel[:8]}
+	case roll < 0.3:
+		f.fuel = max(0, f.fuel-2-4*rand.Float64())
+		fuel := f.fuel
+		msg.Metadata.MessageType = "RocketFuelUpdated"
+		content = models.RocketFuelUpdatedMessage{FuelLevel: &fuel}
+	case roll < 0.55:
+		f.position.Latitude = clamp(f.position.Latitude+rand.Float64()-0.5, -90, 90)
+		f.position.Longitude = math.Mod(f.position.Longitude+rand.Float64()+540, 360) - 180 // Eastwards, around the globe
+		f.position.Altitude += float64(f.speed) * 2
+		lat, lon, alt := f.position.Latitude, f.position.Longitude, f.position.Altitude
+		msg.Metadata.MessageType = "RocketPositionUpdated"
+		content = models.RocketPositionUpdatedMessage{Latitude: &lat, Longitude: &lon, Altitude: &alt}
+	case roll < 0.9 || f.speed < 1000:
+		by := 200 + rand.IntN(1800)
+		f.speed += by
+		msg.Metadata.MessageType = "RocketSpeedIncreased"
+		content = models.RocketSpeedChangedMessage{By: by}
+	default:
+		by := 1 + rand.IntN(f.speed/4)
+		f.speed -= by
+		msg.Metadata.MessageType = "RocketSpeedDecreased"
+		content = models.RocketSpeedChangedMessage{By: by}
+	}
+	// The messages of known structures always encode
+	msg.Message, _ = json.Marshal(content)
+	return msg
+}
+
+func pick[T any](values []T) T {
+	return values[rand.IntN(len(values))]
+}
+
+func clamp(value, low, high float64) float64 {
+	return min(max(value, low), high)
+}
@@ -0,0 +1,41 @@
+package simulator
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/validation"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulatorFliesValidRockets(t *testing.T) {
+	flights := map[string][]*models.RocketMessage{}
+	s := New(func(_ context.Context, msg *models.RocketMessage) error {
+		flights[msg.Metadata.Channel] = append(flights[msg.Metadata.Channel], msg)
+		return nil
+	}, Options{Rockets: 5, Interval: time.Second})
+
+	now := time.Now()
+	for i := range 500 {
+		s.Step(context.Background(), now.Add(time.Duration(i)*time.Second))
+	}
+
+	assert.Greater(t, len(flights), 5, "rockets whose flight ended are replaced")
+	var ended int
+	for channel, msgs := range flights {
+		assert.Equal(t, "RocketLaunched", msgs[0].Metadata.MessageType, channel)
+		for i, msg := range msgs {
+			require.NoError(t, validation.ValidateMessage(msg), "%s message %d", channel, i+1)
+			assert.Equal(t, int64(i+1), msg.Metadata.MessageNumber)
+		}
+		if last := msgs[len(msgs)-1].Metadata.MessageType; slices.Contains([]string{"RocketExploded", "RocketDecommissioned"}, last) {
+			ended++
+		}
+	}
+	assert.Equal(t, len(flights)-5, ended, "only the rockets still flying have not ended")
+}
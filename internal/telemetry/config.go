@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Protocols the OTLP exporters can use
+const (
+	ProtocolHTTP = "http/protobuf"
+	ProtocolGRPC = "grpc"
+)
+
+// Config configures the export of traces and metrics over OTLP
+type Config struct {
+	Endpoint        string            // Base URL of the OTLP receiver, e.g. http://collector:4318; empty disables the export
+	Protocol        string            // ProtocolHTTP or ProtocolGRPC
+	Headers         map[string]string // Sent with every export, e.g. vendor API keys
+	SampleRatio     float64           // Fraction of new traces sampled; traces started by callers keep their decision
+	ServiceName     string
+	Attributes      map[string]string // Extra resource attributes, e.g. deployment.environment
+	MetricsInterval time.Duration     // Delay between two metrics exports
+}
+
+// ConfigFromEnv reads the configuration from the standard OpenTelemetry environment variables:
+// OTEL_EXPORTER_OTLP_ENDPOINT, OTEL_EXPORTER_OTLP_PROTOCOL, OTEL_EXPORTER_OTLP_HEADERS, OTEL_TRACES_SAMPLER_ARG,
+// OTEL_SERVICE_NAME, OTEL_RESOURCE_ATTRIBUTES and OTEL_METRIC_EXPORT_INTERVAL (milliseconds).
+// OTEL_SDK_DISABLED=true leaves the endpoint empty
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Endpoint:        os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT"),
+		Protocol:        envOrDefault("OTEL_EXPORTER_OTLP_PROTOCOL", ProtocolHTTP),
+		SampleRatio:     1,
+		ServiceName:     envOrDefault("OTEL_SERVICE_NAME", "rockets"),
+		MetricsInterval: time.Minute,
+	}
+
+	if disabled, _ := strconv.ParseBool(os.Getenv("OTEL_SDK_DISABLED")); disabled {
+		cfg.Endpoint = ""
+	}
+	if cfg.Endpoint != "" {
+		if u, err := url.Parse(cfg.Endpoint); err != nil || u.Host == "" {
+			return cfg, fmt.Errorf("OTEL_EXPORTER_OTLP_ENDPOINT must be a URL such as http://collector:4318, got: %s",
+				cfg.Endpoint)
+		}
+	}
+
+	if cfg.Protocol != ProtocolHTTP && cfg.Protocol != ProtocolGRPC {
+		return cfg, fmt.Errorf("OTEL_EXPORTER_OTLP_PROTOCOL must be %s or %s, got: %s", ProtocolHTTP, ProtocolGRPC,
+			cfg.Protocol)
+	}
+
+	var err error
+	if cfg.Headers, err = parseKeyValues(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")); err != nil {
+		return cfg, fmt.Errorf("OTEL_EXPORTER_OTLP_HEADERS: %w", err)
+	}
+	if cfg.Attributes, err = parseKeyValues(os.Getenv("OTEL_RESOURCE_ATTRIBUTES")); err != nil {
+		return cfg, fmt.Errorf("OTEL_RESOURCE_ATTRIBUTES: %w", err)
+	}
+
+	if value := os.Getenv("OTEL_TRACES_SAMPLER_ARG"); value != "" {
+		cfg.SampleRatio, err = strconv.ParseFloat(value, 64)
+		if err != nil || cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+			return cfg, fmt.Errorf("OTEL_TRACES_SAMPLER_ARG must be a ratio between 0 and 1, got: %s", value)
+		}
+	}
+
+	if value := os.Getenv("OTEL_METRIC_EXPORT_INTERVAL"); value != "" {
+		millis, err := strconv.Atoi(value)
+		if err != nil || millis <= 0 {
+			return cfg, fmt.Errorf("OTEL_METRIC_EXPORT_INTERVAL must be a positive number of milliseconds, got: %s", value)
+		}
+		cfg.MetricsInterval = time.Duration(millis) * time.Millisecond
+	}
+
+	return cfg, nil
+}
+
+// parseKeyValues parses a comma-separated list of key=value pairs, whose values may be URL-encoded
+func parseKeyValues(value string) (map[string]string, error) {
+	pairs := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+
+		key, val, ok := strings.Cut(item, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("expected key=value pairs, got: %s", item)
+		}
+		decoded, err := url.QueryUnescape(strings.TrimSpace(val))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", key, err)
+		}
+		pairs[strings.TrimSpace(key)] = decoded
+	}
+	return pairs, nil
+}
+
+// envOrDefault returns the value of an environment variable, or def when it is unset or empty
+func envOrDefault(key, def string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return def
+}
@@ -0,0 +1,65 @@
+package telemetry
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Run("defaults", func(t *testing.T) {
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, Config{
+			Protocol:        ProtocolHTTP,
+			Headers:         map[string]string{},
+			SampleRatio:     1,
+			ServiceName:     "rockets",
+			Attributes:      map[string]string{},
+			MetricsInterval: time.Minute,
+		}, cfg)
+	})
+
+	t.Run("exporter settings", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://otlp.example.com")
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "grpc")
+		t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "dd-api-key=secret, x-scope=a%3Db")
+		t.Setenv("OTEL_TRACES_SAMPLER_ARG", "0.25")
+		t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=staging")
+		t.Setenv("OTEL_METRIC_EXPORT_INTERVAL", "15000")
+
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, "https://otlp.example.com", cfg.Endpoint)
+		assert.Equal(t, ProtocolGRPC, cfg.Protocol)
+		assert.Equal(t, map[string]string{"dd-api-key": "secret", "x-scope": "a=b"}, cfg.Headers)
+		assert.Equal(t, 0.25, cfg.SampleRatio)
+		assert.Equal(t, map[string]string{"deployment.environment": "staging"}, cfg.Attributes)
+		assert.Equal(t, 15*time.Second, cfg.MetricsInterval)
+	})
+
+	t.Run("disabled SDK", func(t *testing.T) {
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "https://otlp.example.com")
+		t.Setenv("OTEL_SDK_DISABLED", "true")
+
+		cfg, err := ConfigFromEnv()
+		require.NoError(t, err)
+		assert.Empty(t, cfg.Endpoint)
+	})
+
+	for name, env := range map[string][2]string{
+		"unknown protocol":   {"OTEL_EXPORTER_OTLP_PROTOCOL", "http/json"},
+		"malformed headers":  {"OTEL_EXPORTER_OTLP_HEADERS", "dd-api-key"},
+		"ratio out of range": {"OTEL_TRACES_SAMPLER_ARG", "2"},
+		"negative interval":  {"OTEL_METRIC_EXPORT_INTERVAL", "-1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(env[0], env[1])
+
+			_, err := ConfigFromEnv()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package telemetry ships traces and metrics to an OpenTelemetry collector or vendor over OTLP, so that deployments
+// can send them to Tempo, Jaeger, Datadog and the like through configuration alone.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	prombridge "go.opentelemetry.io/contrib/bridges/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Setup installs the global tracer and meter providers exporting to the configured endpoint. Metrics are read from
+// gatherer, so that the same metrics are available to Prometheus scrapes and OTLP receivers; only traces are exported
+// when gatherer is nil. Nothing is exported while the endpoint is empty. The returned function flushes pending telemetry and must be called on shutdown
+func Setup(ctx context.Context, cfg Config, gatherer prometheus.Gatherer) (func(context.Context) error, error) {
+	// W3C trace context is propagated even when nothing is exported, so that callers' traces aren't broken
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if cfg.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceName(cfg.ServiceName)}
+	for key, value := range cfg.Attributes {
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	res, err := resource.New(ctx, resource.WithTelemetrySDK(), resource.WithAttributes(attrs...))
+	if err != nil {
+		return nil, err
+	}
+
+	spanExporter, err := newSpanExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	if gatherer == nil {
+		return tracerProvider.Shutdown, nil
+	}
+
+	metricExporter, err := newMetricExporter(ctx, cfg)
+	if err != nil {
+		return nil, errors.Join(err, tracerProvider.Shutdown(ctx))
+	}
+	reader := sdkmetric.NewPeriodicReader(metricExporter,
+		sdkmetric.WithInterval(cfg.MetricsInterval),
+		sdkmetric.WithProducer(prombridge.NewMetricProducer(prombridge.WithGatherer(gatherer))),
+	)
+	meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithResource(res))
+	otel.SetMeterProvider(meterProvider)
+
+	return func(ctx context.Context) error {
+		return errors.Join(tracerProvider.Shutdown(ctx), meterProvider.Shutdown(ctx))
+	}, nil
+}
+
+// newSpanExporter creates the OTLP exporter of traces for the configured protocol
+func newSpanExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	if cfg.Protocol == ProtocolGRPC {
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpointURL(cfg.Endpoint),
+			otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+
+	return otlptracehttp.New(ctx,
+		otlptracehttp.WithEndpointURL(signalURL(cfg.Endpoint, "traces")),
+		otlptracehttp.WithHeaders(cfg.Headers))
+}
+
+// newMetricExporter creates the OTLP exporter of metrics for the configured protocol
+func newMetricExporter(ctx context.Context, cfg Config) (sdkmetric.Exporter, error) {
+	if cfg.Protocol == ProtocolGRPC {
+		return otlpmetricgrpc.New(ctx,
+			otlpmetricgrpc.WithEndpointURL(cfg.Endpoint),
+			otlpmetricgrpc.WithHeaders(cfg.Headers))
+	}
+
+	return otlpmetrichttp.New(ctx,
+		otlpmetrichttp.WithEndpointURL(signalURL(cfg.Endpoint, "metrics")),
+		otlpmetrichttp.WithHeaders(cfg.Headers))
+}
+
+// signalURL returns the OTLP/HTTP URL of a signal under the base endpoint, e.g. http://collector:4318/v1/traces
+func signalURL(endpoint, signal string) string {
+	return strings.TrimSuffix(endpoint, "/") + "/v1/" + signal
+}
@@ -0,0 +1,96 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracedRepository records a span for every call to the rocket repository it wraps
+type tracedRepository struct {
+	repository.RocketRepository
+}
+
+// WrapRepository returns a rocket repository recording a span for every call to repo
+func WrapRepository(repo repository.RocketRepository) repository.RocketRepository {
+	return &tracedRepository{RocketRepository: repo}
+}
+
+func (r *tracedRepository) Save(ctx context.Context, rocket *models.Rocket) error {
+	ctx, span := startSpan(ctx, "Save", attribute.String("rocket.id", rocket.ID))
+	defer span.End()
+
+	return endSpan(span, r.RocketRepository.Save(ctx, rocket))
+}
+
+func (r *tracedRepository) FindByID(ctx context.Context, id string) (*models.Rocket, error) {
+	ctx, span := startSpan(ctx, "FindByID", attribute.String("rocket.id", id))
+	defer span.End()
+
+	rocket, err := r.RocketRepository.FindByID(ctx, id)
+	return rocket, endSpan(span, err)
+}
+
+func (r *tracedRepository) FindByName(ctx context.Context, name string) (*models.Rocket, error) {
+	ctx, span := startSpan(ctx, "FindByName")
+	defer span.End()
+
+	rocket, err := r.RocketRepository.FindByName(ctx, name)
+	return rocket, endSpan(span, err)
+}
+
+func (r *tracedRepository) Update(
+	ctx context.Context,
+	id string,
+	fn func(rocket *models.Rocket) error,
+) (*models.Rocket, error) {
+	ctx, span := startSpan(ctx, "Update", attribute.String("rocket.id", id))
+	defer span.End()
+
+	rocket, err := r.RocketRepository.Update(ctx, id, fn)
+	return rocket, endSpan(span, err)
+}
+
+func (r *tracedRepository) FindAll(ctx context.Context, filter models.RocketFilter) []*models.Rocket {
+	ctx, span := startSpan(ctx, "FindAll")
+	defer span.End()
+
+	rockets := r.RocketRepository.FindAll(ctx, filter)
+	span.SetAttributes(attribute.Int("rockets.count", len(rockets)))
+	return rockets
+}
+
+func (r *tracedRepository) GetCount(ctx context.Context) int {
+	ctx, span := startSpan(ctx, "GetCount")
+	defer span.End()
+
+	return r.RocketRepository.GetCount(ctx)
+}
+
+func (r *tracedRepository) Delete(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "Delete", attribute.String("rocket.id", id))
+	defer span.End()
+
+	return endSpan(span, r.RocketRepository.Delete(ctx, id))
+}
+
+// startSpan starts the span of a repository operation
+func startSpan(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, "RocketRepository."+operation,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...))
+}
+
+// endSpan marks the span as failed when err is set, and returns err
+func endSpan(span trace.Span, err error) error {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return err
+}
@@ -0,0 +1,16 @@
+// Package tracing instruments the service with OpenTelemetry spans, so that a single trace follows a message from the
+// request that published it through processing down to the repository calls that stored it.
+package tracing
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans created by this service
+const instrumentationName = "github.com/ahernandez9/rockets"
+
+// Tracer returns the tracer instrumenting the service. It follows the global provider, installed by telemetry.Setup
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
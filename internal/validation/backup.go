@@ -0,0 +1,51 @@
+package validation
+
+import (
+	"fmt"
+
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ValidateBackupRecord validates a record of an imported dump or seed file, normalizing the status of rockets
+func ValidateBackupRecord(record *models.BackupRecord) error {
+	switch record.Kind {
+	case models.RecordKindRocket:
+		rocket := record.Rocket
+		if rocket == nil {
+			return fmt.Errorf("rocket record: 'rocket' field is required")
+		}
+		if _, err := uuid.Parse(rocket.ID); err != nil {
+			return fmt.Errorf("rocket: 'id' must be a valid UUID, got: %s", rocket.ID)
+		}
+		if rocket.Type == "" {
+			return fmt.Errorf("rocket: 'type' field is required")
+		}
+		if rocket.Mission == "" {
+			return fmt.Errorf("rocket: 'mission' field is required")
+		}
+		status, ok := models.ParseStatus(string(rocket.Status))
+		if !ok {
+			return fmt.Errorf("rocket: 'status' must be one of: ACTIVE, EXPLODED, LANDED, DECOMMISSIONED, got: %s", rocket.Status)
+		}
+		rocket.Status = status
+
+	case models.RecordKindEvent:
+		event := record.Event
+		if event == nil {
+			return fmt.Errorf("event record: 'event' field is required")
+		}
+		if _, err := uuid.Parse(event.Channel); err != nil {
+			return fmt.Errorf("event: 'channel' must be a valid UUID, got: %s", event.Channel)
+		}
+		if event.Type == "" {
+			return fmt.Errorf("event: 'type' field is required")
+		}
+
+	default:
+		return fmt.Errorf("'kind' must be one of: %s, %s, got: %s", models.RecordKindRocket, models.RecordKindEvent, record.Kind)
+	}
+
+	return nil
+}
@@ -0,0 +1,193 @@
+// Package validation holds the validation rules for incoming telemetry messages, shared by every transport, and for
+// imported state
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ahernandez9/rockets/internal/jsoncodec"
+	"github.com/ahernandez9/rockets/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidMessageType is returned for messages whose messageType is not a known telemetry message
+var ErrInvalidMessageType = errors.New("invalid messageType")
+
+// ValidateMessage validates both the metadata and the content of a message
+func ValidateMessage(msg *models.RocketMessage) error {
+	if err := ValidateMessageMetadata(msg.Metadata); err != nil {
+		return err
+	}
+	return ValidateMessageContent(msg)
+}
+
+// messageTypes are the known telemetry message types, each applied by the message processor
+var messageTypes = []string{
+	"RocketLaunched",
+	"RocketSpeedIncreased",
+	"RocketSpeedDecreased",
+	"RocketExploded",
+	"RocketLanded",
+	"RocketDecommissioned",
+	"RocketMissionChanged",
+	"RocketFuelUpdated",
+	"RocketPositionUpdated",
+	"RocketStageSeparated",
+	"RocketPayloadDeployed",
+}
+
+// MessageTypes returns the known telemetry message types
+func MessageTypes() []string {
+	return slices.Clone(messageTypes)
+}
+
+// IsKnownMessageType tells whether messageType is a known telemetry message type
+func IsKnownMessageType(messageType string) bool {
+	return slices.Contains(messageTypes, messageType)
+}
+
+// ValidateMessageMetadata validates the metadata fields
+func ValidateMessageMetadata(metadata models.MessageMetadata) error {
+	if _, err := uuid.Parse(metadata.Channel); err != nil {
+		return fmt.Errorf("channel must be a valid UUID, got: %s", metadata.Channel)
+	}
+
+	if metadata.MessageNumber <= 0 {
+		return fmt.Errorf("messageNumber must be positive, got: %d", metadata.MessageNumber)
+	}
+
+	if metadata.MessageTime.IsZero() {
+		return fmt.Errorf("messageTime is required and cannot be zero")
+	}
+
+	if metadata.MessageType == "" {
+		return fmt.Errorf("messageType is required and cannot be empty")
+	}
+
+	if !IsKnownMessageType(metadata.MessageType) {
+		return fmt.Errorf("%w: must be one of: %s, got: %s", ErrInvalidMessageType, strings.Join(messageTypes, ", "),
+			metadata.MessageType)
+	}
+
+	return nil
+}
+
+// ValidateMessageContent validates the message content based on type
+func ValidateMessageContent(msg *models.RocketMessage) error {
+	if len(msg.Message) == 0 || string(msg.Message) == "null" {
+		return fmt.Errorf("message content is required")
+	}
+	msgBytes := msg.Message
+
+	switch msg.Metadata.MessageType {
+	case "RocketLaunched":
+		var launchMsg models.RocketLaunchedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &launchMsg); err != nil {
+			return fmt.Errorf("invalid RocketLaunched message: %w", err)
+		}
+		if launchMsg.Type == "" {
+			return fmt.Errorf("RocketLaunched message: 'type' field is required")
+		}
+		if !isAllowedRocketType(launchMsg.Type) {
+			return fmt.Errorf("RocketLaunched message: 'type' must be an allowed rocket type, got: %s", launchMsg.Type)
+		}
+		if launchMsg.LaunchSpeed < 0 {
+			return fmt.Errorf("RocketLaunched message: 'launchSpeed' must be non-negative")
+		}
+		if launchMsg.Mission == "" {
+			return fmt.Errorf("RocketLaunched message: 'mission' field is required")
+		}
+
+	case "RocketSpeedIncreased":
+		var speedMsg models.RocketSpeedChangedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &speedMsg); err != nil {
+			return fmt.Errorf("invalid RocketSpeedIncreased message: %w", err)
+		}
+		if speedMsg.By <= 0 {
+			return fmt.Errorf("RocketSpeedIncreased message: 'by' must be positive")
+		}
+
+	case "RocketSpeedDecreased":
+		var speedMsg models.RocketSpeedChangedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &speedMsg); err != nil {
+			return fmt.Errorf("invalid RocketSpeedDecreased message: %w", err)
+		}
+		if speedMsg.By <= 0 {
+			return fmt.Errorf("RocketSpeedDecreased message: 'by' must be positive (will be subtracted)")
+		}
+
+	case "RocketExploded":
+		var explodedMsg models.RocketExplodedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &explodedMsg); err != nil {
+			return fmt.Errorf("invalid RocketExploded message: %w", err)
+		}
+		if explodedMsg.Reason == "" {
+			return fmt.Errorf("RocketExploded message: 'reason' field is required")
+		}
+
+	case "RocketMissionChanged":
+		var missionMsg models.RocketMissionChangedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &missionMsg); err != nil {
+			return fmt.Errorf("invalid RocketMissionChanged message: %w", err)
+		}
+		if missionMsg.NewMission == "" {
+			return fmt.Errorf("RocketMissionChanged message: 'newMission' field is required")
+		}
+
+	case "RocketFuelUpdated":
+		var fuelMsg models.RocketFuelUpdatedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &fuelMsg); err != nil {
+			return fmt.Errorf("invalid RocketFuelUpdated message: %w", err)
+		}
+		if fuelMsg.FuelLevel == nil {
+			return fmt.Errorf("RocketFuelUpdated message: 'fuelLevel' field is required")
+		}
+		if *fuelMsg.FuelLevel < 0 || *fuelMsg.FuelLevel > 100 {
+			return fmt.Errorf("RocketFuelUpdated message: 'fuelLevel' must be between 0 and 100, got: %g", *fuelMsg.FuelLevel)
+		}
+
+	case "RocketPositionUpdated":
+		var positionMsg models.RocketPositionUpdatedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &positionMsg); err != nil {
+			return fmt.Errorf("invalid RocketPositionUpdated message: %w", err)
+		}
+		if positionMsg.Latitude == nil || positionMsg.Longitude == nil || positionMsg.Altitude == nil {
+			return fmt.Errorf("RocketPositionUpdated message: 'latitude', 'longitude' and 'altitude' fields are required")
+		}
+		if *positionMsg.Latitude < -90 || *positionMsg.Latitude > 90 {
+			return fmt.Errorf("RocketPositionUpdated message: 'latitude' must be between -90 and 90, got: %g",
+				*positionMsg.Latitude)
+		}
+		if *positionMsg.Longitude < -180 || *positionMsg.Longitude > 180 {
+			return fmt.Errorf("RocketPositionUpdated message: 'longitude' must be between -180 and 180, got: %g",
+				*positionMsg.Longitude)
+		}
+		if *positionMsg.Altitude < 0 {
+			return fmt.Errorf("RocketPositionUpdated message: 'altitude' must be non-negative")
+		}
+
+	case "RocketStageSeparated":
+		var stageMsg models.RocketStageSeparatedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &stageMsg); err != nil {
+			return fmt.Errorf("invalid RocketStageSeparated message: %w", err)
+		}
+		if stageMsg.Stage <= 0 {
+			return fmt.Errorf("RocketStageSeparated message: 'stage' must be positive")
+		}
+
+	case "RocketPayloadDeployed":
+		var payloadMsg models.RocketPayloadDeployedMessage
+		if err := jsoncodec.Unmarshal(msgBytes, &payloadMsg); err != nil {
+			return fmt.Errorf("invalid RocketPayloadDeployed message: %w", err)
+		}
+		if payloadMsg.Name == "" {
+			return fmt.Errorf("RocketPayloadDeployed message: 'name' field is required")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package validation
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// allowedRocketTypes holds the lower-cased rocket types launches are restricted to, nil when any type is accepted
+var allowedRocketTypes atomic.Pointer[map[string]struct{}]
+
+// SetAllowedRocketTypes restricts RocketLaunched messages to the given rocket types, compared case-insensitively.
+// An empty list accepts any type
+func SetAllowedRocketTypes(types []string) {
+	if len(types) == 0 {
+		allowedRocketTypes.Store(nil)
+		return
+	}
+
+	allowed := make(map[string]struct{}, len(types))
+	for _, rocketType := range types {
+		allowed[strings.ToLower(rocketType)] = struct{}{}
+	}
+	allowedRocketTypes.Store(&allowed)
+}
+
+// isAllowedRocketType reports whether launches of the rocket type are accepted
+func isAllowedRocketType(rocketType string) bool {
+	allowed := allowedRocketTypes.Load()
+	if allowed == nil {
+		return true
+	}
+
+	_, ok := (*allowed)[strings.ToLower(rocketType)]
+	return ok
+}
@@ -0,0 +1,215 @@
+// Package webhook delivers rocket state changes to the registered webhooks as signed HTTP POSTs.
+//
+// Each delivery carries the change as JSON, the event name in the X-Rockets-Event header, the change ID in
+// X-Rockets-Delivery and an HMAC-SHA256 of the body keyed with the webhook secret in X-Rockets-Signature,
+// formatted as "sha256=<hex>". Deliveries to a webhook are made in order, one at a time.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/feed"
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository"
+)
+
+// Headers set on every delivery
+const (
+	SignatureHeader = "X-Rockets-Signature"
+	EventHeader     = "X-Rockets-Event"
+	DeliveryHeader  = "X-Rockets-Delivery"
+)
+
+// Options tunes the deliveries
+type Options struct {
+	QueueSize   int           // Deliveries waiting per webhook; further ones are dropped
+	MaxAttempts int           // Attempts per delivery before giving up
+	Backoff     time.Duration // Delay before the first retry, doubled after each failed attempt
+	Timeout     time.Duration // Bounds a single attempt
+}
+
+// DefaultOptions returns the delivery settings used when none are configured
+func DefaultOptions() Options {
+	return Options{
+		QueueSize:   256,
+		MaxAttempts: 5,
+		Backoff:     time.Second,
+		Timeout:     10 * time.Second,
+	}
+}
+
+// delivery is a change to POST to a webhook
+type delivery struct {
+	webhook *models.Webhook
+	event   string
+	id      uint64
+	body    []byte
+}
+
+// Deliverer fans out rocket changes to the webhooks subscribed to them
+type Deliverer struct {
+	webhooks repository.WebhookRepository
+	opts     Options
+	client   *http.Client
+
+	mu     sync.Mutex
+	queues map[string]chan delivery // webhook ID -> pending deliveries
+}
+
+// NewDeliverer creates a deliverer for the webhooks of the repository. Call Run to start delivering
+func NewDeliverer(webhooks repository.WebhookRepository, opts Options) *Deliverer {
+	return &Deliverer{
+		webhooks: webhooks,
+		opts:     opts,
+		client:   &http.Client{Timeout: opts.Timeout},
+		queues:   make(map[string]chan delivery),
+	}
+}
+
+// Run delivers the changes published on the feed until ctx is done
+func (d *Deliverer) Run(ctx context.Context, changes *feed.Feed) {
+	changes.Follow(ctx, func(change models.RocketChange) {
+		d.dispatch(ctx, change)
+	})
+}
+
+// dispatch queues a change for every webhook subscribed to its event
+func (d *Deliverer) dispatch(ctx context.Context, change models.RocketChange) {
+	webhooks := d.webhooks.FindAll(ctx)
+	d.retire(webhooks)
+
+	event := "rocket." + change.Kind
+	var body []byte
+	for _, webhook := range webhooks {
+		if !slices.Contains(webhook.Events, event) {
+			continue
+		}
+
+		if body == nil {
+			var err error
+			if body, err = json.Marshal(change); err != nil {
+				slog.ErrorContext(ctx, "Failed to encode change for webhooks", "change", change.ID, "error", err)
+				return
+			}
+		}
+
+		select {
+		case d.queue(ctx, webhook.ID) <- delivery{webhook: webhook, event: event, id: change.ID, body: body}:
+		default:
+			slog.WarnContext(ctx, "Webhook queue full, dropping change", "change", change.ID, "webhook", webhook.ID)
+		}
+	}
+}
+
+// queue returns the delivery queue of a webhook, starting its worker on first use
+func (d *Deliverer) queue(ctx context.Context, id string) chan delivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	queue, exists := d.queues[id]
+	if !exists {
+		queue = make(chan delivery, d.opts.QueueSize)
+		d.queues[id] = queue
+		go d.work(ctx, queue)
+	}
+
+	return queue
+}
+
+// retire stops the workers of the webhooks that were deleted, once their pending deliveries are done
+func (d *Deliverer) retire(webhooks []*models.Webhook) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id, queue := range d.queues {
+		registered := slices.ContainsFunc(webhooks, func(webhook *models.Webhook) bool {
+			return webhook.ID == id
+		})
+		if !registered {
+			close(queue)
+			delete(d.queues, id)
+		}
+	}
+}
+
+// work delivers the queued changes of a webhook in order until its queue is closed or ctx is done
+func (d *Deliverer) work(ctx context.Context, queue <-chan delivery) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case next, ok := <-queue:
+			if !ok {
+				return
+			}
+			d.deliver(ctx, next)
+		}
+	}
+}
+
+// deliver POSTs a change to a webhook, retrying with exponential backoff
+func (d *Deliverer) deliver(ctx context.Context, next delivery) {
+	backoff := d.opts.Backoff
+
+	var err error
+	for attempt := 1; attempt <= d.opts.MaxAttempts; attempt++ {
+		if err = d.post(ctx, next); err == nil {
+			return
+		}
+
+		if attempt == d.opts.MaxAttempts {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+
+	slog.ErrorContext(ctx, "Giving up on webhook delivery", "change", next.id, "webhook", next.webhook.ID,
+		"attempts", d.opts.MaxAttempts, "error", err)
+}
+
+// post makes a single delivery attempt, failing on any non-2xx response
+func (d *Deliverer) post(ctx context.Context, next delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, next.webhook.URL, bytes.NewReader(next.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, next.event)
+	req.Header.Set(DeliveryHeader, strconv.FormatUint(next.id, 10))
+	req.Header.Set(SignatureHeader, Sign(next.webhook.Secret, next.body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Sign returns the signature of a delivery body, as sent in the X-Rockets-Signature header
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
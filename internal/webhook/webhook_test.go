@@ -0,0 +1,70 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ahernandez9/rockets/internal/models"
+	"github.com/ahernandez9/rockets/internal/repository/inmemory"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	assert.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestDeliverer(t *testing.T) {
+	type request struct {
+		header http.Header
+		body   []byte
+	}
+	requests := make(chan request, 10)
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		requests <- request{header: r.Header, body: body}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	webhooks := inmemory.NewInMemoryWebhookRepository()
+	require.NoError(t, webhooks.Save(ctx, &models.Webhook{
+		ID:     "updates",
+		URL:    server.URL,
+		Events: []string{models.WebhookEventRocketUpdated},
+		Secret: "secret",
+	}))
+
+	d := NewDeliverer(webhooks, Options{QueueSize: 10, MaxAttempts: 3, Backoff: time.Millisecond, Timeout: time.Second})
+	d.dispatch(ctx, models.RocketChange{ID: 1, Kind: "deleted", RocketID: "rocket"})
+	d.dispatch(ctx, models.RocketChange{ID: 2, Kind: "updated", RocketID: "rocket"})
+
+	select {
+	case got := <-requests:
+		assert.Equal(t, models.WebhookEventRocketUpdated, got.header.Get(EventHeader))
+		assert.Equal(t, "2", got.header.Get(DeliveryHeader))
+		assert.Equal(t, Sign("secret", got.body), got.header.Get(SignatureHeader))
+	case <-time.After(time.Second):
+		t.Fatal("change was not delivered")
+	}
+
+	select {
+	case got := <-requests:
+		t.Fatalf("unexpected delivery %s", got.body)
+	case <-time.After(50 * time.Millisecond):
+	}
+}